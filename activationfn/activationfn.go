@@ -7,160 +7,183 @@ import (
 )
 
 // ActivationFunction is an alias for the type of the activation functions
+// The ActivationFn32 and DeactivationFn32 functions are the single-precision counterparts of ActivationFn and DeactivationFn.
 type ActivationFunction struct {
-	Name                         string
-	ActivationFn, DeactivationFn func(*matrix.Matrix) matrix.ApplyFn
+	Name                             string
+	ActivationFn, DeactivationFn     matrix.VectorFn
+	ActivationFn32, DeactivationFn32 matrix.VectorFn32
 }
 
-func calculateApplySum(s []float64, aFn func(float64) float64) float64 {
-	sum := 0.0
-	for _, v := range s {
-		sum += aFn(v)
+// elementWise32 returns a VectorFn32 that applies "fn" to each element, the elements are converted to float64 for the calculation.
+func elementWise32(fn matrix.ApplyFn) matrix.VectorFn32 {
+	return func(dst, src *matrix.Matrix32) {
+		dVals := dst.Values[:len(src.Values)]
+		for idx, v := range src.Values {
+			dVals[idx] = float32(fn(float64(v)))
+		}
 	}
+}
 
-	return sum
+func sigmoid(v float64) float64 {
+	return 1 / (1 + math.Exp(-v))
 }
 
-func calculateMax(s []float64) float64 {
-	max := math.Inf(-1)
-	for _, v := range s {
-		max = math.Max(max, v)
+func dSigmoid(v float64) float64 {
+	v = sigmoid(v)
+	return v * (1 - v)
+}
+
+func dTanH(v float64) float64 {
+	return 1 - math.Pow(math.Tanh(v), 2)
+}
+
+func relu(v float64) float64 {
+	return math.Max(0, v)
+}
+
+func dReLU(v float64) float64 {
+	if v >= 0 {
+		return 1
+	} else {
+		return 0
 	}
+}
 
-	return max
+func leaky(v float64) float64 {
+	if v >= 0 {
+		return v
+	} else {
+		return 0.01 * v
+	}
+}
+
+func dLeaky(v float64) float64 {
+	if v >= 0 {
+		return 1
+	} else {
+		return 0.01
+	}
 }
 
 // LogisticSigmoid ...
 var logisticSigmoid *ActivationFunction = &ActivationFunction{
 	Name: "LogisticSigmoid",
-	ActivationFn: func(_ *matrix.Matrix) matrix.ApplyFn {
-		return func(v float64, _ int, _ []float64) float64 {
-			return 1 / (1 + math.Exp(-v))
-		}
+	ActivationFn: func(dst, src *matrix.Matrix) {
+		dst.Apply(sigmoid, src)
 	},
-	DeactivationFn: func(_ *matrix.Matrix) matrix.ApplyFn {
-		return func(v float64, _ int, _ []float64) float64 {
-			v = (1 / (1 + math.Exp(-v)))
-			return v * (1 - v)
-		}
+	DeactivationFn: func(dst, src *matrix.Matrix) {
+		dst.Apply(dSigmoid, src)
 	},
+	ActivationFn32:   elementWise32(sigmoid),
+	DeactivationFn32: elementWise32(dSigmoid),
 }
 
 // TanH ...
 var tanH *ActivationFunction = &ActivationFunction{
 	Name: "TanH",
-	ActivationFn: func(_ *matrix.Matrix) matrix.ApplyFn {
-		return func(v float64, _ int, _ []float64) float64 {
-			return math.Tanh(v)
-		}
+	ActivationFn: func(dst, src *matrix.Matrix) {
+		dst.Apply(math.Tanh, src)
 	},
-	DeactivationFn: func(_ *matrix.Matrix) matrix.ApplyFn {
-		return func(v float64, _ int, _ []float64) float64 {
-			return 1 - math.Pow(math.Tanh(v), 2)
-		}
+	DeactivationFn: func(dst, src *matrix.Matrix) {
+		dst.Apply(dTanH, src)
 	},
+	ActivationFn32:   elementWise32(math.Tanh),
+	DeactivationFn32: elementWise32(dTanH),
 }
 
 // ReLU ...
 var reLU *ActivationFunction = &ActivationFunction{
 	Name: "ReLU",
-	ActivationFn: func(_ *matrix.Matrix) matrix.ApplyFn {
-		return func(v float64, _ int, _ []float64) float64 {
-			return math.Max(0, v)
-		}
+	ActivationFn: func(dst, src *matrix.Matrix) {
+		dst.Apply(relu, src)
 	},
-	DeactivationFn: func(_ *matrix.Matrix) matrix.ApplyFn {
-		return func(v float64, _ int, _ []float64) float64 {
-			if v >= 0 {
-				return 1
-			} else {
-				return 0
-			}
-		}
+	DeactivationFn: func(dst, src *matrix.Matrix) {
+		dst.Apply(dReLU, src)
 	},
+	ActivationFn32:   elementWise32(relu),
+	DeactivationFn32: elementWise32(dReLU),
 }
 
 // LeakyReLU ...
 var leakyReLU *ActivationFunction = &ActivationFunction{
 	Name: "LeakyReLU",
-	ActivationFn: func(_ *matrix.Matrix) matrix.ApplyFn {
-		return func(v float64, _ int, _ []float64) float64 {
-			if v >= 0 {
-				return v
-			} else {
-				return 0.01 * v
-			}
-		}
+	ActivationFn: func(dst, src *matrix.Matrix) {
+		dst.Apply(leaky, src)
 	},
-	DeactivationFn: func(_ *matrix.Matrix) matrix.ApplyFn {
-		return func(v float64, _ int, _ []float64) float64 {
-			if v >= 0 {
-				return 1
-			} else {
-				return 0.01
-			}
-		}
+	DeactivationFn: func(dst, src *matrix.Matrix) {
+		dst.Apply(dLeaky, src)
 	},
+	ActivationFn32:   elementWise32(leaky),
+	DeactivationFn32: elementWise32(dLeaky),
 }
 
 // Softmax ...
 var softmax *ActivationFunction = &ActivationFunction{
 	Name: "Softmax",
-	ActivationFn: func(m *matrix.Matrix) matrix.ApplyFn {
-		sum := calculateApplySum(m.Values, func(v float64) float64 {
-			return math.Exp(v)
-		})
-		return func(v float64, _ int, _ []float64) float64 {
-			return math.Exp(v) / sum
-		}
+	ActivationFn: func(dst, src *matrix.Matrix) {
+		dst.Exp(src)
+		dst.Scale(1/dst.Sum(), dst)
 	},
-	DeactivationFn: func(m *matrix.Matrix) matrix.ApplyFn {
-		sum := calculateApplySum(m.Values, func(v float64) float64 {
-			return math.Exp(v)
-		})
-		var vF float64
-		return func(v float64, idx int, _ []float64) float64 {
-			v = math.Exp(v) / sum
-			if idx == 0 {
-				vF = v
-				p := v * (1 - v)
-				return p
-			} else {
-				return -vF * v
-			}
-		}
+	DeactivationFn: func(dst, src *matrix.Matrix) {
+		dst.Exp(src)
+		sum := dst.Sum()
+		vF := dst.Values[0] / sum
+		dst.Scale(-vF/sum, dst)
+		dst.Values[0] = vF * (1 - vF)
+	},
+	ActivationFn32: func(dst, src *matrix.Matrix32) {
+		dst.Exp(src)
+		dst.Scale(1/dst.Sum(), dst)
+	},
+	DeactivationFn32: func(dst, src *matrix.Matrix32) {
+		dst.Exp(src)
+		sum := dst.Sum()
+		vF := dst.Values[0] / sum
+		dst.Scale(-vF/sum, dst)
+		dst.Values[0] = vF * (1 - vF)
 	},
 }
 
+// stableExp32 calculates "e**(x - max)" for each element "x" of "src" into "dst", and returns the sum of the results.
+func stableExp32(dst, src *matrix.Matrix32) float32 {
+	max := src.Max()
+	dVals := dst.Values[:len(src.Values)]
+	for idx, v := range src.Values {
+		dVals[idx] = float32(math.Exp(float64(v - max)))
+	}
+
+	return dst.Sum()
+}
+
 // StableSoftmax ...
 var stableSoftmax *ActivationFunction = &ActivationFunction{
 	Name: "StableSoftmax",
-	ActivationFn: func(m *matrix.Matrix) matrix.ApplyFn {
-		max := calculateMax(m.Values)
-		sum := calculateApplySum(m.Values, func(v float64) float64 {
+	ActivationFn: func(dst, src *matrix.Matrix) {
+		max := src.Max()
+		dst.Apply(func(v float64) float64 {
 			return math.Exp(v - max)
-		})
-		return func(v float64, _ int, _ []float64) float64 {
-			return math.Exp(v-max) / sum
-		}
+		}, src)
+		dst.Scale(1/dst.Sum(), dst)
 	},
-	DeactivationFn: func(m *matrix.Matrix) matrix.ApplyFn {
-		max := -calculateMax(m.Values)
-		sum := calculateApplySum(m.Values, func(v float64) float64 {
-			return math.Exp(v + max)
-		})
-		var vF float64
-		return func(v float64, idx int, _ []float64) float64 {
-			v = math.Exp(v+max) / sum
-			v = v / sum
-			if idx == 0 {
-				vF = v
-				p := v * (1 - v)
-				return p
-			} else {
-				return -vF * v
-			}
-		}
+	DeactivationFn: func(dst, src *matrix.Matrix) {
+		max := src.Max()
+		dst.Apply(func(v float64) float64 {
+			return math.Exp(v - max)
+		}, src)
+		sum := dst.Sum()
+		vF := (dst.Values[0] / sum) / sum
+		dst.Scale(-vF/(sum*sum), dst)
+		dst.Values[0] = vF * (1 - vF)
+	},
+	ActivationFn32: func(dst, src *matrix.Matrix32) {
+		sum := stableExp32(dst, src)
+		dst.Scale(1/sum, dst)
+	},
+	DeactivationFn32: func(dst, src *matrix.Matrix32) {
+		sum := stableExp32(dst, src)
+		vF := (dst.Values[0] / sum) / sum
+		dst.Scale(-vF/(sum*sum), dst)
+		dst.Values[0] = vF * (1 - vF)
 	},
 }
 
@@ -11,11 +11,11 @@ func BenchmarkLogisticSigmoidActivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	aFn := logisticSigmoid.ActivationFn(m)
+	aFn := logisticSigmoid.ActivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(aFn, m)
+		m.ApplyVector(aFn, m)
 	}
 }
 
@@ -23,11 +23,11 @@ func BenchmarkLogisticSigmoidDeactivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	dFn := logisticSigmoid.DeactivationFn(m)
+	dFn := logisticSigmoid.DeactivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(dFn, m)
+		m.ApplyVector(dFn, m)
 	}
 }
 
@@ -35,11 +35,11 @@ func BenchmarkTanHActivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	aFn := tanH.ActivationFn(m)
+	aFn := tanH.ActivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(aFn, m)
+		m.ApplyVector(aFn, m)
 	}
 }
 
@@ -47,11 +47,11 @@ func BenchmarkTanHDeactivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	dFn := tanH.DeactivationFn(m)
+	dFn := tanH.DeactivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(dFn, m)
+		m.ApplyVector(dFn, m)
 	}
 }
 
@@ -59,11 +59,11 @@ func BenchmarkReLUActivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	aFn := reLU.ActivationFn(m)
+	aFn := reLU.ActivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(aFn, m)
+		m.ApplyVector(aFn, m)
 	}
 }
 
@@ -71,11 +71,11 @@ func BenchmarkReLUDeactivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	dFn := reLU.DeactivationFn(m)
+	dFn := reLU.DeactivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(dFn, m)
+		m.ApplyVector(dFn, m)
 	}
 }
 
@@ -83,11 +83,11 @@ func BenchmarkLeakyReLUActivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	aFn := leakyReLU.ActivationFn(m)
+	aFn := leakyReLU.ActivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(aFn, m)
+		m.ApplyVector(aFn, m)
 	}
 }
 
@@ -95,11 +95,11 @@ func BenchmarkLeakyReLUDeactivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	dFn := leakyReLU.DeactivationFn(m)
+	dFn := leakyReLU.DeactivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(dFn, m)
+		m.ApplyVector(dFn, m)
 	}
 }
 
@@ -107,11 +107,11 @@ func BenchmarkSoftmaxActivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	aFn := softmax.ActivationFn(m)
+	aFn := softmax.ActivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(aFn, m)
+		m.ApplyVector(aFn, m)
 	}
 }
 
@@ -119,11 +119,11 @@ func BenchmarkSoftmaxDeactivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	dFn := softmax.DeactivationFn(m)
+	dFn := softmax.DeactivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(dFn, m)
+		m.ApplyVector(dFn, m)
 	}
 }
 
@@ -131,11 +131,11 @@ func BenchmarkStableSoftmaxActivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	aFn := stableSoftmax.ActivationFn(m)
+	aFn := stableSoftmax.ActivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(aFn, m)
+		m.ApplyVector(aFn, m)
 	}
 }
 
@@ -143,10 +143,50 @@ func BenchmarkStableSoftmaxDeactivation(b *testing.B) {
 	rnd := rand.New(rand.NewSource(0))
 	inputs := []float64{rnd.Float64(), rnd.Float64()}
 	m, _ := matrix.New(len(inputs), 1, inputs)
-	dFn := stableSoftmax.DeactivationFn(m)
+	dFn := stableSoftmax.DeactivationFn
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		m.Apply(dFn, m)
+		m.ApplyVector(dFn, m)
 	}
 }
+
+func benchmarkActivationSize(b *testing.B, aFn matrix.VectorFn, n int) {
+	rnd := rand.New(rand.NewSource(0))
+	inputs := make([]float64, n)
+	for idx := range inputs {
+		inputs[idx] = rnd.Float64()
+	}
+	m, _ := matrix.New(len(inputs), 1, inputs)
+	out := &matrix.Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out.ApplyVector(aFn, m)
+	}
+}
+
+func BenchmarkSoftmaxActivation_100(b *testing.B) {
+	benchmarkActivationSize(b, softmax.ActivationFn, 100)
+}
+
+func BenchmarkSoftmaxActivation_1000(b *testing.B) {
+	benchmarkActivationSize(b, softmax.ActivationFn, 1000)
+}
+
+func BenchmarkSoftmaxActivation_10000(b *testing.B) {
+	benchmarkActivationSize(b, softmax.ActivationFn, 10000)
+}
+
+func BenchmarkLogisticSigmoidActivation_100(b *testing.B) {
+	benchmarkActivationSize(b, logisticSigmoid.ActivationFn, 100)
+}
+
+func BenchmarkLogisticSigmoidActivation_1000(b *testing.B) {
+	benchmarkActivationSize(b, logisticSigmoid.ActivationFn, 1000)
+}
+
+func BenchmarkLogisticSigmoidActivation_10000(b *testing.B) {
+	benchmarkActivationSize(b, logisticSigmoid.ActivationFn, 10000)
+}
@@ -34,9 +34,9 @@ func TestActivationFunction_activate(t *testing.T) {
 			t.Parallel()
 
 			m, _ := matrix.New(len(tc.inputs), 1, tc.inputs)
-			aFn := ActivationFunctions[tc.activationFunctionName].ActivationFn(m)
+			aFn := ActivationFunctions[tc.activationFunctionName].ActivationFn
 
-			m.Apply(aFn, m)
+			m.ApplyVector(aFn, m)
 			for idx, out := range tc.exceptedActivatedOutputs {
 				if !isFloatInThreshold(m.Values[idx], out, 0.00001) {
 					t.Errorf("expected activated output is %f, but got %f", out, m.Values[idx])
@@ -44,8 +44,8 @@ func TestActivationFunction_activate(t *testing.T) {
 			}
 
 			m, _ = matrix.New(len(tc.inputs), 1, tc.inputs)
-			dFn := ActivationFunctions[tc.activationFunctionName].DeactivationFn(m)
-			m.Apply(dFn, m)
+			dFn := ActivationFunctions[tc.activationFunctionName].DeactivationFn
+			m.ApplyVector(dFn, m)
 			for idx, out := range tc.exceptedDeactivatedOutputs {
 				if !isFloatInThreshold(m.Values[idx], out, 0.00001) {
 					t.Errorf("expected deactivated output is %f, but got %f", out, m.Values[idx])
@@ -54,3 +54,34 @@ func TestActivationFunction_activate(t *testing.T) {
 		})
 	}
 }
+
+func TestActivationFunction_activate32(t *testing.T) {
+	inputs := []float64{1.43, -0.4, 0.23, 0, -2.5}
+	for name, fn := range ActivationFunctions {
+		fn := fn
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			fns := []struct {
+				fn   matrix.VectorFn
+				fn32 matrix.VectorFn32
+			}{
+				{fn.ActivationFn, fn.ActivationFn32},
+				{fn.DeactivationFn, fn.DeactivationFn32},
+			}
+
+			for _, f := range fns {
+				m, _ := matrix.New(len(inputs), 1, inputs)
+				m32, _ := matrix.ToFloat32(m)
+				m.ApplyVector(f.fn, m)
+				m32.ApplyVector(f.fn32, m32)
+
+				for idx, v := range m.Values {
+					if !isFloatInThreshold(float64(m32.Values[idx]), v, 0.00001) {
+						t.Errorf("expected output is %f, but got %f", v, m32.Values[idx])
+					}
+				}
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package ann
 import (
 	"errors"
 	"math/rand"
+	"strconv"
 
 	"github.com/azuwey/gonetwork/activationfn"
 	"github.com/azuwey/gonetwork/matrix"
@@ -34,6 +35,15 @@ type ANN struct {
 	learningRate float64
 	layers       []*Layer
 	rand         *rand.Rand
+	workspace    *workspace
+	pool         *matrix.Pool
+}
+
+// LayerStats summarizes the parameters of a layer, it can be used to monitor the training, e.g. to stop it when the weights diverge.
+type LayerStats struct {
+	WeightsNorm float64 // The Frobenius norm of the weights
+	BiasesNorm  float64 // The Frobenius norm of the biases
+	Finite      bool    // Whether all of the weights and biases are finite
 }
 
 // layerValues is used by calculateLayerValues to return both activated and unactivated values.
@@ -42,6 +52,23 @@ type layerValues struct {
 	unactivated *matrix.Matrix
 }
 
+// workspace holds the matrices that are reused between the calls of Train, so the steady-state training does not allocate.
+type workspace struct {
+	values                                []*layerValues
+	target, err, lastErr, gradient, delta *matrix.Matrix
+	transposed                            *matrix.Matrix
+}
+
+// newLayerValues allocates empty layer values for "l" layers, the first element is reserved for the input.
+func newLayerValues(l int) []*layerValues {
+	vals := make([]*layerValues, l+1)
+	for idx := range vals {
+		vals[idx] = &layerValues{&matrix.Matrix{}, &matrix.Matrix{}}
+	}
+
+	return vals
+}
+
 // New creates a new artificial neural network with "ls" layer structure,
 // the first element in the "ls" represents the input layer,
 // the last element in the "ls" represents the output layer.
@@ -61,19 +88,23 @@ func New(model *Model, r *rand.Rand) (*ANN, error) {
 	}
 
 	lyrs := make([]*Layer, len(model.Layers)-1)
-	rnd := func(v float64, _ int, _ []float64) float64 {
-		return r.Float64()*2 - 1
-	}
-
 	for idx, lyr := range model.Layers[1:] {
-		w, err := matrix.New(lyr.Nodes, model.Layers[idx].Nodes, lyr.Weights)
+		var w *matrix.Matrix
+		var err error
+		if lyr.Weights != nil {
+			w, err = matrix.New(lyr.Nodes, model.Layers[idx].Nodes, lyr.Weights)
+		} else {
+			w, err = matrix.NewRandom(lyr.Nodes, model.Layers[idx].Nodes, matrix.Uniform{Min: -1, Max: 1}, r)
+		}
+
 		if !errors.Is(err, nil) {
 			return nil, err
 		}
 
-		b, _ := matrix.New(lyr.Nodes, 1, lyr.Weights)
-
-		w.Apply(rnd, w)
+		b, err := matrix.New(lyr.Nodes, 1, lyr.Biases)
+		if !errors.Is(err, nil) {
+			return nil, err
+		}
 
 		aFn, ok := activationfn.ActivationFunctions[lyr.ActivationFunction]
 		if !ok {
@@ -83,33 +114,88 @@ func New(model *Model, r *rand.Rand) (*ANN, error) {
 		lyrs[idx] = &Layer{w, b, aFn}
 	}
 
-	n := &ANN{model.LearningRate, lyrs, r}
+	n := &ANN{model.LearningRate, lyrs, r, nil, &matrix.Pool{}}
 
 	return n, nil
 }
 
+// borrowLayerValues returns the layer values for a prediction, the matrices are borrowed from the pool of the network, and "in" is used as the input.
+// The layer values should be returned by releaseLayerValues when they are no longer used.
+func (n *ANN) borrowLayerValues(in *matrix.Matrix) []*layerValues {
+	vals, lVals := make([]*layerValues, len(n.layers)+1), make([]layerValues, len(n.layers)+1)
+	lVals[0].activated = in
+	vals[0] = &lVals[0]
+	for idx, l := range n.layers {
+		lVals[idx+1].unactivated, _ = n.pool.Get(l.weights.Rows, 1)
+		lVals[idx+1].activated, _ = n.pool.Get(l.weights.Rows, 1)
+		vals[idx+1] = &lVals[idx+1]
+	}
+
+	return vals
+}
+
+// releaseLayerValues returns the matrices of "vals" to the pool of the network, including the input.
+func (n *ANN) releaseLayerValues(vals []*layerValues) {
+	for _, v := range vals {
+		n.pool.Put(v.activated)
+		n.pool.Put(v.unactivated)
+	}
+}
+
+// calculateLayerValues calculates the values of each layer for the input "i", the result should be returned by releaseLayerValues.
 func (n *ANN) calculateLayerValues(i []float64) ([]*layerValues, error) {
-	iMat, err := matrix.New(len(i), 1, i)
+	if len(i) == 0 {
+		return nil, matrix.ErrZeroRow
+	}
+
+	iMat, err := n.pool.Get(len(i), 1)
 	if !errors.Is(err, nil) {
 		return nil, err
 	}
 
-	vals := make([]*layerValues, len(n.layers)+1)
-	vals[0] = &layerValues{iMat, nil}
+	copy(iMat.Values, i)
+	vals := n.borrowLayerValues(iMat)
+
+	if err := n.propagate(vals, nil); !errors.Is(err, nil) {
+		n.releaseLayerValues(vals)
+		return nil, err
+	}
+
+	return vals, nil
+}
 
-	for idx := range vals[1:] {
-		uV := &matrix.Matrix{}
+// propagate calculates the values of each layer from the input stored in "vals[0].activated", reusing the matrices in "vals".
+// If "sparse" is not nil, it is used as the input instead of "vals[0].activated".
+func (n *ANN) propagate(vals []*layerValues, sparse *matrix.Sparse) error {
+	for idx, l := range n.layers {
+		uV, aV := vals[idx+1].unactivated, vals[idx+1].activated
 
-		uV.Product(n.layers[idx].weights, vals[idx].activated)
-		uV.Add(n.layers[idx].biases, uV)
+		var err error
+		if idx == 0 && sparse != nil {
+			err = uV.DenseSparseProduct(l.weights, sparse)
+		} else {
+			err = uV.Product(l.weights, vals[idx].activated)
+		}
 
-		aV, _ := matrix.Copy(uV)
-		aV.Apply(n.layers[idx].activationFunction.ActivationFn(aV), aV)
+		if !errors.Is(err, nil) {
+			return inLayer(err, idx)
+		}
 
-		vals[idx+1] = &layerValues{aV, uV}
+		uV.Add(l.biases, uV)
+		aV.ApplyVector(l.activationFunction.ActivationFn, uV)
 	}
 
-	return vals, nil
+	return nil
+}
+
+// inLayer sets the layer of the ShapeError in "err", if there is one, to the index of the "idx"-th layer (see Stats), and returns "err".
+func inLayer(err error, idx int) error {
+	var shapeErr *matrix.ShapeError
+	if errors.As(err, &shapeErr) {
+		shapeErr.Layer = strconv.Itoa(idx)
+	}
+
+	return err
 }
 
 // Predict ...
@@ -123,7 +209,80 @@ func (n *ANN) Predict(i []float64) ([]float64, error) {
 		return nil, err
 	}
 
-	return lVals[len(lVals)-1].activated.Values, nil
+	o := append([]float64(nil), lVals[len(lVals)-1].activated.Values...)
+	n.releaseLayerValues(lVals)
+
+	return o, nil
+}
+
+// PredictSparse is the same as Predict, but the input is a sparse column vector, so only the weights of its non-zero elements are read.
+// It will return an error if "i == nil", "i" is not a column vector, or the number of its rows is not the number of nodes in the input layer.
+func (n *ANN) PredictSparse(i *matrix.Sparse) ([]float64, error) {
+	if i == nil {
+		return nil, ErrNilSparseInput
+	}
+
+	if i.Columns != 1 {
+		return nil, ErrBadSparseInput
+	}
+
+	lVals := n.borrowLayerValues(nil)
+	defer n.releaseLayerValues(lVals)
+
+	if err := n.propagate(lVals, i); !errors.Is(err, nil) {
+		return nil, err
+	}
+
+	return append([]float64(nil), lVals[len(lVals)-1].activated.Values...), nil
+}
+
+// PredictClass returns the index of the output node with the largest value for the input "i", which is the predicted class of a classifier network.
+// It will return the same errors as Predict, and it will also return an error if every output is NaN.
+func (n *ANN) PredictClass(i []float64) (int, error) {
+	if i == nil {
+		return 0, ErrNilInputSlice
+	}
+
+	lVals, err := n.calculateLayerValues(i)
+	if !errors.Is(err, nil) {
+		return 0, err
+	}
+
+	class, _ := lVals[len(lVals)-1].activated.ArgMax()
+	n.releaseLayerValues(lVals)
+
+	if class < 0 {
+		return 0, ErrNaNOutput
+	}
+
+	return class, nil
+}
+
+// Stats returns the LayerStats of each layer, starting with the first layer after the input layer.
+func (n *ANN) Stats() []LayerStats {
+	stats := make([]LayerStats, len(n.layers))
+	for idx, l := range n.layers {
+		stats[idx] = LayerStats{
+			l.weights.FrobeniusNorm(),
+			l.biases.FrobeniusNorm(),
+			l.weights.IsFinite() && l.biases.IsFinite(),
+		}
+	}
+
+	return stats
+}
+
+// prepareWorkspace allocates the workspace of Train on the first call, and returns it.
+func (n *ANN) prepareWorkspace() *workspace {
+	if n.workspace == nil {
+		n.workspace = &workspace{
+			newLayerValues(len(n.layers)),
+			&matrix.Matrix{}, &matrix.Matrix{}, &matrix.Matrix{}, &matrix.Matrix{}, &matrix.Matrix{},
+			&matrix.Matrix{},
+		}
+	}
+
+	return n.workspace
 }
 
 // Train ...
@@ -136,41 +295,80 @@ func (n *ANN) Train(i, t []float64) error {
 		return ErrNilTargetSlice
 	}
 
-	lVals, err := n.calculateLayerValues(i)
-	if !errors.Is(err, nil) {
+	ws := n.prepareWorkspace()
+	if err := ws.values[0].activated.SetValues(len(i), 1, i); !errors.Is(err, nil) {
 		return err
 	}
 
-	tMat, err := matrix.New(len(t), 1, t)
-	if !errors.Is(err, nil) {
+	return n.train(ws, nil, t)
+}
+
+// TrainSparse is the same as Train, but the input is a sparse column vector,
+// so only the weights of its non-zero elements are read and updated in the first layer.
+// It will return an error if "i == nil", "i" is not a column vector, or the number of its rows is not the number of nodes in the input layer.
+func (n *ANN) TrainSparse(i *matrix.Sparse, t []float64) error {
+	if i == nil {
+		return ErrNilSparseInput
+	}
+
+	if i.Columns != 1 {
+		return ErrBadSparseInput
+	}
+
+	if t == nil {
+		return ErrNilTargetSlice
+	}
+
+	return n.train(n.prepareWorkspace(), i, t)
+}
+
+// train performs a single step of backpropagation, the input is either stored in the first layer values of "ws", or it is "sparse" when that is not nil.
+func (n *ANN) train(ws *workspace, sparse *matrix.Sparse, t []float64) error {
+	lVals := ws.values
+	if err := n.propagate(lVals, sparse); !errors.Is(err, nil) {
 		return err
 	}
 
-	if lVals[len(lVals)-1].activated.Rows != tMat.Rows {
-		return ErrBadTargetSlice
+	tMat := ws.target
+	if err := tMat.SetValues(len(t), 1, t); !errors.Is(err, nil) {
+		return err
+	}
+
+	if out := lVals[len(lVals)-1].activated; out.Rows != tMat.Rows {
+		return &matrix.ShapeError{Op: "Train", Layer: strconv.Itoa(len(n.layers) - 1), Expected: []int{out.Rows}, Actual: []int{len(t)}, Err: ErrBadTargetSlice}
 	}
 
-	lastErrVal := &matrix.Matrix{}
 	for idx := len(n.layers) - 1; idx >= 0; idx-- {
-		e := &matrix.Matrix{}
+		e := ws.err
 		if idx == len(n.layers)-1 {
 			e.Subtract(tMat, lVals[idx+1].activated)
 		} else {
-			e.Transpose(n.layers[idx+1].weights)
-			e.Product(e, lastErrVal)
+			ws.transposed.Transpose(n.layers[idx+1].weights)
+			e.Product(ws.transposed, ws.lastErr)
 		}
-		lastErrVal = e
+		ws.err, ws.lastErr = ws.lastErr, e
 
-		g := &matrix.Matrix{}
-		g.Apply(n.layers[idx].activationFunction.DeactivationFn(lVals[idx+1].unactivated), lVals[idx+1].unactivated)
+		g := ws.gradient
+		g.ApplyVector(n.layers[idx].activationFunction.DeactivationFn, lVals[idx+1].unactivated)
 		g.Multiply(e, g)
 
-		d := &matrix.Matrix{}
-		d.Transpose(lVals[idx].activated)
-		d.Product(g, d)
-		d.Scale(n.learningRate, d)
+		if idx == 0 && sparse != nil {
+			// Only the columns of the weights that belong to the non-zero inputs change.
+			w := n.layers[idx].weights
+			sparse.Each(func(k, _ int, v float64) {
+				for r, gVal := range g.Values {
+					w.Values[r*w.Columns+k] += n.learningRate * gVal * v
+				}
+			})
+		} else {
+			d := ws.delta
+			ws.transposed.Transpose(lVals[idx].activated)
+			d.Product(g, ws.transposed)
+			d.Scale(n.learningRate, d)
+
+			n.layers[idx].weights.Add(n.layers[idx].weights, d)
+		}
 
-		n.layers[idx].weights.Add(n.layers[idx].weights, d)
 		n.layers[idx].biases.Add(n.layers[idx].biases, g)
 	}
 
@@ -0,0 +1,138 @@
+package ann
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/azuwey/gonetwork/activationfn"
+)
+
+func TestNew32(t *testing.T) {
+	model := &Model{0.1, []LayerDescriptor{
+		{2, "", nil, nil},
+		{4, "TanH", nil, nil},
+		{2, "Softmax", nil, nil},
+	}}
+
+	n, err := New32(model, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if len(n.layers) != 2 || n.learningRate != 0.1 {
+		t.Errorf("Expected number of layers and learning rate are %d and %f, but got %d and %f", 2, 0.1, len(n.layers), n.learningRate)
+	}
+
+	if _, err := New32(&Model{0.1, nil}, rand.New(rand.NewSource(0))); err != ErrLayerStructureLength {
+		t.Errorf("Expected error is %v, but got %v", ErrLayerStructureLength, err)
+	}
+
+	custom := &ANN{0.1, []*Layer{{activationFunction: &activationfn.ActivationFunction{Name: "Custom"}}}, nil, nil, nil}
+	if _, err := custom.Float32(); err != ErrNoFloat32ActivationFn {
+		t.Errorf("Expected error is %v, but got %v", ErrNoFloat32ActivationFn, err)
+	}
+}
+
+// TestANN32 trains a double-precision network and its single-precision copy on the same samples, and compares their predictions.
+func TestANN32(t *testing.T) {
+	n, _ := New(&Model{0.1, []LayerDescriptor{
+		{2, "", nil, nil},
+		{8, "TanH", nil, nil},
+		{1, "LogisticSigmoid", nil, nil},
+	}}, rand.New(rand.NewSource(0)))
+
+	n32, err := n.Float32()
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	inputs := [][]float64{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	targets := [][]float64{{0}, {1}, {1}, {0}}
+	inputs32 := [][]float32{{0, 0}, {0, 1}, {1, 0}, {1, 1}}
+	targets32 := [][]float32{{0}, {1}, {1}, {0}}
+
+	for epoch := 0; epoch < 2000; epoch++ {
+		for idx := range inputs {
+			n.Train(inputs[idx], targets[idx])
+			if err := n32.Train(inputs32[idx], targets32[idx]); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+		}
+	}
+
+	for idx := range inputs {
+		p, _ := n.Predict(inputs[idx])
+		p32, err := n32.Predict(inputs32[idx])
+		if err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if math.Abs(p[0]-float64(p32[0])) > 1e-3 {
+			t.Errorf("Expected prediction is %f, but got %f", p[0], p32[0])
+		}
+
+		if !isFloatInThreshold(float64(p32[0]), targets[idx][0], 0.1) {
+			t.Errorf("Expected prediction is %f, but got %f", targets[idx][0], p32[0])
+		}
+	}
+
+	// The conversion back to double precision is exact.
+	n64 := n32.Float64()
+	for idx := range inputs32 {
+		p32, _ := n32.Predict(inputs32[idx])
+		p, _ := n64.Predict([]float64{float64(inputs32[idx][0]), float64(inputs32[idx][1])})
+		if math.Abs(p[0]-float64(p32[0])) > 1e-6 {
+			t.Errorf("Expected prediction is %f, but got %f", float64(p32[0]), p[0])
+		}
+	}
+}
+
+func TestANN32_errors(t *testing.T) {
+	n, _ := New32(&Model{0.1, []LayerDescriptor{
+		{2, "", nil, nil},
+		{3, "ReLU", nil, nil},
+		{2, "StableSoftmax", nil, nil},
+	}}, rand.New(rand.NewSource(0)))
+
+	if _, err := n.Predict(nil); err != ErrNilInputSlice {
+		t.Errorf("Expected error is %v, but got %v", ErrNilInputSlice, err)
+	}
+
+	if _, err := n.PredictClass(nil); err != ErrNilInputSlice {
+		t.Errorf("Expected error is %v, but got %v", ErrNilInputSlice, err)
+	}
+
+	if err := n.Train([]float32{0, 1}, nil); err != ErrNilTargetSlice {
+		t.Errorf("Expected error is %v, but got %v", ErrNilTargetSlice, err)
+	}
+
+	if err := n.Train([]float32{0, 1}, []float32{1}); !errors.Is(err, ErrBadTargetSlice) {
+		t.Errorf("Expected error is %v, but got %v", ErrBadTargetSlice, err)
+	}
+
+	if class, err := n.PredictClass([]float32{0, 1}); err != nil || class < 0 || class > 1 {
+		t.Errorf("Expected class is between %d and %d, but got %d with %v error", 0, 1, class, err)
+	}
+
+	n.layers[len(n.layers)-1].biases.Set(0, 0, float32(math.NaN()))
+	n.layers[len(n.layers)-1].biases.Set(1, 0, float32(math.NaN()))
+	if _, err := n.PredictClass([]float32{0, 1}); err != ErrNaNOutput {
+		t.Errorf("Expected error is %v, but got %v", ErrNaNOutput, err)
+	}
+}
+
+func TestTrain32_allocations(t *testing.T) {
+	n, _ := New32(&Model{0.1, []LayerDescriptor{
+		{4, "", nil, nil},
+		{16, "TanH", nil, nil},
+		{4, "LogisticSigmoid", nil, nil},
+	}}, rand.New(rand.NewSource(0)))
+	inputs, targets := []float32{0, 1, 1, 0}, []float32{0, 1, 1, 1}
+	n.Train(inputs, targets)
+
+	if allocs := testing.AllocsPerRun(100, func() { n.Train(inputs, targets) }); allocs != 0 {
+		t.Errorf("Expected number of allocations is %d, but got %f", 0, allocs)
+	}
+}
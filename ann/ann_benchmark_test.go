@@ -2,7 +2,10 @@ package ann
 
 import (
 	"math/rand"
+	"runtime"
 	"testing"
+
+	"github.com/azuwey/gonetwork/matrix"
 )
 
 func BenchmarkNew(b *testing.B) {
@@ -13,6 +16,7 @@ func BenchmarkNew(b *testing.B) {
 	}}
 	rnd := rand.New(rand.NewSource(0))
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		New(model, rnd)
@@ -29,6 +33,7 @@ func BenchmarkCalculateLayerValues(b *testing.B) {
 	n, _ := New(model, rnd)
 	inputs := []float64{rnd.Float64()}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		n.calculateLayerValues(inputs)
@@ -45,6 +50,7 @@ func BenchmarkPredict(b *testing.B) {
 	n, _ := New(model, rnd)
 	inputs := []float64{rnd.Float64()}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		n.Predict(inputs)
@@ -62,8 +68,104 @@ func BenchmarkTrain(b *testing.B) {
 	inputs := []float64{rnd.Float64()}
 	target := []float64{rnd.Float64()}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		n.Train(inputs, target)
 	}
 }
+
+func BenchmarkTrainSparse(b *testing.B) {
+	model := &Model{0.1, []LayerDescriptor{
+		{50000, "", nil, nil},
+		{32, "TanH", nil, nil},
+		{4, "LogisticSigmoid", nil, nil},
+	}}
+	rnd := rand.New(rand.NewSource(0))
+	n, _ := New(model, rnd)
+	rows, cols, vals := make([]int, 50), make([]int, 50), make([]float64, 50)
+	for idx := range rows {
+		rows[idx], vals[idx] = rnd.Intn(50000), 1
+	}
+	inputs, _ := matrix.NewSparse(50000, 1, rows, cols, vals)
+	targets := []float64{0, 1, 0, 0}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n.TrainSparse(inputs, targets)
+	}
+}
+
+// benchmarkEpoch trains and evaluates the network on "samples" inputs in every iteration, and reports the number of allocations per sample.
+func benchmarkEpoch(b *testing.B, model *Model, samples int) {
+	rnd := rand.New(rand.NewSource(0))
+	n, _ := New(model, rnd)
+	inputs, targets := make([][]float64, samples), make([][]float64, samples)
+	for idx := range inputs {
+		inputs[idx] = make([]float64, model.Layers[0].Nodes)
+		for i := range inputs[idx] {
+			inputs[idx][i] = rnd.Float64()
+		}
+
+		targets[idx] = make([]float64, model.Layers[len(model.Layers)-1].Nodes)
+		targets[idx][rnd.Intn(len(targets[idx]))] = 1
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for idx := range inputs {
+			n.Train(inputs[idx], targets[idx])
+		}
+
+		for idx := range inputs {
+			n.PredictClass(inputs[idx])
+		}
+	}
+	b.StopTimer()
+
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.Mallocs-before.Mallocs)/float64(b.N*samples), "allocs/sample")
+}
+
+func BenchmarkEpoch_64x32x10(b *testing.B) {
+	benchmarkEpoch(b, &Model{0.1, []LayerDescriptor{
+		{64, "", nil, nil},
+		{32, "TanH", nil, nil},
+		{10, "Softmax", nil, nil},
+	}}, 100)
+}
+
+func BenchmarkPredict_512x256x10(b *testing.B) {
+	n, _ := New(&Model{0.1, []LayerDescriptor{
+		{512, "", nil, nil},
+		{256, "TanH", nil, nil},
+		{10, "Softmax", nil, nil},
+	}}, rand.New(rand.NewSource(0)))
+	inputs := make([]float64, 512)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n.PredictClass(inputs)
+	}
+}
+
+func BenchmarkPredict32_512x256x10(b *testing.B) {
+	n, _ := New32(&Model{0.1, []LayerDescriptor{
+		{512, "", nil, nil},
+		{256, "TanH", nil, nil},
+		{10, "Softmax", nil, nil},
+	}}, rand.New(rand.NewSource(0)))
+	inputs := make([]float32, 512)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n.PredictClass(inputs)
+	}
+}
@@ -193,5 +193,5 @@ func Example_train_4_bit_counter() {
 	// Before training
 	// Predictions: [0.31960827874944575 0.15624517612075375 0.22486376541093606 0.33754839385451846] want: [1 1 1 0]
 	// After training
-	// Predictions: [0.9975696941940078 0.9801913790665919 0.9985607706133298 0.0024022594962865643] want: [1 1 1 0]
+	// Predictions: [0.9982978555078484 0.9935720192484176 0.989436649050012 0.002119795404464922] want: [1 1 1 0]
 }
@@ -1,6 +1,8 @@
 package ann
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"math/rand"
 	"testing"
@@ -225,6 +227,49 @@ func TestPredict(t *testing.T) {
 	}
 }
 
+func TestPredictClass(t *testing.T) {
+	testCases := []struct {
+		name          string
+		inputs        []float64
+		weights       []float64
+		expectedClass int
+		expectedError error
+	}{
+		{"Normal", []float64{1}, []float64{-1, 2, 1}, 1, nil},
+		{"ErrNilInputSlice", nil, nil, 0, ErrNilInputSlice},
+		{"matrix.ErrZeroRow", []float64{}, nil, 0, matrix.ErrZeroRow},
+		{"ErrNaNOutput", []float64{1}, []float64{math.NaN(), math.NaN(), math.NaN()}, 0, ErrNaNOutput},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			n, _ := New(&Model{0.1, []LayerDescriptor{
+				{1, "", nil, nil},
+				{1, "ReLU", nil, nil},
+				{3, "Softmax", nil, nil},
+			}}, rand.New(rand.NewSource(0)))
+			if tc.weights != nil {
+				n.layers[0].weights.SetValues(1, 1, []float64{1})
+				n.layers[1].weights.SetValues(3, 1, tc.weights)
+			}
+
+			class, err := n.PredictClass(tc.inputs)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if class != tc.expectedClass {
+				t.Errorf("Expected class is %d, but got %d", tc.expectedClass, class)
+			}
+		})
+	}
+}
+
 func TestTrain(t *testing.T) {
 	type dataSet struct{ inputs, targets []float64 }
 	testCases := []struct {
@@ -305,7 +350,7 @@ func TestTrain(t *testing.T) {
 			for _, ld := range tc.learningData {
 				err := n.Train(ld.inputs, ld.targets)
 				if tc.expectedError != nil {
-					if err != tc.expectedError {
+					if !errors.Is(err, tc.expectedError) {
 						t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
 					}
 				} else if err != nil {
@@ -418,3 +463,203 @@ func TestTrain_dataset(t *testing.T) {
 		})
 	}
 }
+
+func TestTrain_allocations(t *testing.T) {
+	n, _ := New(&Model{0.1, []LayerDescriptor{
+		{4, "", nil, nil},
+		{16, "TanH", nil, nil},
+		{4, "LogisticSigmoid", nil, nil},
+	}}, rand.New(rand.NewSource(0)))
+	inputs, targets := []float64{0, 1, 1, 0}, []float64{0, 1, 1, 1}
+	n.Train(inputs, targets)
+
+	if allocs := testing.AllocsPerRun(100, func() { n.Train(inputs, targets) }); allocs != 0 {
+		t.Errorf("Expected number of allocations is %d, but got %f", 0, allocs)
+	}
+}
+
+func TestPredictSparse(t *testing.T) {
+	model := &Model{0.1, []LayerDescriptor{
+		{6, "", nil, nil},
+		{4, "TanH", nil, nil},
+		{2, "LogisticSigmoid", nil, nil},
+	}}
+	n, _ := New(model, rand.New(rand.NewSource(0)))
+	inputs := []float64{0, 0.5, 0, 0, -1, 0}
+
+	expected, _ := n.Predict(inputs)
+	s, _ := matrix.NewSparse(6, 1, []int{1, 4}, []int{0, 0}, []float64{0.5, -1})
+	predictions, err := n.PredictSparse(s)
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	for idx, p := range predictions {
+		if !isFloatInThreshold(p, expected[idx], 1e-12) {
+			t.Errorf("Expected prediction is %f, but got %f", expected[idx], p)
+		}
+	}
+
+	if _, err := n.PredictSparse(nil); err != ErrNilSparseInput {
+		t.Errorf("Expected error is %v, but got %v", ErrNilSparseInput, err)
+	}
+
+	wide, _ := matrix.NewSparse(6, 2, nil, nil, nil)
+	if _, err := n.PredictSparse(wide); err != ErrBadSparseInput {
+		t.Errorf("Expected error is %v, but got %v", ErrBadSparseInput, err)
+	}
+
+	short, _ := matrix.NewSparse(5, 1, nil, nil, nil)
+	if _, err := n.PredictSparse(short); !errors.Is(err, matrix.ErrBadProductDimension) {
+		t.Errorf("Expected error is %v, but got %v", matrix.ErrBadProductDimension, err)
+	}
+}
+
+func TestTrainSparse(t *testing.T) {
+	model := &Model{0.1, []LayerDescriptor{
+		{6, "", nil, nil},
+		{4, "TanH", nil, nil},
+		{2, "LogisticSigmoid", nil, nil},
+	}}
+	dense, _ := New(model, rand.New(rand.NewSource(0)))
+	sparse, _ := New(model, rand.New(rand.NewSource(0)))
+
+	inputs, targets := []float64{0, 0.5, 0, 0, -1, 0}, []float64{1, 0}
+	s, _ := matrix.NewSparse(6, 1, []int{1, 4}, []int{0, 0}, []float64{0.5, -1})
+	for i := 0; i < 10; i++ {
+		dense.Train(inputs, targets)
+		if err := sparse.TrainSparse(s, targets); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+	}
+
+	for idx, l := range dense.layers {
+		for wIdx, w := range l.weights.Values {
+			if !isFloatInThreshold(sparse.layers[idx].weights.Values[wIdx], w, 1e-12) {
+				t.Errorf("Expected weight is %f, but got %f", w, sparse.layers[idx].weights.Values[wIdx])
+			}
+		}
+	}
+
+	if err := sparse.TrainSparse(nil, targets); err != ErrNilSparseInput {
+		t.Errorf("Expected error is %v, but got %v", ErrNilSparseInput, err)
+	}
+
+	if err := sparse.TrainSparse(s, nil); err != ErrNilTargetSlice {
+		t.Errorf("Expected error is %v, but got %v", ErrNilTargetSlice, err)
+	}
+
+	if err := sparse.TrainSparse(s, []float64{1}); !errors.Is(err, ErrBadTargetSlice) {
+		t.Errorf("Expected error is %v, but got %v", ErrBadTargetSlice, err)
+	}
+}
+
+func TestNew_seed(t *testing.T) {
+	model := &Model{0.1, []LayerDescriptor{
+		{4, "", nil, nil},
+		{3, "LogisticSigmoid", nil, nil},
+		{2, "LogisticSigmoid", nil, []float64{0.5, -0.5}},
+	}}
+
+	a, _ := New(model, rand.New(rand.NewSource(7)))
+	b, _ := New(model, rand.New(rand.NewSource(7)))
+	for idx := range a.layers {
+		for vIdx, v := range a.layers[idx].weights.Values {
+			if w := b.layers[idx].weights.Values[vIdx]; v != w || v < -1 || v >= 1 {
+				t.Fatalf("Expected weights are %v, but got %v", a.layers[idx].weights.Values, b.layers[idx].weights.Values)
+			}
+		}
+	}
+
+	if biases := a.layers[1].biases.Values; biases[0] != 0.5 || biases[1] != -0.5 {
+		t.Errorf("Expected biases are %v, but got %v", model.Layers[2].Biases, biases)
+	}
+}
+
+func TestStats(t *testing.T) {
+	n, _ := New(&Model{0.1, []LayerDescriptor{
+		{2, "", nil, nil},
+		{2, "LogisticSigmoid", []float64{3, 0, 0, -4}, []float64{1, 0}},
+		{1, "LogisticSigmoid", []float64{1, 2}, []float64{2}},
+	}}, rand.New(rand.NewSource(0)))
+
+	expected := []LayerStats{{5, 1, true}, {math.Sqrt(5), 2, true}}
+	stats := n.Stats()
+	if len(stats) != len(expected) {
+		t.Fatalf("Expected length of stats is %d, but got %d", len(expected), len(stats))
+	}
+
+	for idx, s := range stats {
+		if !isFloatInThreshold(s.WeightsNorm, expected[idx].WeightsNorm, 1e-12) || !isFloatInThreshold(s.BiasesNorm, expected[idx].BiasesNorm, 1e-12) || s.Finite != expected[idx].Finite {
+			t.Errorf("Expected stats are %v, but got %v", expected[idx], s)
+		}
+	}
+
+	n.layers[1].biases.Values[0] = math.NaN()
+	if stats := n.Stats(); !stats[0].Finite || stats[1].Finite {
+		t.Errorf("Expected finite layers are %t and %t, but got %t and %t", true, false, stats[0].Finite, stats[1].Finite)
+	}
+}
+
+func TestPredict_pooled(t *testing.T) {
+	n, _ := New(&Model{0.1, []LayerDescriptor{
+		{2, "", nil, nil},
+		{8, "TanH", nil, nil},
+		{2, "LogisticSigmoid", nil, nil},
+	}}, rand.New(rand.NewSource(0)))
+
+	first, _ := n.Predict([]float64{0, 1})
+	expected := append([]float64(nil), first...)
+	second, _ := n.Predict([]float64{1, 0})
+
+	for idx := range expected {
+		if first[idx] != expected[idx] {
+			t.Errorf("Expected prediction is %f, but got %f", expected[idx], first[idx])
+		}
+	}
+
+	if again, _ := n.Predict([]float64{0, 1}); again[0] != expected[0] || again[1] != expected[1] || second[0] == expected[0] {
+		t.Errorf("Expected prediction is %v, but got %v", expected, again)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { n.PredictClass([]float64{0, 1}) }); allocs > 2 {
+		t.Errorf("Expected number of allocations is at most %d, but got %f", 2, allocs)
+	}
+}
+
+func TestShapeError(t *testing.T) {
+	n, _ := New(&Model{0.1, []LayerDescriptor{
+		{2, "", nil, nil},
+		{3, "TanH", nil, nil},
+		{2, "LogisticSigmoid", nil, nil},
+	}}, rand.New(rand.NewSource(0)))
+
+	testCases := []struct {
+		name          string
+		fn            func() error
+		op, layer     string
+		expected      []int
+		actual        []int
+		expectedError error
+	}{
+		{"Predict", func() error { _, err := n.Predict([]float64{0, 1, 2}); return err }, "Product", "0", []int{2, 1}, []int{3, 1}, matrix.ErrBadProductDimension},
+		{"Train", func() error { return n.Train([]float64{0, 1}, []float64{1}) }, "Train", "1", []int{2}, []int{1}, ErrBadTargetSlice},
+	}
+
+	for _, tc := range testCases {
+		err := tc.fn()
+		if !errors.Is(err, tc.expectedError) {
+			t.Fatalf("%s: expected error is %v, but got %v", tc.name, tc.expectedError, err)
+		}
+
+		var shapeErr *matrix.ShapeError
+		if !errors.As(err, &shapeErr) {
+			t.Fatalf("%s: expected a ShapeError, but got %T", tc.name, err)
+		}
+
+		if shapeErr.Op != tc.op || shapeErr.Layer != tc.layer || fmt.Sprint(shapeErr.Expected) != fmt.Sprint(tc.expected) || fmt.Sprint(shapeErr.Actual) != fmt.Sprint(tc.actual) {
+			t.Errorf("%s: expected ShapeError is %s in layer %s, %v and %v, but got %s in layer %s, %v and %v", tc.name,
+				tc.op, tc.layer, tc.expected, tc.actual, shapeErr.Op, shapeErr.Layer, shapeErr.Expected, shapeErr.Actual)
+		}
+	}
+}
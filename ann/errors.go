@@ -20,8 +20,20 @@ var ErrNilRand = errors.New("network: random source must not be nil")
 // ErrNilMatrix is returned by Train when `t` is nil.
 var ErrNilTargetSlice = errors.New("network: traget slice must not be nil")
 
-// ErrNilMatrix is returned by Train when the length of `t` is not equal to the number of nodes in the output layer.
+// ErrBadTargetSlice is returned by Train when the length of `t` is not equal to the number of nodes in the output layer, wrapped in a matrix.ShapeError.
 var ErrBadTargetSlice = errors.New("network: length of the target slice needs to be the same as the number of nodes in the output layer")
 
 // ErrNilMatrix is returned by any operation that is require a input slice as argument.
 var ErrNilInputSlice = errors.New("network: input slice must not be nil")
+
+// ErrNilSparseInput is returned by PredictSparse and TrainSparse when `i` is nil.
+var ErrNilSparseInput = errors.New("network: sparse input must not be nil")
+
+// ErrBadSparseInput is returned by PredictSparse and TrainSparse when `i` is not a column vector.
+var ErrBadSparseInput = errors.New("network: sparse input must be a column vector")
+
+// ErrNaNOutput is returned by PredictClass when every output of the network is NaN, e.g. because the training diverged.
+var ErrNaNOutput = errors.New("network: every output of the network is NaN, there is no class to predict")
+
+// ErrNoFloat32ActivationFn is returned by Float32 and New32 when an activation function of the network has no single-precision implementation.
+var ErrNoFloat32ActivationFn = errors.New("network: activation function must have a single-precision implementation")
@@ -0,0 +1,118 @@
+// Package autodiff implements reverse-mode automatic differentiation over matrices.
+//
+// The operations are recorded on a Tape as they are evaluated, and Backward walks the tape in reverse order,
+// so the gradient of a scalar result with respect to every recorded Variable is calculated from the chain rule.
+package autodiff
+
+import "github.com/azuwey/gonetwork/matrix"
+
+// Tape records the variables and the operations that produced them, in the order of their evaluation.
+// A tape is meant to be used for a single evaluation, e.g. for one step of the training, and then discarded.
+type Tape struct {
+	variables []*Variable
+}
+
+// Variable is a node of the computation, it holds the value of an input or the result of an operation.
+type Variable struct {
+	Value *matrix.Matrix // The value of the variable, it must not be changed after the variable is recorded
+	Grad  *matrix.Matrix // The gradient calculated by the last Backward, nil if the variable does not affect the result
+
+	tape     *Tape
+	index    int
+	backward func(g *matrix.Matrix) error // Accumulates the gradients of the operands from the gradient of the result, nil for the inputs
+}
+
+// NewTape creates a new, empty Tape.
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+// Variable records "m" as an input of the computation, e.g. a parameter of a layer or a mini-batch.
+// The matrix is not copied, so it must not be changed while the tape is in use.
+// It will return an error if "m == nil".
+func (t *Tape) Variable(m *matrix.Matrix) (*Variable, error) {
+	if m == nil {
+		return nil, ErrNilMatrix
+	}
+
+	return t.record(m, nil), nil
+}
+
+// record appends a new variable with the value "m" to the tape.
+func (t *Tape) record(m *matrix.Matrix, backward func(g *matrix.Matrix) error) *Variable {
+	v := &Variable{Value: m, tape: t, index: len(t.variables), backward: backward}
+	t.variables = append(t.variables, v)
+
+	return v
+}
+
+// Backward calculates the gradient of the variable with respect to every variable recorded before it on the same tape, and stores them in Grad.
+// The gradients of the previous call are discarded, so Backward can be called more than once.
+// It will return an error if the value of the variable is not a 1 x 1 matrix.
+func (v *Variable) Backward() error {
+	if v.Value.Rows != 1 || v.Value.Columns != 1 {
+		return ErrNotScalar
+	}
+
+	for _, x := range v.tape.variables {
+		x.Grad = nil
+	}
+
+	v.Grad, _ = matrix.New(1, 1, []float64{1})
+	for idx := v.index; idx >= 0; idx-- {
+		x := v.tape.variables[idx]
+		if x.Grad == nil || x.backward == nil {
+			continue
+		}
+
+		if err := x.backward(x.Grad); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// accumulate adds "g" to the gradient of the variable.
+// If "g" is the gradient of a broadcast value, it is summed along the broadcast dimensions first.
+func (v *Variable) accumulate(g *matrix.Matrix) error {
+	if g.Rows != v.Value.Rows && v.Value.Rows == 1 {
+		s := &matrix.Matrix{}
+		if err := s.SumAxis(matrix.ByColumn, g); err != nil {
+			return err
+		}
+
+		g = s
+	}
+
+	if g.Columns != v.Value.Columns && v.Value.Columns == 1 {
+		s := &matrix.Matrix{}
+		if err := s.SumAxis(matrix.ByRow, g); err != nil {
+			return err
+		}
+
+		g = s
+	}
+
+	if v.Grad == nil {
+		v.Grad, _ = matrix.Copy(g)
+		return nil
+	}
+
+	return v.Grad.Add(v.Grad, g)
+}
+
+// sameTape returns an error if any of the variables is nil, or they are not recorded on the same tape.
+func sameTape(vars ...*Variable) (*Tape, error) {
+	for _, v := range vars {
+		if v == nil {
+			return nil, ErrNilVariable
+		}
+
+		if v.tape != vars[0].tape {
+			return nil, ErrDifferentTapes
+		}
+	}
+
+	return vars[0].tape, nil
+}
@@ -0,0 +1,26 @@
+package autodiff_test
+
+import (
+	"fmt"
+
+	"github.com/azuwey/gonetwork/autodiff"
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+func Example() {
+	// The mean squared error of the "y = w * x" model on two samples, one sample per column.
+	t := autodiff.NewTape()
+	w, _ := t.Variable(&matrix.Matrix{Values: []float64{0.5}, Rows: 1, Columns: 1})
+	x, _ := t.Variable(&matrix.Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2})
+	y, _ := t.Variable(&matrix.Matrix{Values: []float64{2, 4}, Rows: 1, Columns: 2})
+
+	p, _ := autodiff.Product(w, x)
+	e, _ := autodiff.Subtract(p, y)
+	e, _ = autodiff.Multiply(e, e)
+	loss, _ := autodiff.Mean(e)
+
+	loss.Backward()
+	fmt.Println(loss.Value.Values, w.Grad.Values)
+	// Output:
+	// [5.625] [-7.5]
+}
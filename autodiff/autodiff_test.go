@@ -0,0 +1,235 @@
+package autodiff
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/azuwey/gonetwork/activationfn"
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// randomMatrix returns a matrix with elements uniformly distributed on "[min, max)".
+func randomMatrix(r *rand.Rand, rows, cols int, min, max float64) *matrix.Matrix {
+	m, _ := matrix.NewRandom(rows, cols, matrix.Uniform{Min: min, Max: max}, r)
+	return m
+}
+
+// evaluate records the inputs on a new tape and evaluates "f" on them.
+func evaluate(inputs []*matrix.Matrix, f func(vars []*Variable) (*Variable, error)) ([]*Variable, *Variable, error) {
+	t := NewTape()
+	vars := make([]*Variable, len(inputs))
+	for idx, in := range inputs {
+		vars[idx], _ = t.Variable(in)
+	}
+
+	out, err := f(vars)
+	return vars, out, err
+}
+
+// gradientCheck compares the gradients calculated by Backward with central finite differences, for every element of every input.
+func gradientCheck(t *testing.T, inputs []*matrix.Matrix, f func(vars []*Variable) (*Variable, error)) {
+	t.Helper()
+
+	vars, out, err := evaluate(inputs, f)
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if err := out.Backward(); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	const h = 1e-6
+	for idx, in := range inputs {
+		for k := range in.Values {
+			v := in.Values[k]
+			in.Values[k] = v + h
+			_, plus, _ := evaluate(inputs, f)
+			in.Values[k] = v - h
+			_, minus, _ := evaluate(inputs, f)
+			in.Values[k] = v
+
+			numeric := (plus.Value.Values[0] - minus.Value.Values[0]) / (2 * h)
+			analytic := 0.0
+			if vars[idx].Grad != nil {
+				analytic = vars[idx].Grad.Values[k]
+			}
+
+			if math.Abs(numeric-analytic) > 1e-6*math.Max(1, math.Abs(numeric)) {
+				t.Errorf("Input %d, element %d: expected gradient is %f, but got %f", idx, k, numeric, analytic)
+			}
+		}
+	}
+}
+
+func TestGradients(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	sigmoid := activationfn.ActivationFunctions["LogisticSigmoid"]
+	tanh := activationfn.ActivationFunctions["TanH"]
+	relu := activationfn.ActivationFunctions["ReLU"]
+
+	testCases := []struct {
+		name   string
+		inputs []*matrix.Matrix
+		f      func(v []*Variable) (*Variable, error)
+	}{
+		{"Add broadcast", []*matrix.Matrix{randomMatrix(r, 3, 4, -1, 1), randomMatrix(r, 3, 1, -1, 1), randomMatrix(r, 1, 4, -1, 1)},
+			func(v []*Variable) (*Variable, error) {
+				s, _ := Add(v[0], v[1])
+				p, _ := Multiply(s, v[2])
+				p, _ = Multiply(p, p)
+				return Sum(p)
+			}},
+		{"Subtract", []*matrix.Matrix{randomMatrix(r, 2, 3, -1, 1), randomMatrix(r, 1, 3, -1, 1)},
+			func(v []*Variable) (*Variable, error) {
+				d, _ := Subtract(v[0], v[1])
+				d, _ = Multiply(d, d)
+				return Mean(d)
+			}},
+		{"Product", []*matrix.Matrix{randomMatrix(r, 3, 2, -1, 1), randomMatrix(r, 2, 4, -1, 1), randomMatrix(r, 3, 4, -1, 1)},
+			func(v []*Variable) (*Variable, error) {
+				p, _ := Product(v[0], v[1])
+				p, _ = Multiply(p, v[2])
+				return Sum(p)
+			}},
+		{"Transpose and Scale", []*matrix.Matrix{randomMatrix(r, 3, 2, -1, 1), randomMatrix(r, 3, 2, -1, 1)},
+			func(v []*Variable) (*Variable, error) {
+				tr, _ := Transpose(v[0])
+				p, _ := Product(tr, v[1])
+				p, _ = Scale(-2.5, p)
+				p, _ = Multiply(p, p)
+				return Sum(p)
+			}},
+		{"Exp and Log", []*matrix.Matrix{randomMatrix(r, 2, 2, 0.5, 2)},
+			func(v []*Variable) (*Variable, error) {
+				l, _ := Log(v[0])
+				e, _ := Exp(v[0])
+				p, _ := Multiply(l, e)
+				return Sum(p)
+			}},
+		{"Activations", []*matrix.Matrix{randomMatrix(r, 3, 3, -2, 2), randomMatrix(r, 3, 3, -1, 1)},
+			func(v []*Variable) (*Variable, error) {
+				s, _ := Activate(sigmoid, v[0])
+				th, _ := Activate(tanh, v[0])
+				re, _ := Activate(relu, v[0])
+				p, _ := Multiply(s, th)
+				p, _ = Add(p, re)
+				p, _ = Multiply(p, v[1])
+				return Sum(p)
+			}},
+		{"Softmax cross-entropy", []*matrix.Matrix{randomMatrix(r, 4, 3, -3, 3), {Values: []float64{1, 0, 0, 0, 1, 0, 0, 0, 0.5, 0, 0, 0.5}, Rows: 4, Columns: 3}},
+			func(v []*Variable) (*Variable, error) {
+				s, _ := Softmax(v[0])
+				l, _ := Log(s)
+				p, _ := Multiply(l, v[1])
+				return Scale(-1, p)
+			}},
+		{"Reductions", []*matrix.Matrix{randomMatrix(r, 3, 4, -1, 1), randomMatrix(r, 3, 1, -1, 1), randomMatrix(r, 1, 4, -1, 1)},
+			func(v []*Variable) (*Variable, error) {
+				rows, _ := SumAxis(matrix.ByRow, v[0])
+				rows, _ = Multiply(rows, v[1])
+				cols, _ := MeanAxis(matrix.ByColumn, v[0])
+				cols, _ = Multiply(cols, v[2])
+				cols, _ = Multiply(cols, cols)
+				a, _ := Sum(rows)
+				b, _ := Sum(cols)
+				return Add(a, b)
+			}},
+		{"Layer", []*matrix.Matrix{randomMatrix(r, 3, 4, -1, 1), randomMatrix(r, 3, 1, -1, 1), randomMatrix(r, 4, 5, -1, 1), randomMatrix(r, 3, 5, 0, 1)},
+			func(v []*Variable) (*Variable, error) {
+				// The mean squared error of a sigmoid layer on a mini-batch of five samples.
+				z, _ := Product(v[0], v[2])
+				z, _ = Add(z, v[1])
+				y, _ := Activate(sigmoid, z)
+				e, _ := Subtract(y, v[3])
+				e, _ = Multiply(e, e)
+				return Mean(e)
+			}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gradientCheck(t, tc.inputs, func(v []*Variable) (*Variable, error) {
+				out, err := tc.f(v)
+				if err != nil || (out.Value.Rows == 1 && out.Value.Columns == 1) {
+					return out, err
+				}
+
+				return Sum(out)
+			})
+		})
+	}
+}
+
+func TestBackward(t *testing.T) {
+	t.Run("Unused variable", func(t *testing.T) {
+		t.Parallel()
+
+		tape := NewTape()
+		a, _ := tape.Variable(&matrix.Matrix{Values: []float64{2}, Rows: 1, Columns: 1})
+		b, _ := tape.Variable(&matrix.Matrix{Values: []float64{3}, Rows: 1, Columns: 1})
+		out, _ := Multiply(a, a)
+		if err := out.Backward(); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if a.Grad == nil || a.Grad.Values[0] != 4 {
+			t.Errorf("Expected gradient is %v, but got %v", 4, a.Grad)
+		}
+
+		if b.Grad != nil {
+			t.Errorf("Expected gradient is %v, but got %v", nil, b.Grad)
+		}
+
+		// The gradients are recalculated, not accumulated between the calls.
+		out.Backward()
+		if a.Grad.Values[0] != 4 {
+			t.Errorf("Expected gradient is %v, but got %v", 4, a.Grad)
+		}
+	})
+
+	t.Run("ErrNotScalar", func(t *testing.T) {
+		t.Parallel()
+
+		v, _ := NewTape().Variable(&matrix.Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1})
+		if err := v.Backward(); err != ErrNotScalar {
+			t.Errorf("Expected error is %v, but got %v", ErrNotScalar, err)
+		}
+	})
+}
+
+func TestErrors(t *testing.T) {
+	tape := NewTape()
+	a, _ := tape.Variable(&matrix.Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1})
+	other, _ := NewTape().Variable(&matrix.Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1})
+
+	testCases := []struct {
+		name          string
+		fn            func() (*Variable, error)
+		expectedError error
+	}{
+		{"ErrNilMatrix", func() (*Variable, error) { return tape.Variable(nil) }, ErrNilMatrix},
+		{"ErrNilVariable", func() (*Variable, error) { return Add(a, nil) }, ErrNilVariable},
+		{"ErrNilVariable unary", func() (*Variable, error) { return Exp(nil) }, ErrNilVariable},
+		{"ErrDifferentTapes", func() (*Variable, error) { return Multiply(a, other) }, ErrDifferentTapes},
+		{"ErrNilActivationFn", func() (*Variable, error) { return Activate(nil, a) }, ErrNilActivationFn},
+		{"matrix.ErrBadProductDimension", func() (*Variable, error) { return Product(a, a) }, matrix.ErrBadProductDimension},
+		{"matrix.ErrBadAxis", func() (*Variable, error) { return SumAxis(matrix.Axis(2), a) }, matrix.ErrBadAxis},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := tc.fn(); !errors.Is(err, tc.expectedError) {
+				t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+		})
+	}
+}
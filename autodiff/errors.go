@@ -0,0 +1,18 @@
+package autodiff
+
+import "errors"
+
+// ErrNilMatrix is returned by Variable when `m` is nil.
+var ErrNilMatrix = errors.New("autodiff: matrix must not be nil")
+
+// ErrNilVariable is returned by any operation that is require a variable as argument.
+var ErrNilVariable = errors.New("autodiff: variable must not be nil")
+
+// ErrDifferentTapes is returned by the operations when the variables are recorded on different tapes.
+var ErrDifferentTapes = errors.New("autodiff: the variables must be recorded on the same tape")
+
+// ErrNotScalar is returned by Backward when the value of the variable is not a 1 x 1 matrix.
+var ErrNotScalar = errors.New("autodiff: the gradient can only be calculated for a 1 x 1 variable")
+
+// ErrNilActivationFn is returned by Activate when `fn` is nil.
+var ErrNilActivationFn = errors.New("autodiff: activation function must not be nil")
@@ -0,0 +1,310 @@
+package autodiff
+
+import (
+	"github.com/azuwey/gonetwork/activationfn"
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// broadcastTo returns an "r x c" matrix with "g" broadcast to every element, "g" must be broadcastable to "r x c".
+func broadcastTo(g *matrix.Matrix, r, c int) (*matrix.Matrix, error) {
+	b, _ := matrix.New(r, c, nil)
+	if err := b.Add(b, g); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Add records the element-wise sum of "a" and "b", the operands are broadcast together the same way as in matrix.Add.
+// It will return an error if "a == nil" or "b == nil", they are recorded on different tapes, or their dimensions cannot be broadcast together.
+func Add(a, b *Variable) (*Variable, error) {
+	t, err := sameTape(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	if err := out.Add(a.Value, b.Value); err != nil {
+		return nil, err
+	}
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		if err := a.accumulate(g); err != nil {
+			return err
+		}
+
+		return b.accumulate(g)
+	}), nil
+}
+
+// Subtract records the element-wise difference of "a" and "b", in the order of "a - b", the operands are broadcast together the same way as in matrix.Subtract.
+// It will return the same errors as Add.
+func Subtract(a, b *Variable) (*Variable, error) {
+	t, err := sameTape(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	if err := out.Subtract(a.Value, b.Value); err != nil {
+		return nil, err
+	}
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		if err := a.accumulate(g); err != nil {
+			return err
+		}
+
+		gb := &matrix.Matrix{}
+		gb.Scale(-1, g)
+		return b.accumulate(gb)
+	}), nil
+}
+
+// Multiply records the element-wise product of "a" and "b", the operands are broadcast together the same way as in matrix.Multiply.
+// It will return the same errors as Add.
+func Multiply(a, b *Variable) (*Variable, error) {
+	t, err := sameTape(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	if err := out.Multiply(a.Value, b.Value); err != nil {
+		return nil, err
+	}
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga, gb := &matrix.Matrix{}, &matrix.Matrix{}
+		ga.Multiply(g, b.Value)
+		gb.Multiply(g, a.Value)
+		if err := a.accumulate(ga); err != nil {
+			return err
+		}
+
+		return b.accumulate(gb)
+	}), nil
+}
+
+// Product records the matrix multiplication of "a" and "b".
+// It will return an error if "a == nil" or "b == nil", they are recorded on different tapes, or the number of columns in "a" not equal with the number of rows in "b".
+func Product(a, b *Variable) (*Variable, error) {
+	t, err := sameTape(a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	if err := out.Product(a.Value, b.Value); err != nil {
+		return nil, err
+	}
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga, gb, tr := &matrix.Matrix{}, &matrix.Matrix{}, &matrix.Matrix{}
+		tr.Transpose(b.Value)
+		ga.Product(g, tr)
+		tr.Transpose(a.Value)
+		gb.Product(tr, g)
+		if err := a.accumulate(ga); err != nil {
+			return err
+		}
+
+		return b.accumulate(gb)
+	}), nil
+}
+
+// Scale records the multiplication of the elements of "a" by "s".
+// It will return an error if "a == nil".
+func Scale(s float64, a *Variable) (*Variable, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	out.Scale(s, a.Value)
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga := &matrix.Matrix{}
+		ga.Scale(s, g)
+		return a.accumulate(ga)
+	}), nil
+}
+
+// Transpose records the transpose of "a".
+// It will return an error if "a == nil".
+func Transpose(a *Variable) (*Variable, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	out.Transpose(a.Value)
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga := &matrix.Matrix{}
+		ga.Transpose(g)
+		return a.accumulate(ga)
+	}), nil
+}
+
+// Exp records "e**x" for each element "x" of "a".
+// It will return an error if "a == nil".
+func Exp(a *Variable) (*Variable, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	out.Exp(a.Value)
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga := &matrix.Matrix{}
+		ga.Multiply(g, out)
+		return a.accumulate(ga)
+	}), nil
+}
+
+// Log records the natural logarithm of each element of "a".
+// It will return an error if "a == nil".
+func Log(a *Variable) (*Variable, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	out.Log(a.Value)
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga := &matrix.Matrix{}
+		ga.Divide(g, a.Value)
+		return a.accumulate(ga)
+	}), nil
+}
+
+// Activate records the activation function "fn" applied to "a".
+// The gradient is calculated as the element-wise product of the gradient of the result and "fn.DeactivationFn" applied to "a",
+// which is exact for the element-wise activations, for the softmax functions use Softmax instead.
+// It will return an error if "fn == nil" or "a == nil".
+func Activate(fn *activationfn.ActivationFunction, a *Variable) (*Variable, error) {
+	if fn == nil {
+		return nil, ErrNilActivationFn
+	}
+
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	if err := out.ApplyVector(fn.ActivationFn, a.Value); err != nil {
+		return nil, err
+	}
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga := &matrix.Matrix{}
+		ga.ApplyVector(fn.DeactivationFn, a.Value)
+		ga.Multiply(g, ga)
+		return a.accumulate(ga)
+	}), nil
+}
+
+// Softmax records the softmax function applied to each column of "a", so every column of the result sums to one.
+// The largest element of each column is subtracted before the exponentiation, so it does not overflow.
+// It will return an error if "a == nil".
+func Softmax(a *Variable) (*Variable, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+
+	max, sum, out := &matrix.Matrix{}, &matrix.Matrix{}, &matrix.Matrix{}
+	max.MaxAxis(matrix.ByColumn, a.Value)
+	out.Subtract(a.Value, max)
+	out.Exp(out)
+	sum.SumAxis(matrix.ByColumn, out)
+	out.Divide(out, sum)
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		// The Jacobian of a column "y" is "diag(y) - y * y^T", so the gradient is "y * (g - sum(g * y))".
+		ga, dot := &matrix.Matrix{}, &matrix.Matrix{}
+		ga.Multiply(g, out)
+		dot.SumAxis(matrix.ByColumn, ga)
+		ga.Subtract(g, dot)
+		ga.Multiply(out, ga)
+		return a.accumulate(ga)
+	}), nil
+}
+
+// Sum records the sum of the elements of "a", the result is a 1 x 1 matrix.
+// It will return an error if "a == nil".
+func Sum(a *Variable) (*Variable, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out, _ := matrix.New(1, 1, []float64{a.Value.Sum()})
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga, err := broadcastTo(g, a.Value.Rows, a.Value.Columns)
+		if err != nil {
+			return err
+		}
+
+		return a.accumulate(ga)
+	}), nil
+}
+
+// Mean records the arithmetic mean of the elements of "a", the result is a 1 x 1 matrix.
+// It will return an error if "a == nil".
+func Mean(a *Variable) (*Variable, error) {
+	s, err := Sum(a)
+	if err != nil {
+		return nil, err
+	}
+
+	return Scale(1/float64(a.Value.Rows*a.Value.Columns), s)
+}
+
+// SumAxis records the sum of each row or column of "a", as selected by "axis", the same way as matrix.SumAxis.
+// It will return an error if "a == nil", or "axis" is neither matrix.ByRow nor matrix.ByColumn.
+func SumAxis(axis matrix.Axis, a *Variable) (*Variable, error) {
+	t, err := sameTape(a)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &matrix.Matrix{}
+	if err := out.SumAxis(axis, a.Value); err != nil {
+		return nil, err
+	}
+
+	return t.record(out, func(g *matrix.Matrix) error {
+		ga, err := broadcastTo(g, a.Value.Rows, a.Value.Columns)
+		if err != nil {
+			return err
+		}
+
+		return a.accumulate(ga)
+	}), nil
+}
+
+// MeanAxis records the mean of each row or column of "a", as selected by "axis", the same way as matrix.MeanAxis.
+// It will return the same errors as SumAxis.
+func MeanAxis(axis matrix.Axis, a *Variable) (*Variable, error) {
+	s, err := SumAxis(axis, a)
+	if err != nil {
+		return nil, err
+	}
+
+	n := a.Value.Columns
+	if axis == matrix.ByColumn {
+		n = a.Value.Rows
+	}
+
+	return Scale(1/float64(n), s)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/azuwey/gonetwork/activationfn"
 	"github.com/azuwey/gonetwork/common"
 	"github.com/azuwey/gonetwork/matrix"
+	"github.com/azuwey/gonetwork/tensor"
 )
 
 type ArtificialLayerDescriptor struct {
@@ -70,18 +71,17 @@ func NewArtificialLayer(d ArtificialLayerDescriptor, r *rand.Rand) (*artificialL
 		return nil, ErrNilRand
 	}
 
-	var wv []float64 = nil
+	if d.UUID == "" {
+		d.UUID = ArtificialLayerUUIDPrefix + common.GenerateUUID(10, r)
+	}
+
+	var w *matrix.Matrix
 	if d.Weights != nil {
-		wv = d.Weights
+		w, _ = matrix.New(d.OutputShape.Rows, d.InputShape.Rows, d.Weights)
 	} else {
-		wv = make([]float64, d.OutputShape.Rows*d.InputShape.Rows)
-		for i := range wv {
-			wv[i] = rand.Float64()*2 - 1
-		}
+		w, _ = matrix.NewRandom(d.OutputShape.Rows, d.InputShape.Rows, matrix.Uniform{Min: -1, Max: 1}, r)
 	}
 
-	w, _ := matrix.New(d.OutputShape.Rows, d.InputShape.Rows, wv)
-
 	var bv []float64 = nil
 	if d.Biases != nil {
 		bv = d.Biases
@@ -89,72 +89,83 @@ func NewArtificialLayer(d ArtificialLayerDescriptor, r *rand.Rand) (*artificialL
 
 	b, _ := matrix.New(d.OutputShape.Rows, 1, bv)
 
-	if d.UUID == "" {
-		d.UUID = ArtificialLayerUUIDPrefix + common.GenerateUUID(10, r)
-	}
-	layer := layer{d.UUID, d.InputShape, d.OutputShape, nil, nil, d.LearningRate, &matrix.Matrix{}, &matrix.Matrix{}, nil}
+	layer := layer{d.UUID, d.InputShape, d.OutputShape, nil, nil, d.LearningRate, &matrix.Matrix{}, &matrix.Matrix{}, &matrix.Matrix{}, &matrix.Pool{},
+		&matrix.Matrix{}, newSample(d.OutputShape), newSample(d.InputShape)}
 	return &artificialLayer{layer, aFn, w, b}, nil
 }
 
-func (l *artificialLayer) Forwardprop(input *matrix.Matrix) ([]float64, error) {
+func (l *artificialLayer) Forwardprop(input *tensor.Tensor) ([]float64, error) {
 	if input == nil {
 		return nil, ErrNilInput
 	}
 
-	if input.Rows != l.InputShape.Rows || input.Columns != l.InputShape.Columns || len(input.Values) != l.InputShape.Rows*l.InputShape.Columns {
-		return nil, ErrBadInputShape
+	if !l.InputShape.matches(input) {
+		return nil, l.InputShape.mismatch("Forwardprop", l.UUID, ErrBadInputShape, input)
 	}
 
-	l.input, _ = matrix.Copy(input)
+	l.input.SetValues(l.InputShape.size(), 1, input.Values[:l.InputShape.size()])
 
-	l.deactivated, _ = matrix.Copy(input)
 	l.deactivated.Product(l.weights, l.input)
 	l.deactivated.Add(l.biases, l.deactivated)
 
-	l.activated, _ = matrix.Copy(l.deactivated)
-	l.activated.Apply(l.activationFn.ActivationFn(l.activated), l.activated)
+	l.activated.ApplyVector(l.activationFn.ActivationFn, l.deactivated)
 
 	if l.Next == nil {
-		return l.activated.Values, nil
+		return append([]float64(nil), l.activated.Values...), nil
 	} else {
-		return l.Next.Forwardprop(l.activated)
+		l.output.Values = l.activated.Values
+		return l.Next.Forwardprop(l.output)
 	}
 }
 
-func (l *artificialLayer) Backprop(target *matrix.Matrix) error {
+func (l *artificialLayer) Backprop(target *tensor.Tensor) error {
 	if target == nil {
 		return ErrNilTarget
 	}
 
-	if target.Rows != l.OutputShape.Rows || target.Columns != l.OutputShape.Columns || len(target.Values) != l.OutputShape.Rows*l.OutputShape.Columns {
-		return ErrBadTargetShape
+	if !l.OutputShape.matches(target) {
+		return l.OutputShape.mismatch("Backprop", l.UUID, ErrBadTargetShape, target)
 	}
 
-	e := &matrix.Matrix{}
+	t := l.target
+	t.SetValues(l.OutputShape.size(), 1, target.Values[:l.OutputShape.size()])
+
+	outRows, inRows := l.OutputShape.Rows, l.InputShape.Rows
 
+	var e *matrix.Matrix
 	if l.Next == nil {
-		e.Subtract(target, l.activated)
+		e, _ = l.pool.Get(outRows, 1)
+		e.Subtract(t, l.activated)
 	} else {
-		e.Transpose(l.weights)
-		e.Product(e, target)
+		tr, _ := l.pool.Get(inRows, outRows)
+		tr.Transpose(l.weights)
+		e, _ = l.pool.Get(inRows, 1)
+		e.Product(tr, t)
+		l.pool.Put(tr)
 	}
+	defer l.pool.Put(e)
 
-	g := &matrix.Matrix{}
-	g.Apply(l.activationFn.DeactivationFn(l.deactivated), l.deactivated)
+	g, _ := l.pool.Get(outRows, 1)
+	defer l.pool.Put(g)
+	g.ApplyVector(l.activationFn.DeactivationFn, l.deactivated)
 	g.Multiply(e, g)
 
-	d := &matrix.Matrix{}
-	d.Transpose(l.input)
-	d.Product(g, d)
+	tr, _ := l.pool.Get(1, inRows)
+	tr.Transpose(l.input)
+	d, _ := l.pool.Get(outRows, inRows)
+	d.Product(g, tr)
 	d.Scale(*l.learningRate, d)
+	l.pool.Put(tr)
 
 	l.weights.Add(l.weights, d)
 	l.biases.Add(l.biases, g)
+	l.pool.Put(d)
 
 	if l.Previous == nil {
 		return nil
 	} else {
-		return l.Previous.Backprop(e)
+		l.backward.Values = e.Values
+		return l.Previous.Backprop(l.backward)
 	}
 }
 
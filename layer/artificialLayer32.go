@@ -0,0 +1,167 @@
+package layer
+
+import (
+	"math/rand"
+
+	"github.com/azuwey/gonetwork/activationfn"
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// artificialLayer32 is the single-precision counterpart of artificialLayer, the weights, the biases and every intermediate value are stored as float32.
+type artificialLayer32 struct {
+	UUID           string
+	InputShape     Shape
+	OutputShape    Shape
+	Previous, Next Layer32
+
+	learningRate                  *float64
+	activationFn                  *activationfn.ActivationFunction
+	weights, biases               *matrix.Matrix32
+	input, activated, deactivated *matrix.Matrix32
+
+	// The scratch matrices of Backprop32, they are kept between the calls, so the training does not allocate.
+	target, e, g, d, transposed *matrix.Matrix32
+}
+
+// *artificialLayer32 have to implement Layer32
+var _ Layer32 = &artificialLayer32{}
+
+// NewArtificialLayer32 creates a new single-precision artificial layer, the same way as NewArtificialLayer.
+// It will return the same errors as NewArtificialLayer, and it will also return an error if the activation function has no single-precision implementation.
+func NewArtificialLayer32(d ArtificialLayerDescriptor, r *rand.Rand) (*artificialLayer32, error) {
+	l, err := NewArtificialLayer(d, r)
+	if err != nil {
+		return nil, err
+	}
+
+	return l.Float32()
+}
+
+// Float32 converts the layer into a single-precision layer, the weights and the biases are rounded to float32.
+// The layer is not linked to the other layers, the Previous and the Next layers of the result have to be set again.
+// It will return an error if the activation function has no single-precision implementation.
+func (l *artificialLayer) Float32() (*artificialLayer32, error) {
+	if l.activationFn.ActivationFn32 == nil || l.activationFn.DeactivationFn32 == nil {
+		return nil, ErrNoFloat32ActivationFn
+	}
+
+	w, _ := matrix.ToFloat32(l.weights)
+	b, _ := matrix.ToFloat32(l.biases)
+
+	return &artificialLayer32{
+		UUID:         l.UUID,
+		InputShape:   l.InputShape,
+		OutputShape:  l.OutputShape,
+		learningRate: l.learningRate,
+		activationFn: l.activationFn,
+		weights:      w,
+		biases:       b,
+		input:        &matrix.Matrix32{},
+		activated:    &matrix.Matrix32{},
+		deactivated:  &matrix.Matrix32{},
+		target:       &matrix.Matrix32{},
+		e:            &matrix.Matrix32{},
+		g:            &matrix.Matrix32{},
+		d:            &matrix.Matrix32{},
+		transposed:   &matrix.Matrix32{},
+	}, nil
+}
+
+// Float64 converts the layer into a double-precision layer, the conversion of the weights and the biases is exact.
+// The layer is not linked to the other layers, the Previous and the Next layers of the result have to be set again.
+func (l *artificialLayer32) Float64() *artificialLayer {
+	w, _ := matrix.ToFloat64(l.weights)
+	b, _ := matrix.ToFloat64(l.biases)
+
+	layer := layer{l.UUID, l.InputShape, l.OutputShape, nil, nil, l.learningRate, &matrix.Matrix{}, &matrix.Matrix{}, &matrix.Matrix{}, &matrix.Pool{},
+		&matrix.Matrix{}, newSample(l.OutputShape), newSample(l.InputShape)}
+	return &artificialLayer{layer, l.activationFn, w, b}
+}
+
+func (l *artificialLayer32) Forwardprop32(input []float32) ([]float32, error) {
+	if input == nil {
+		return nil, ErrNilInput
+	}
+
+	if len(input) != l.InputShape.size() {
+		return nil, &matrix.ShapeError{Op: "Forwardprop32", Layer: l.UUID, Expected: []int{l.InputShape.size()}, Actual: []int{len(input)}, Err: ErrBadInputShape}
+	}
+
+	l.input.SetValues(len(input), 1, input)
+
+	l.deactivated.Product(l.weights, l.input)
+	l.deactivated.Add(l.biases, l.deactivated)
+
+	l.activated.ApplyVector(l.activationFn.ActivationFn32, l.deactivated)
+
+	if l.Next == nil {
+		return append([]float32(nil), l.activated.Values...), nil
+	} else {
+		return l.Next.Forwardprop32(l.activated.Values)
+	}
+}
+
+func (l *artificialLayer32) Backprop32(target []float32) error {
+	if target == nil {
+		return ErrNilTarget
+	}
+
+	if len(target) != l.OutputShape.size() {
+		return &matrix.ShapeError{Op: "Backprop32", Layer: l.UUID, Expected: []int{l.OutputShape.size()}, Actual: []int{len(target)}, Err: ErrBadTargetShape}
+	}
+
+	t := l.target
+	t.Values, t.Rows, t.Columns = target, len(target), 1
+
+	e := l.e
+	if l.Next == nil {
+		e.Subtract(t, l.activated)
+	} else {
+		l.transposed.Transpose(l.weights)
+		e.Product(l.transposed, t)
+	}
+
+	g := l.g
+	g.ApplyVector(l.activationFn.DeactivationFn32, l.deactivated)
+	g.Multiply(e, g)
+
+	d := l.d
+	l.transposed.Transpose(l.input)
+	d.Product(g, l.transposed)
+	d.Scale(float32(*l.learningRate), d)
+
+	l.weights.Add(l.weights, d)
+	l.biases.Add(l.biases, g)
+
+	if l.Previous == nil {
+		return nil
+	} else {
+		return l.Previous.Backprop32(e.Values)
+	}
+}
+
+func (l *artificialLayer32) GetLayerDescription() interface{} {
+	nextLayerUUID := ""
+	if l.Next != nil {
+		nextLayerUUID = l.Next.GetUUID()
+	}
+
+	w, _ := matrix.ToFloat64(l.weights)
+	b, _ := matrix.ToFloat64(l.biases)
+
+	return &ArtificialLayerDescriptor{
+		LayerDescriptor: LayerDescriptor{
+			UUID:          l.UUID,
+			NextLayerUUID: nextLayerUUID,
+			InputShape:    l.InputShape,
+			OutputShape:   l.OutputShape,
+		},
+		ActivationFn: l.activationFn.Name,
+		Weights:      w.Values,
+		Biases:       b.Values,
+	}
+}
+
+func (l *artificialLayer32) GetUUID() string {
+	return l.UUID
+}
@@ -0,0 +1,194 @@
+package layer
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/azuwey/gonetwork/activationfn"
+	"github.com/azuwey/gonetwork/matrix"
+	"github.com/azuwey/gonetwork/tensor"
+)
+
+// newLayerPair returns a linked pair of double-precision layers, and their linked single-precision counterparts.
+func newLayerPair(learningRate *float64) (*artificialLayer, *artificialLayer, *artificialLayer32, *artificialLayer32) {
+	r := rand.New(rand.NewSource(0))
+	hidden, _ := NewArtificialLayer(ArtificialLayerDescriptor{
+		LayerDescriptor{"", "", Shape{3, 1, 1}, Shape{3, 1, 1}, learningRate}, "TanH", nil, nil,
+	}, r)
+	output, _ := NewArtificialLayer(ArtificialLayerDescriptor{
+		LayerDescriptor{"", "", Shape{3, 1, 1}, Shape{3, 1, 1}, learningRate}, "LogisticSigmoid", nil, nil,
+	}, r)
+	hidden.Next, output.Previous = output, hidden
+
+	hidden32, _ := hidden.Float32()
+	output32, _ := output.Float32()
+	hidden32.Next, output32.Previous = output32, hidden32
+
+	return hidden, output, hidden32, output32
+}
+
+func isApproxEqual32(a []float32, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for idx := range a {
+		if math.Abs(float64(a[idx])-b[idx]) > tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestArtificialLayer32(t *testing.T) {
+	learningRate := 0.1
+	hidden, output, hidden32, output32 := newLayerPair(&learningRate)
+
+	inputs := [][]float64{{0.1, 0.5, -0.3}, {1, 0, 0.2}}
+	targets := [][]float64{{0, 1, 0}, {1, 0, 0}}
+	for epoch := 0; epoch < 10; epoch++ {
+		for idx := range inputs {
+			in32, _ := matrix.ToFloat32(&matrix.Matrix{Values: inputs[idx], Rows: 3, Columns: 1})
+			t32, _ := matrix.ToFloat32(&matrix.Matrix{Values: targets[idx], Rows: 3, Columns: 1})
+
+			o, err := hidden.Forwardprop(&tensor.Tensor{Values: inputs[idx], Shape: []int{1, 3, 1, 1}})
+			if err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			o32, err := hidden32.Forwardprop32(in32.Values)
+			if err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if !isApproxEqual32(o32, o, 1e-5) {
+				t.Fatalf("Expected output is %v, but got %v", o, o32)
+			}
+
+			if err := output.Backprop(&tensor.Tensor{Values: targets[idx], Shape: []int{1, 3, 1, 1}}); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if err := output32.Backprop32(t32.Values); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+		}
+	}
+
+	for _, pair := range [][2]interface{}{{hidden, hidden32}, {output, output32}} {
+		d := pair[0].(*artificialLayer).GetLayerDescription().(*ArtificialLayerDescriptor)
+		d32 := pair[1].(*artificialLayer32).GetLayerDescription().(*ArtificialLayerDescriptor)
+		for idx, w := range d.Weights {
+			if math.Abs(w-d32.Weights[idx]) > 1e-5 {
+				t.Errorf("Expected weight is %f, but got %f", w, d32.Weights[idx])
+			}
+		}
+
+		for idx, b := range d.Biases {
+			if math.Abs(b-d32.Biases[idx]) > 1e-5 {
+				t.Errorf("Expected bias is %f, but got %f", b, d32.Biases[idx])
+			}
+		}
+	}
+}
+
+func TestArtificialLayer32_errors(t *testing.T) {
+	learningRate := 0.1
+	_, _, hidden32, output32 := newLayerPair(&learningRate)
+
+	if _, err := hidden32.Forwardprop32(nil); err != ErrNilInput {
+		t.Errorf("Expected error is %v, but got %v", ErrNilInput, err)
+	}
+
+	if _, err := hidden32.Forwardprop32([]float32{1, 2}); !errors.Is(err, ErrBadInputShape) {
+		t.Errorf("Expected error is %v, but got %v", ErrBadInputShape, err)
+	}
+
+	if err := output32.Backprop32(nil); err != ErrNilTarget {
+		t.Errorf("Expected error is %v, but got %v", ErrNilTarget, err)
+	}
+
+	if err := output32.Backprop32([]float32{1, 2}); !errors.Is(err, ErrBadTargetShape) {
+		t.Errorf("Expected error is %v, but got %v", ErrBadTargetShape, err)
+	}
+
+	if _, err := NewArtificialLayer32(ArtificialLayerDescriptor{
+		LayerDescriptor{"", "", Shape{3, 1, 1}, Shape{2, 1, 1}, &learningRate}, "TanH", nil, nil,
+	}, nil); err != ErrNilRand {
+		t.Errorf("Expected error is %v, but got %v", ErrNilRand, err)
+	}
+
+	l := &artificialLayer{activationFn: &activationfn.ActivationFunction{Name: "Custom"}}
+	if _, err := l.Float32(); err != ErrNoFloat32ActivationFn {
+		t.Errorf("Expected error is %v, but got %v", ErrNoFloat32ActivationFn, err)
+	}
+}
+
+func TestArtificialLayer32_conversion(t *testing.T) {
+	learningRate := 0.1
+	l, err := NewArtificialLayer32(ArtificialLayerDescriptor{
+		LayerDescriptor{"ARTIFICIAL_conversion", "", Shape{2, 1, 1}, Shape{2, 1, 1}, &learningRate}, "ReLU",
+		[]float64{0.5, 0.25, -1, 2}, []float64{0.125, -0.5},
+	}, rand.New(rand.NewSource(0)))
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	d := l.Float64().GetLayerDescription().(*ArtificialLayerDescriptor)
+	if d.UUID != "ARTIFICIAL_conversion" || d.ActivationFn != "ReLU" {
+		t.Errorf("Expected UUID and activation function are %s and %s, but got %s and %s", "ARTIFICIAL_conversion", "ReLU", d.UUID, d.ActivationFn)
+	}
+
+	expectedWeights, expectedBiases := []float64{0.5, 0.25, -1, 2}, []float64{0.125, -0.5}
+	for idx, w := range d.Weights {
+		if w != expectedWeights[idx] {
+			t.Errorf("Expected weight is %f, but got %f", expectedWeights[idx], w)
+		}
+	}
+
+	for idx, b := range d.Biases {
+		if b != expectedBiases[idx] {
+			t.Errorf("Expected bias is %f, but got %f", expectedBiases[idx], b)
+		}
+	}
+}
+
+func TestArtificialLayer32_allocations(t *testing.T) {
+	learningRate := 0.1
+	_, _, hidden32, output32 := newLayerPair(&learningRate)
+	input, target := []float32{0.1, 0.5, -0.3}, []float32{0, 1, 0}
+	step := func() {
+		hidden32.Forwardprop32(input)
+		output32.Backprop32(target)
+	}
+	step()
+
+	// The only allocation is the copy of the output returned by the last layer.
+	if allocs := testing.AllocsPerRun(100, step); allocs != 1 {
+		t.Errorf("Expected number of allocations is %d, but got %f", 1, allocs)
+	}
+}
+
+func TestForwardprop32_artificialLayer32_output(t *testing.T) {
+	learningRate := 0.1
+	_, _, hidden32, _ := newLayerPair(&learningRate)
+
+	first, err := hidden32.Forwardprop32([]float32{0.1, 0.5, -0.3})
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+	expected := append([]float32(nil), first...)
+
+	if _, err := hidden32.Forwardprop32([]float32{-0.2, 0.4, 0.9}); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	for idx, v := range expected {
+		if first[idx] != v {
+			t.Errorf("Expected value is %f, but got %f", v, first[idx])
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package layer
+
+import (
+	"math/rand"
+	"runtime"
+	"testing"
+
+	"github.com/azuwey/gonetwork/tensor"
+)
+
+func BenchmarkEpoch_artificialLayer(b *testing.B) {
+	// The layers have the same size, since Backprop passes the error of a layer to the previous one in the shape of its own input.
+	learningRate, samples := 0.1, 100
+	rnd := rand.New(rand.NewSource(0))
+	hidden, _ := NewArtificialLayer(ArtificialLayerDescriptor{
+		LayerDescriptor{"", "", Shape{32, 1, 1}, Shape{32, 1, 1}, &learningRate}, "TanH", nil, nil,
+	}, rnd)
+	output, _ := NewArtificialLayer(ArtificialLayerDescriptor{
+		LayerDescriptor{"", "", Shape{32, 1, 1}, Shape{32, 1, 1}, &learningRate}, "LogisticSigmoid", nil, nil,
+	}, rnd)
+	hidden.Next, output.Previous = output, hidden
+
+	inputs, targets := make([]*tensor.Tensor, samples), make([]*tensor.Tensor, samples)
+	for idx := range inputs {
+		in := make([]float64, 32)
+		for i := range in {
+			in[i] = rnd.Float64()
+		}
+
+		t := make([]float64, 32)
+		t[rnd.Intn(len(t))] = 1
+
+		inputs[idx] = &tensor.Tensor{Values: in, Shape: []int{1, 32, 1, 1}}
+		targets[idx] = &tensor.Tensor{Values: t, Shape: []int{1, 32, 1, 1}}
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for idx := range inputs {
+			if _, err := hidden.Forwardprop(inputs[idx]); err != nil {
+				b.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if err := output.Backprop(targets[idx]); err != nil {
+				b.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+		}
+	}
+	b.StopTimer()
+
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.Mallocs-before.Mallocs)/float64(b.N*samples), "allocs/sample")
+}
@@ -1,12 +1,15 @@
 package layer
 
 import (
+	"errors"
+	"fmt"
 	"math"
 	"math/rand"
 	"testing"
 
 	"github.com/azuwey/gonetwork/activationfn"
 	"github.com/azuwey/gonetwork/matrix"
+	"github.com/azuwey/gonetwork/tensor"
 )
 
 func TestNew_artificialLayer(t *testing.T) {
@@ -118,21 +121,21 @@ func TestForwardprop_artificialLayer(t *testing.T) {
 		name               string
 		rand               *rand.Rand
 		layerDescriptions  []ArtificialLayerDescriptor
-		input              *matrix.Matrix
+		input              *tensor.Tensor
 		expectedPrediction []float64
 		expectedError      error
 	}{
 		{"Single layer", rand.New(rand.NewSource(0)), []ArtificialLayerDescriptor{
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
-			}}, &matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 2, Columns: 1}, []float64{0.16, 0.37, 0.58, 0.79}, nil,
+			}}, &tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 2, 1, 1}}, []float64{0.16, 0.37, 0.58, 0.79}, nil,
 		},
 		{"Dual layer", rand.New(rand.NewSource(0)), []ArtificialLayerDescriptor{
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "ARTIFICIAL_mdN6RA0rI1", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
 			}, {LayerDescriptor{"ARTIFICIAL_mdN6RA0rI1", "", Shape{4, 1, 1}, Shape{1, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4}, []float64{0.01},
-			}}, &matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 2, Columns: 1}, []float64{0.59}, nil,
+			}}, &tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 2, 1, 1}}, []float64{0.59}, nil,
 		},
 		{"ErrNilInput", rand.New(rand.NewSource(0)), []ArtificialLayerDescriptor{
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
@@ -142,17 +145,22 @@ func TestForwardprop_artificialLayer(t *testing.T) {
 		{"ErrBadInputShape empty input values", rand.New(rand.NewSource(0)), []ArtificialLayerDescriptor{
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
-			}}, &matrix.Matrix{Values: []float64{}, Rows: 2, Columns: 1}, []float64{0.16, 0.37, 0.58, 0.79}, ErrBadInputShape,
+			}}, &tensor.Tensor{Values: []float64{}, Shape: []int{1, 2, 1, 1}}, []float64{0.16, 0.37, 0.58, 0.79}, ErrBadInputShape,
 		},
 		{"ErrBadInputShape bad number of rows", rand.New(rand.NewSource(0)), []ArtificialLayerDescriptor{
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
-			}}, &matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 1, Columns: 1}, []float64{0.16, 0.37, 0.58, 0.79}, ErrBadInputShape,
+			}}, &tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 1, 1, 1}}, []float64{0.16, 0.37, 0.58, 0.79}, ErrBadInputShape,
 		},
 		{"ErrBadInputShape bad number of columns", rand.New(rand.NewSource(0)), []ArtificialLayerDescriptor{
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
-			}}, &matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 2, Columns: 2}, []float64{0.16, 0.37, 0.58, 0.79}, ErrBadInputShape,
+			}}, &tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 2, 2, 1}}, []float64{0.16, 0.37, 0.58, 0.79}, ErrBadInputShape,
+		},
+		{"ErrBadInputShape bad batch size", rand.New(rand.NewSource(0)), []ArtificialLayerDescriptor{
+			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
+				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
+			}}, &tensor.Tensor{Values: []float64{0.5, 0.5, 0.5, 0.5}, Shape: []int{2, 2, 1, 1}}, []float64{0.16, 0.37, 0.58, 0.79}, ErrBadInputShape,
 		},
 	}
 
@@ -187,7 +195,7 @@ func TestForwardprop_artificialLayer(t *testing.T) {
 
 			prediction, err := s.Forwardprop(tc.input)
 			if tc.expectedError != nil {
-				if err != tc.expectedError {
+				if !errors.Is(err, tc.expectedError) {
 					t.Errorf("expected error is %v, but got %v", tc.expectedError, err)
 				}
 			} else if err != nil {
@@ -209,7 +217,7 @@ func TestBackwardprop_artificialLayer(t *testing.T) {
 		name              string
 		rand              *rand.Rand
 		layerDescriptions []ArtificialLayerDescriptor
-		input, target     *matrix.Matrix
+		input, target     *tensor.Tensor
 		expectedWeights   [][]float64
 		expectedBiases    [][]float64
 		expectedError     error
@@ -218,8 +226,8 @@ func TestBackwardprop_artificialLayer(t *testing.T) {
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
 			}},
-			&matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 2, Columns: 1},
-			&matrix.Matrix{Values: []float64{0.16, 0.37, 0.58, 0.79}, Rows: 4, Columns: 1}, [][]float64{
+			&tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 2, 1, 1}},
+			&tensor.Tensor{Values: []float64{0.16, 0.37, 0.58, 0.79}, Shape: []int{1, 4, 1, 1}}, [][]float64{
 				{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8},
 			}, [][]float64{
 				{0.01, 0.02, 0.03, 0.04},
@@ -229,8 +237,8 @@ func TestBackwardprop_artificialLayer(t *testing.T) {
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
 			}},
-			&matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 2, Columns: 1},
-			&matrix.Matrix{Values: []float64{0.1, 0.7, 0.2, 0.9}, Rows: 4, Columns: 1}, [][]float64{
+			&tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 2, 1, 1}},
+			&tensor.Tensor{Values: []float64{0.1, 0.7, 0.2, 0.9}, Shape: []int{1, 4, 1, 1}}, [][]float64{
 				{0.097, 0.197, 0.3165, 0.4165, 0.481, 0.581, 0.7055, 0.8055},
 			}, [][]float64{
 				{-0.05, 0.35, -0.35, 0.15},
@@ -242,8 +250,8 @@ func TestBackwardprop_artificialLayer(t *testing.T) {
 			}, {LayerDescriptor{"ARTIFICIAL_mdN6RA0rI1", "", Shape{4, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
 			}},
-			&matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 2, Columns: 1},
-			&matrix.Matrix{Values: []float64{0.1, 0.7, 0.2, 0.9}, Rows: 4, Columns: 1}, [][]float64{
+			&tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 2, 1, 1}},
+			&tensor.Tensor{Values: []float64{0.1, 0.7, 0.2, 0.9}, Shape: []int{1, 4, 1, 1}}, [][]float64{
 				{0.15, 0.25, 0.35, 0.45, 0.55, 0.65, 0.75, 0.85},
 				{0.09216, 0.18187, 0.27158, 0.36129, 0.48944, 0.57558, 0.66172, 0.74786, 0.09344, 0.18483, 0.27622, 0.36761, 0.49232, 0.58224, 0.67216, 0.76208},
 			}, [][]float64{
@@ -255,7 +263,7 @@ func TestBackwardprop_artificialLayer(t *testing.T) {
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
 			}},
-			&matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 2, Columns: 1}, nil, [][]float64{
+			&tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 2, 1, 1}}, nil, [][]float64{
 				{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8},
 			}, nil, ErrNilTarget,
 		},
@@ -263,8 +271,8 @@ func TestBackwardprop_artificialLayer(t *testing.T) {
 			{LayerDescriptor{"ARTIFICIAL_mdN6RA0rI0", "", Shape{2, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU",
 				[]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8}, []float64{0.01, 0.02, 0.03, 0.04},
 			}},
-			&matrix.Matrix{Values: []float64{0.5, 0.5}, Rows: 2, Columns: 1},
-			&matrix.Matrix{Values: []float64{0.16, 0.37, 0.58}, Rows: 3, Columns: 1}, [][]float64{
+			&tensor.Tensor{Values: []float64{0.5, 0.5}, Shape: []int{1, 2, 1, 1}},
+			&tensor.Tensor{Values: []float64{0.16, 0.37, 0.58}, Shape: []int{1, 3, 1, 1}}, [][]float64{
 				{0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8},
 			}, nil, ErrBadTargetShape,
 		},
@@ -306,7 +314,7 @@ func TestBackwardprop_artificialLayer(t *testing.T) {
 			s.Forwardprop(tc.input)
 			err := e.Backprop(tc.target)
 			if tc.expectedError != nil {
-				if err != tc.expectedError {
+				if !errors.Is(err, tc.expectedError) {
 					t.Errorf("expected error is %v, but got %v", tc.expectedError, err)
 				}
 			} else if err != nil {
@@ -481,3 +489,113 @@ func TestGetUUID_artificialLayer(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_artificialLayer_seed(t *testing.T) {
+	learningRate := 0.1
+	d := ArtificialLayerDescriptor{LayerDescriptor{"", "", Shape{3, 1, 1}, Shape{4, 1, 1}, &learningRate}, "ReLU", nil, nil}
+
+	a, _ := NewArtificialLayer(d, rand.New(rand.NewSource(7)))
+	b, _ := NewArtificialLayer(d, rand.New(rand.NewSource(7)))
+	for idx, v := range a.weights.Values {
+		if w := b.weights.Values[idx]; v != w || v < -1 || v >= 1 {
+			t.Fatalf("expected weights are %v, but got %v", a.weights.Values, b.weights.Values)
+		}
+	}
+}
+
+func TestShapeError_artificialLayer(t *testing.T) {
+	learningRate := 0.1
+	l, _ := NewArtificialLayer(ArtificialLayerDescriptor{
+		LayerDescriptor{"ARTIFICIAL_shape", "", Shape{2, 1, 1}, Shape{3, 1, 1}, &learningRate}, "ReLU", nil, nil,
+	}, rand.New(rand.NewSource(0)))
+
+	testCases := []struct {
+		name          string
+		fn            func() error
+		op            string
+		expected      []int
+		actual        []int
+		expectedError error
+	}{
+		{"Forwardprop shape", func() error {
+			_, err := l.Forwardprop(&tensor.Tensor{Values: []float64{1, 2, 3}, Shape: []int{1, 3, 1, 1}})
+			return err
+		}, "Forwardprop", []int{1, 2, 1, 1}, []int{1, 3, 1, 1}, ErrBadInputShape},
+		{"Forwardprop values", func() error {
+			_, err := l.Forwardprop(&tensor.Tensor{Values: []float64{1}, Shape: []int{1, 2, 1, 1}})
+			return err
+		}, "Forwardprop", []int{2}, []int{1}, ErrBadInputShape},
+		{"Backprop", func() error {
+			return l.Backprop(&tensor.Tensor{Values: []float64{1, 2}, Shape: []int{1, 2, 1, 1}})
+		}, "Backprop", []int{1, 3, 1, 1}, []int{1, 2, 1, 1}, ErrBadTargetShape},
+	}
+
+	for _, tc := range testCases {
+		err := tc.fn()
+		if !errors.Is(err, tc.expectedError) {
+			t.Fatalf("%s: expected error is %v, but got %v", tc.name, tc.expectedError, err)
+		}
+
+		var shapeErr *matrix.ShapeError
+		if !errors.As(err, &shapeErr) {
+			t.Fatalf("%s: expected a ShapeError, but got %T", tc.name, err)
+		}
+
+		if shapeErr.Op != tc.op || shapeErr.Layer != "ARTIFICIAL_shape" || fmt.Sprint(shapeErr.Expected) != fmt.Sprint(tc.expected) || fmt.Sprint(shapeErr.Actual) != fmt.Sprint(tc.actual) {
+			t.Errorf("%s: expected ShapeError is %s in layer %s, %v and %v, but got %s in layer %s, %v and %v", tc.name,
+				tc.op, "ARTIFICIAL_shape", tc.expected, tc.actual, shapeErr.Op, shapeErr.Layer, shapeErr.Expected, shapeErr.Actual)
+		}
+	}
+}
+
+func TestTrain_artificialLayer_allocations(t *testing.T) {
+	learningRate := 0.1
+	rnd := rand.New(rand.NewSource(0))
+	hidden, _ := NewArtificialLayer(ArtificialLayerDescriptor{
+		LayerDescriptor{"", "", Shape{4, 1, 1}, Shape{4, 1, 1}, &learningRate}, "TanH", nil, nil,
+	}, rnd)
+	output, _ := NewArtificialLayer(ArtificialLayerDescriptor{
+		LayerDescriptor{"", "", Shape{4, 1, 1}, Shape{4, 1, 1}, &learningRate}, "LogisticSigmoid", nil, nil,
+	}, rnd)
+	hidden.Next, output.Previous = output, hidden
+
+	input := &tensor.Tensor{Values: []float64{0, 1, 1, 0}, Shape: []int{1, 4, 1, 1}}
+	target := &tensor.Tensor{Values: []float64{0, 1, 1, 1}, Shape: []int{1, 4, 1, 1}}
+	train := func() {
+		if _, err := hidden.Forwardprop(input); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if err := output.Backprop(target); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+	}
+	train()
+
+	// The only allocation is the copy of the output returned by the last layer.
+	if allocs := testing.AllocsPerRun(100, train); allocs != 1 {
+		t.Errorf("Expected number of allocations is %d, but got %f", 1, allocs)
+	}
+}
+
+func TestForwardprop_artificialLayer_output(t *testing.T) {
+	learningRate := 0.1
+	l, _ := NewArtificialLayer(ArtificialLayerDescriptor{
+		LayerDescriptor{"", "", Shape{2, 1, 1}, Shape{2, 1, 1}, &learningRate}, "ReLU", []float64{1, 0, 0, 1}, []float64{0, 0},
+	}, rand.New(rand.NewSource(0)))
+
+	first, err := l.Forwardprop(&tensor.Tensor{Values: []float64{1, 2}, Shape: []int{1, 2, 1, 1}})
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if _, err := l.Forwardprop(&tensor.Tensor{Values: []float64{3, 4}, Shape: []int{1, 2, 1, 1}}); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	for idx, v := range []float64{1, 2} {
+		if first[idx] != v {
+			t.Errorf("Expected value is %f, but got %f", v, first[idx])
+		}
+	}
+}
@@ -35,11 +35,14 @@ var ErrNilRand = errors.New("layer: the rand cannot be nil")
 // ErrNilInput is returned by Forwardprop when the input matrix is nil.
 var ErrNilInput = errors.New("layer: the input matrix cannot be nil")
 
-// ErrBadInputShape is returned by Forwardprop when the input matrix shape does not match the input shape
+// ErrBadInputShape is returned by Forwardprop when the input matrix shape does not match the input shape, wrapped in a matrix.ShapeError
 var ErrBadInputShape = errors.New("layer: the provided input matrix does not match the input shape")
 
 // ErrNilTarget is returned by Backprop when the target matrix is nil.
 var ErrNilTarget = errors.New("layer: the target matrix cannot be nil")
 
-// ErrBadTargetShape is returned by Backprop when the target matrix shape does not match the output shape
+// ErrBadTargetShape is returned by Backprop when the target matrix shape does not match the output shape, wrapped in a matrix.ShapeError
 var ErrBadTargetShape = errors.New("layer: the provided target matrix does not match the output shape")
+
+// ErrNoFloat32ActivationFn is returned by Float32 and NewArtificialLayer32 when the activation function has no single-precision implementation.
+var ErrNoFloat32ActivationFn = errors.New("layer: the activation function does not have a single-precision implementation")
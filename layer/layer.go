@@ -2,6 +2,7 @@ package layer
 
 import (
 	"github.com/azuwey/gonetwork/matrix"
+	"github.com/azuwey/gonetwork/tensor"
 )
 
 /* type Shape struct {
@@ -55,6 +56,38 @@ type Shape struct {
 	Depth   int `json:"depth"`
 }
 
+// TensorShape returns the shape of the tensors that hold "batch" samples of this shape, in the "[batch, rows, columns, depth]" order.
+func (s Shape) TensorShape(batch int) []int {
+	return []int{batch, s.Rows, s.Columns, s.Depth}
+}
+
+// matches reports whether "t" holds a single, contiguous sample of this shape.
+func (s Shape) matches(t *tensor.Tensor) bool {
+	if len(t.Shape) != 4 || t.Shape[0] != 1 || t.Shape[1] != s.Rows || t.Shape[2] != s.Columns || t.Shape[3] != s.Depth {
+		return false
+	}
+
+	return t.IsContiguous() && len(t.Values) >= s.Rows*s.Columns*s.Depth
+}
+
+// mismatch returns the ShapeError of the "op" operation of the layer "uuid" with the sentinel "err", for a tensor "t" that does not match this shape.
+// When the shape of the tensor is right, but its values are not, the expected and the actual shapes are the numbers of the elements.
+func (s Shape) mismatch(op, uuid string, err error, t *tensor.Tensor) *matrix.ShapeError {
+	e := &matrix.ShapeError{Op: op, Layer: uuid, Expected: s.TensorShape(1), Actual: append([]int(nil), t.Shape...), Err: err}
+	if len(e.Actual) != len(e.Expected) {
+		return e
+	}
+
+	for idx, d := range e.Expected {
+		if e.Actual[idx] != d {
+			return e
+		}
+	}
+
+	e.Expected, e.Actual = []int{s.size()}, []int{len(t.Values)}
+	return e
+}
+
 type LayerDescriptor struct {
 	UUID          string `json:"uuid"`
 	NextLayerUUID string `json:"nextLayerUUID"`
@@ -65,11 +98,27 @@ type LayerDescriptor struct {
 }
 
 type Layer interface {
-	// Forwardprop performs forwardpropagation for the current layer
-	Forwardprop(input *matrix.Matrix) ([]float64, error)
+	// Forwardprop performs forwardpropagation for the current layer, the input has the "[batch, rows, columns, depth]" shape
+	Forwardprop(input *tensor.Tensor) ([]float64, error)
+
+	// Backprop performs backpropagation for the current layer, the target has the "[batch, rows, columns, depth]" shape
+	Backprop(target *tensor.Tensor) error
+
+	// GetLayerDescription is return a the layer description in an interface{} format
+	GetLayerDescription() interface{}
 
-	// Backprop performs backpropagation for the current layer
-	Backprop(target *matrix.Matrix) error
+	// GetUUID returns the UUID of the layer
+	GetUUID() string
+}
+
+// Layer32 is the single-precision counterpart of Layer, the input and the target hold a single sample in a flat slice,
+// its length has to be "rows * columns * depth" of the input and the output shape respectively.
+type Layer32 interface {
+	// Forwardprop32 performs forwardpropagation for the current layer
+	Forwardprop32(input []float32) ([]float32, error)
+
+	// Backprop32 performs backpropagation for the current layer
+	Backprop32(target []float32) error
 
 	// GetLayerDescription is return a the layer description in an interface{} format
 	GetLayerDescription() interface{}
@@ -78,6 +127,11 @@ type Layer interface {
 	GetUUID() string
 }
 
+// size returns the number of elements of a single sample of this shape.
+func (s Shape) size() int {
+	return s.Rows * s.Columns * s.Depth
+}
+
 type layer struct {
 	UUID           string
 	InputShape     Shape
@@ -86,4 +140,17 @@ type layer struct {
 
 	learningRate                  *float64
 	input, activated, deactivated *matrix.Matrix
+
+	// pool holds the scratch matrices of Backprop, so the training does not allocate new ones for every sample.
+	pool *matrix.Pool
+
+	// target holds the target of Backprop, and output and backward wrap the samples passed to Next and Previous,
+	// so their headers are not allocated for every sample either.
+	target           *matrix.Matrix
+	output, backward *tensor.Tensor
+}
+
+// newSample returns a reusable tensor that holds a single sample of the shape "s", its Values have to be set before every use.
+func newSample(s Shape) *tensor.Tensor {
+	return &tensor.Tensor{Shape: s.TensorShape(1)}
 }
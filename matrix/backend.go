@@ -0,0 +1,271 @@
+package matrix
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Backend implements the compute kernels of the matrix operations, so they can be replaced without changing the callers,
+// e.g. with an unrolled or an assembly implementation.
+// The element-wise kernels are called with slices of the same length, either with whole matrices or row by row,
+// "dst" may be the same slice as any of the operands, but it never overlaps them partially.
+// Every kernel has to overwrite all of the elements of "dst", the previous content of "dst" is undefined.
+type Backend interface {
+	// Name returns the name of the backend, e.g. "go".
+	Name() string
+
+	// Product calculates the matrix multiplication of "a" and "b" into "dst".
+	// The "dst" already has the dimensions of the result and it does not share elements with the operands, any of the matrices may be a view.
+	Product(dst, a, b *Matrix)
+
+	// Add calculates "a[i] + b[i]" into "dst[i]".
+	Add(dst, a, b []float64)
+
+	// Subtract calculates "a[i] - b[i]" into "dst[i]".
+	Subtract(dst, a, b []float64)
+
+	// Multiply calculates "a[i] * b[i]" into "dst[i]".
+	Multiply(dst, a, b []float64)
+
+	// Divide calculates "a[i] / b[i]" into "dst[i]".
+	Divide(dst, a, b []float64)
+
+	// Scale calculates "s * a[i]" into "dst[i]".
+	Scale(dst []float64, s float64, a []float64)
+
+	// Apply calculates "fn(a[i])" into "dst[i]".
+	Apply(dst, a []float64, fn ApplyFn)
+}
+
+// GoBackend is the default Backend, it is implemented in pure Go, and its Product is blocked and multi-threaded (see SetProductWorkers).
+var GoBackend Backend = goBackend{}
+
+// backendHolder wraps the global backend, so values of different dynamic types can be stored in the same atomic.Value.
+type backendHolder struct {
+	b Backend
+}
+
+var (
+	// globalBackend is the Backend used by the matrices that do not have their own.
+	globalBackend atomic.Value
+
+	// globalBackendMu serializes the calls of SetBackend.
+	globalBackendMu sync.Mutex
+)
+
+func init() {
+	globalBackend.Store(backendHolder{GoBackend})
+}
+
+// SetBackend sets the Backend used by the matrices that do not have their own, and returns the previous one.
+// If "b == nil", GoBackend is used.
+func SetBackend(b Backend) Backend {
+	if b == nil {
+		b = GoBackend
+	}
+
+	globalBackendMu.Lock()
+	defer globalBackendMu.Unlock()
+
+	prev := DefaultBackend()
+	globalBackend.Store(backendHolder{b})
+
+	return prev
+}
+
+// DefaultBackend returns the Backend used by the matrices that do not have their own.
+func DefaultBackend() Backend {
+	return globalBackend.Load().(backendHolder).b
+}
+
+// SetBackend sets the Backend used by the operations that place their result in the receiver.
+// If "b == nil", the receiver uses the global backend (see the SetBackend function).
+// The views and the copies of the receiver inherit its backend.
+func (m *Matrix) SetBackend(b Backend) {
+	m.backend = b
+}
+
+// Backend returns the Backend used by the operations that place their result in the receiver.
+func (m *Matrix) Backend() Backend {
+	if m.backend != nil {
+		return m.backend
+	}
+
+	return DefaultBackend()
+}
+
+// goBackend implements GoBackend.
+type goBackend struct{}
+
+func (goBackend) Name() string {
+	return "go"
+}
+
+func (goBackend) Product(dst, a, b *Matrix) {
+	dst.product(a, b)
+}
+
+func (goBackend) Add(dst, a, b []float64) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := range dst {
+		dst[idx] = a[idx] + b[idx]
+	}
+}
+
+func (goBackend) Subtract(dst, a, b []float64) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := range dst {
+		dst[idx] = a[idx] - b[idx]
+	}
+}
+
+func (goBackend) Multiply(dst, a, b []float64) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := range dst {
+		dst[idx] = a[idx] * b[idx]
+	}
+}
+
+func (goBackend) Divide(dst, a, b []float64) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := range dst {
+		dst[idx] = a[idx] / b[idx]
+	}
+}
+
+func (goBackend) Scale(dst []float64, s float64, a []float64) {
+	a = a[:len(dst)]
+	for idx := range dst {
+		dst[idx] = s * a[idx]
+	}
+}
+
+func (goBackend) Apply(dst, a []float64, fn ApplyFn) {
+	a = a[:len(dst)]
+	for idx := range dst {
+		dst[idx] = fn(a[idx])
+	}
+}
+
+// Backend32 is the single-precision counterpart of Backend, it implements the compute kernels of the Matrix32 operations.
+// The kernels are called the same way as the kernels of Backend.
+type Backend32 interface {
+	// Name returns the name of the backend, e.g. "go".
+	Name() string
+
+	// Product calculates the matrix multiplication of "a" and "b" into "dst".
+	// The "dst" already has the dimensions of the result and it does not share elements with the operands.
+	Product(dst, a, b *Matrix32)
+
+	// Add calculates "a[i] + b[i]" into "dst[i]".
+	Add(dst, a, b []float32)
+
+	// Subtract calculates "a[i] - b[i]" into "dst[i]".
+	Subtract(dst, a, b []float32)
+
+	// Multiply calculates "a[i] * b[i]" into "dst[i]".
+	Multiply(dst, a, b []float32)
+
+	// Divide calculates "a[i] / b[i]" into "dst[i]".
+	Divide(dst, a, b []float32)
+
+	// Scale calculates "s * a[i]" into "dst[i]".
+	Scale(dst []float32, s float32, a []float32)
+
+	// Apply calculates "fn(a[i])" into "dst[i]".
+	Apply(dst, a []float32, fn ApplyFn32)
+}
+
+// GoBackend32 is the default Backend32, it is implemented in pure Go, and its Product is blocked and multi-threaded the same way as the Product of GoBackend.
+var GoBackend32 Backend32 = goBackend32{}
+
+// backendHolder32 wraps the global Backend32 the same way as backendHolder.
+type backendHolder32 struct {
+	b Backend32
+}
+
+var (
+	// globalBackend32 is the Backend32 used by the matrices that do not have their own.
+	globalBackend32 atomic.Value
+
+	// globalBackend32Mu serializes the calls of SetBackend32.
+	globalBackend32Mu sync.Mutex
+)
+
+func init() {
+	globalBackend32.Store(backendHolder32{GoBackend32})
+}
+
+// SetBackend32 sets the Backend32 used by the Matrix32 matrices that do not have their own, and returns the previous one.
+// If "b == nil", GoBackend32 is used.
+func SetBackend32(b Backend32) Backend32 {
+	if b == nil {
+		b = GoBackend32
+	}
+
+	globalBackend32Mu.Lock()
+	defer globalBackend32Mu.Unlock()
+
+	prev := DefaultBackend32()
+	globalBackend32.Store(backendHolder32{b})
+
+	return prev
+}
+
+// DefaultBackend32 returns the Backend32 used by the Matrix32 matrices that do not have their own.
+func DefaultBackend32() Backend32 {
+	return globalBackend32.Load().(backendHolder32).b
+}
+
+// goBackend32 implements GoBackend32.
+type goBackend32 struct{}
+
+func (goBackend32) Name() string {
+	return "go"
+}
+
+func (goBackend32) Product(dst, a, b *Matrix32) {
+	dst.product(a, b)
+}
+
+func (goBackend32) Add(dst, a, b []float32) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := range dst {
+		dst[idx] = a[idx] + b[idx]
+	}
+}
+
+func (goBackend32) Subtract(dst, a, b []float32) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := range dst {
+		dst[idx] = a[idx] - b[idx]
+	}
+}
+
+func (goBackend32) Multiply(dst, a, b []float32) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := range dst {
+		dst[idx] = a[idx] * b[idx]
+	}
+}
+
+func (goBackend32) Divide(dst, a, b []float32) {
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := range dst {
+		dst[idx] = a[idx] / b[idx]
+	}
+}
+
+func (goBackend32) Scale(dst []float32, s float32, a []float32) {
+	a = a[:len(dst)]
+	for idx := range dst {
+		dst[idx] = s * a[idx]
+	}
+}
+
+func (goBackend32) Apply(dst, a []float32, fn ApplyFn32) {
+	a = a[:len(dst)]
+	for idx := range dst {
+		dst[idx] = fn(a[idx])
+	}
+}
@@ -0,0 +1,241 @@
+package matrix
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// countingBackend counts the calls of its kernels, and uses GoBackend to calculate them.
+type countingBackend struct {
+	Backend
+	calls *int64
+}
+
+func newCountingBackend() countingBackend {
+	return countingBackend{GoBackend, new(int64)}
+}
+
+func (b countingBackend) Product(dst, aMat, bMat *Matrix) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend.Product(dst, aMat, bMat)
+}
+
+func (b countingBackend) Add(dst, aVals, bVals []float64) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend.Add(dst, aVals, bVals)
+}
+
+func (b countingBackend) Scale(dst []float64, s float64, aVals []float64) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend.Scale(dst, s, aVals)
+}
+
+func (b countingBackend) Apply(dst, aVals []float64, fn ApplyFn) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend.Apply(dst, aVals, fn)
+}
+
+func TestBackend(t *testing.T) {
+	aMat := &Matrix{Values: []float64{1, 2, 3, 4}, Rows: 2, Columns: 2}
+	testCases := []struct {
+		name string
+		fn   func(m *Matrix) error
+	}{
+		{"Add", func(m *Matrix) error { return m.Add(aMat, aMat) }},
+		{"Scale", func(m *Matrix) error { return m.Scale(2, aMat) }},
+		{"Apply", func(m *Matrix) error { return m.Apply(func(v float64) float64 { return v }, aMat) }},
+		{"Product", func(m *Matrix) error { return m.Product(aMat, aMat) }},
+		{"Product in-place", func(m *Matrix) error { m.CopyFrom(aMat); return m.Product(m, m) }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := newCountingBackend()
+			m := &Matrix{}
+			m.SetBackend(b)
+			if m.Backend() != Backend(b) {
+				t.Fatalf("Expected backend is %v, but got %v", b, m.Backend())
+			}
+
+			if err := tc.fn(m); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if *b.calls == 0 {
+				t.Error("The backend of the receiver should be used")
+			}
+		})
+	}
+
+	t.Run("Inherited", func(t *testing.T) {
+		t.Parallel()
+
+		b := newCountingBackend()
+		m, _ := New(3, 3, nil)
+		m.SetBackend(b)
+
+		v, _ := m.Slice(0, 2, 0, 2)
+		c, _ := Copy(m)
+		if v.Backend() != Backend(b) || c.Backend() != Backend(b) {
+			t.Errorf("Expected backend is %v, but got %v and %v", b, v.Backend(), c.Backend())
+		}
+	})
+}
+
+func TestSetBackend(t *testing.T) {
+	b := newCountingBackend()
+	if prev := SetBackend(b); prev != GoBackend {
+		t.Errorf("Expected previous backend is %v, but got %v", GoBackend, prev)
+	}
+
+	defer SetBackend(nil)
+
+	m := &Matrix{}
+	m.Add(&Matrix{Values: []float64{1}, Rows: 1, Columns: 1}, &Matrix{Values: []float64{2}, Rows: 1, Columns: 1})
+	if *b.calls != 1 || DefaultBackend() != Backend(b) {
+		t.Errorf("Expected number of calls is %d, but got %d", 1, *b.calls)
+	}
+
+	// The backend of a matrix takes precedence over the global backend.
+	m.SetBackend(GoBackend)
+	m.Add(m, m)
+	if *b.calls != 1 {
+		t.Errorf("Expected number of calls is %d, but got %d", 1, *b.calls)
+	}
+
+	if prev := SetBackend(nil); prev != Backend(b) || DefaultBackend() != GoBackend {
+		t.Errorf("Expected previous backend is %v, but got %v", b, prev)
+	}
+}
+
+// countingBackend32 counts the calls of its kernels, and uses GoBackend32 to calculate them.
+type countingBackend32 struct {
+	Backend32
+	calls *int64
+}
+
+func newCountingBackend32() countingBackend32 {
+	return countingBackend32{GoBackend32, new(int64)}
+}
+
+func (b countingBackend32) Product(dst, aMat, bMat *Matrix32) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend32.Product(dst, aMat, bMat)
+}
+
+func (b countingBackend32) Add(dst, aVals, bVals []float32) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend32.Add(dst, aVals, bVals)
+}
+
+func (b countingBackend32) Subtract(dst, aVals, bVals []float32) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend32.Subtract(dst, aVals, bVals)
+}
+
+func (b countingBackend32) Multiply(dst, aVals, bVals []float32) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend32.Multiply(dst, aVals, bVals)
+}
+
+func (b countingBackend32) Divide(dst, aVals, bVals []float32) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend32.Divide(dst, aVals, bVals)
+}
+
+func (b countingBackend32) Scale(dst []float32, s float32, aVals []float32) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend32.Scale(dst, s, aVals)
+}
+
+func (b countingBackend32) Apply(dst, aVals []float32, fn ApplyFn32) {
+	atomic.AddInt64(b.calls, 1)
+	b.Backend32.Apply(dst, aVals, fn)
+}
+
+func TestBackend32(t *testing.T) {
+	aMat := &Matrix32{Values: []float32{1, 2, 3, 4}, Rows: 2, Columns: 2}
+	testCases := []struct {
+		name     string
+		fn       func(m *Matrix32) error
+		expected []float32
+	}{
+		{"Add", func(m *Matrix32) error { return m.Add(aMat, aMat) }, []float32{2, 4, 6, 8}},
+		{"Subtract", func(m *Matrix32) error { return m.Subtract(aMat, aMat) }, []float32{0, 0, 0, 0}},
+		{"Multiply", func(m *Matrix32) error { return m.Multiply(aMat, aMat) }, []float32{1, 4, 9, 16}},
+		{"Divide", func(m *Matrix32) error { return m.Divide(aMat, aMat) }, []float32{1, 1, 1, 1}},
+		{"Scale", func(m *Matrix32) error { return m.Scale(2, aMat) }, []float32{2, 4, 6, 8}},
+		{"Apply", func(m *Matrix32) error { return m.Apply(func(v float32) float32 { return -v }, aMat) }, []float32{-1, -2, -3, -4}},
+		{"Product", func(m *Matrix32) error { return m.Product(aMat, aMat) }, []float32{7, 10, 15, 22}},
+		{"Product in-place", func(m *Matrix32) error { m.CopyFrom(aMat); return m.Product(m, m) }, []float32{7, 10, 15, 22}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b := newCountingBackend32()
+			m := &Matrix32{}
+			m.SetBackend(b)
+			if m.Backend() != Backend32(b) {
+				t.Fatalf("Expected backend is %v, but got %v", b, m.Backend())
+			}
+
+			if err := tc.fn(m); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if *b.calls == 0 {
+				t.Error("The backend of the receiver should be used")
+			}
+
+			for idx, v := range tc.expected {
+				if m.Values[idx] != v {
+					t.Fatalf("Expected values are %v, but got %v", tc.expected, m.Values)
+				}
+			}
+		})
+	}
+
+	t.Run("Inherited", func(t *testing.T) {
+		t.Parallel()
+
+		b := newCountingBackend32()
+		m, _ := New32(2, 2, nil)
+		m.SetBackend(b)
+
+		if c, _ := Copy32(m); c.Backend() != Backend32(b) {
+			t.Errorf("Expected backend is %v, but got %v", b, c.Backend())
+		}
+	})
+}
+
+func TestSetBackend32(t *testing.T) {
+	b := newCountingBackend32()
+	if prev := SetBackend32(b); prev != GoBackend32 {
+		t.Errorf("Expected previous backend is %v, but got %v", GoBackend32, prev)
+	}
+
+	defer SetBackend32(nil)
+
+	m := &Matrix32{}
+	m.Add(&Matrix32{Values: []float32{1}, Rows: 1, Columns: 1}, &Matrix32{Values: []float32{2}, Rows: 1, Columns: 1})
+	if *b.calls != 1 || DefaultBackend32() != Backend32(b) {
+		t.Errorf("Expected number of calls is %d, but got %d", 1, *b.calls)
+	}
+
+	// The backend of a matrix takes precedence over the global backend.
+	m.SetBackend(GoBackend32)
+	m.Add(m, m)
+	if *b.calls != 1 {
+		t.Errorf("Expected number of calls is %d, but got %d", 1, *b.calls)
+	}
+
+	if prev := SetBackend32(nil); prev != Backend32(b) || DefaultBackend32() != GoBackend32 {
+		t.Errorf("Expected previous backend is %v, but got %v", b, prev)
+	}
+}
@@ -0,0 +1,250 @@
+// Package backendtest implements the conformance test suite that every matrix.Backend must pass.
+//
+// A backend runs the suite from its own tests:
+//
+//	func TestBackend(t *testing.T) {
+//		backendtest.Run(t, myBackend{})
+//	}
+package backendtest
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// lengths are the lengths of the slices passed to the element-wise kernels, they cover the remainders of the usual unrolling and vector widths.
+var lengths = []int{0, 1, 2, 3, 4, 5, 7, 8, 9, 15, 16, 17, 31, 32, 33, 64, 100, 1000}
+
+// productDimensions are the "rows of a x columns of a x columns of b" dimensions of the tested products.
+var productDimensions = [][3]int{
+	{1, 1, 1}, {1, 7, 1}, {7, 1, 5}, {3, 5, 2}, {16, 16, 16}, {17, 9, 33}, {65, 70, 63}, {130, 64, 129},
+}
+
+// specials are mixed into the operands, so the kernels have to follow the IEEE 754 rules, e.g. "0 * Inf = NaN".
+var specials = []float64{0, math.Copysign(0, -1), 1, -1, math.Inf(1), math.Inf(-1), math.NaN(), math.MaxFloat64, math.SmallestNonzeroFloat64}
+
+// randomSlice returns "n" random numbers, every fifth of them is a special value.
+func randomSlice(r *rand.Rand, n int, withSpecials bool) []float64 {
+	s := make([]float64, n)
+	for idx := range s {
+		if withSpecials && idx%5 == 4 {
+			s[idx] = specials[r.Intn(len(specials))]
+		} else {
+			s[idx] = r.Float64()*4 - 2
+		}
+	}
+
+	return s
+}
+
+// garbage returns "n" NaN elements, so the elements that a kernel does not overwrite are detected.
+func garbage(n int) []float64 {
+	s := make([]float64, n)
+	for idx := range s {
+		s[idx] = math.NaN()
+	}
+
+	return s
+}
+
+// same reports whether "a" and "b" are the same, NaN is the same as NaN.
+func same(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+// Run runs the conformance tests against "b" as subtests of "t".
+// The element-wise kernels must produce exactly the same results as the IEEE 754 operations,
+// the products may differ by rounding errors, since the order of the additions is up to the backend.
+func Run(t *testing.T, b matrix.Backend) {
+	t.Run("Name", func(t *testing.T) {
+		if b.Name() == "" {
+			t.Error("Name should not be empty")
+		}
+	})
+
+	t.Run("Binary", func(t *testing.T) { testBinary(t, b) })
+	t.Run("Scale", func(t *testing.T) { testScale(t, b) })
+	t.Run("Apply", func(t *testing.T) { testApply(t, b) })
+	t.Run("Product", func(t *testing.T) { testProduct(t, b) })
+	t.Run("Matrix", func(t *testing.T) { testMatrix(t, b) })
+}
+
+func testBinary(t *testing.T, b matrix.Backend) {
+	kernels := []struct {
+		name   string
+		kernel func(dst, a, b []float64)
+		op     func(a, b float64) float64
+	}{
+		{"Add", b.Add, func(a, b float64) float64 { return a + b }},
+		{"Subtract", b.Subtract, func(a, b float64) float64 { return a - b }},
+		{"Multiply", b.Multiply, func(a, b float64) float64 { return a * b }},
+		{"Divide", b.Divide, func(a, b float64) float64 { return a / b }},
+	}
+
+	r := rand.New(rand.NewSource(0))
+	for _, k := range kernels {
+		for _, n := range lengths {
+			aVals, bVals := randomSlice(r, n, true), randomSlice(r, n, true)
+			expected := make([]float64, n)
+			for idx := range expected {
+				expected[idx] = k.op(aVals[idx], bVals[idx])
+			}
+
+			// The destination is a separate slice, the first operand and the second operand.
+			for mode := 0; mode < 3; mode++ {
+				a, bb := append([]float64(nil), aVals...), append([]float64(nil), bVals...)
+				dst := garbage(n)
+				switch mode {
+				case 1:
+					dst = a
+				case 2:
+					dst = bb
+				}
+
+				k.kernel(dst, a, bb)
+				for idx := range expected {
+					if !same(dst[idx], expected[idx]) {
+						t.Errorf("%s, length %d, mode %d: expected element %d is %v, but got %v", k.name, n, mode, idx, expected[idx], dst[idx])
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func testScale(t *testing.T, b matrix.Backend) {
+	r := rand.New(rand.NewSource(1))
+	for _, s := range []float64{0, -1, 2.5, math.Inf(1)} {
+		for _, n := range lengths {
+			a := randomSlice(r, n, true)
+			expected := make([]float64, n)
+			for idx := range expected {
+				expected[idx] = s * a[idx]
+			}
+
+			dst := garbage(n)
+			b.Scale(dst, s, a)
+			b.Scale(a, s, a)
+			for idx := range expected {
+				if !same(dst[idx], expected[idx]) || !same(a[idx], expected[idx]) {
+					t.Errorf("Scale by %v, length %d: expected element %d is %v, but got %v and %v in-place", s, n, idx, expected[idx], dst[idx], a[idx])
+					break
+				}
+			}
+		}
+	}
+}
+
+func testApply(t *testing.T, b matrix.Backend) {
+	r := rand.New(rand.NewSource(2))
+	fn := func(v float64) float64 { return v*v - 1 }
+	for _, n := range lengths {
+		a := randomSlice(r, n, true)
+		expected := make([]float64, n)
+		for idx := range expected {
+			expected[idx] = fn(a[idx])
+		}
+
+		dst := garbage(n)
+		b.Apply(dst, a, fn)
+		b.Apply(a, a, fn)
+		for idx := range expected {
+			if !same(dst[idx], expected[idx]) || !same(a[idx], expected[idx]) {
+				t.Errorf("Apply, length %d: expected element %d is %v, but got %v and %v in-place", n, idx, expected[idx], dst[idx], a[idx])
+				break
+			}
+		}
+	}
+
+	// The function is called exactly once for each element.
+	calls := 0
+	b.Apply(make([]float64, 37), make([]float64, 37), func(v float64) float64 { calls++; return v })
+	if calls != 37 {
+		t.Errorf("Expected number of calls is %d, but got %d", 37, calls)
+	}
+}
+
+// view returns an "r x c" view in the middle of a larger matrix with random elements, so the rows of the view are not contiguous.
+func view(rnd *rand.Rand, r, c int) *matrix.Matrix {
+	parent, _ := matrix.New(r+2, c+3, randomSlice(rnd, (r+2)*(c+3), false))
+	v, _ := parent.Slice(1, r+1, 2, c+2)
+
+	return v
+}
+
+func testProduct(t *testing.T, b matrix.Backend) {
+	r := rand.New(rand.NewSource(3))
+	for _, dim := range productDimensions {
+		for _, views := range []bool{false, true} {
+			var aMat, bMat, dst *matrix.Matrix
+			if views {
+				aMat, bMat, dst = view(r, dim[0], dim[1]), view(r, dim[1], dim[2]), view(r, dim[0], dim[2])
+			} else {
+				aMat, _ = matrix.New(dim[0], dim[1], randomSlice(r, dim[0]*dim[1], false))
+				bMat, _ = matrix.New(dim[1], dim[2], randomSlice(r, dim[1]*dim[2], false))
+				dst, _ = matrix.New(dim[0], dim[2], garbage(dim[0]*dim[2]))
+			}
+
+			b.Product(dst, aMat, bMat)
+			for i := 0; i < dim[0]; i++ {
+				for j := 0; j < dim[2]; j++ {
+					sum, abs := 0.0, 0.0
+					for k := 0; k < dim[1]; k++ {
+						av, _ := aMat.At(i, k)
+						bv, _ := bMat.At(k, j)
+						sum += av * bv
+						abs += math.Abs(av * bv)
+					}
+
+					if v, _ := dst.At(i, j); !(math.Abs(v-sum) <= 1e-13*abs) {
+						t.Fatalf("Product %v, views %t: expected element %d, %d is %v, but got %v", dim, views, i, j, sum, v)
+					}
+				}
+			}
+		}
+	}
+}
+
+// testMatrix checks the results of the matrix operations that use the backend of their receiver.
+func testMatrix(t *testing.T, b matrix.Backend) {
+	r := rand.New(rand.NewSource(4))
+	aMat, _ := matrix.New(5, 4, randomSlice(r, 20, false))
+	bMat, _ := matrix.New(5, 4, randomSlice(r, 20, false))
+	col, _ := matrix.New(5, 1, randomSlice(r, 5, false))
+	cMat, _ := matrix.New(4, 3, randomSlice(r, 12, false))
+
+	ops := []struct {
+		name string
+		fn   func(m *matrix.Matrix) error
+	}{
+		{"Add broadcast", func(m *matrix.Matrix) error { return m.Add(aMat, col) }},
+		{"Subtract", func(m *matrix.Matrix) error { return m.Subtract(aMat, bMat) }},
+		{"Multiply", func(m *matrix.Matrix) error { return m.Multiply(col, bMat) }},
+		{"Divide", func(m *matrix.Matrix) error { return m.Divide(aMat, bMat) }},
+		{"Scale", func(m *matrix.Matrix) error { return m.Scale(-3, aMat) }},
+		{"Apply", func(m *matrix.Matrix) error { return m.Apply(math.Abs, aMat) }},
+		{"Product", func(m *matrix.Matrix) error { return m.Product(aMat, cMat) }},
+	}
+
+	for _, op := range ops {
+		expected := &matrix.Matrix{}
+		expected.SetBackend(matrix.GoBackend)
+		if err := op.fn(expected); err != nil {
+			t.Fatalf("%s: expected error is %v, but got %v", op.name, nil, err)
+		}
+
+		m := &matrix.Matrix{}
+		m.SetBackend(b)
+		if err := op.fn(m); err != nil {
+			t.Fatalf("%s: expected error is %v, but got %v", op.name, nil, err)
+		}
+
+		if !matrix.EqualApprox(m, expected, 1e-13) {
+			t.Errorf("%s: expected matrix is\n%v\nbut got\n%v", op.name, expected, m)
+		}
+	}
+}
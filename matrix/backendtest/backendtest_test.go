@@ -0,0 +1,39 @@
+package backendtest
+
+import (
+	"testing"
+
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// unrolledBackend is an alternative Backend, its Add kernel is unrolled four times, and it uses GoBackend for everything else.
+type unrolledBackend struct {
+	matrix.Backend
+}
+
+func (unrolledBackend) Name() string {
+	return "unrolled"
+}
+
+func (unrolledBackend) Add(dst, a, b []float64) {
+	n := len(dst) &^ 3
+	a, b = a[:len(dst)], b[:len(dst)]
+	for idx := 0; idx < n; idx += 4 {
+		dst[idx] = a[idx] + b[idx]
+		dst[idx+1] = a[idx+1] + b[idx+1]
+		dst[idx+2] = a[idx+2] + b[idx+2]
+		dst[idx+3] = a[idx+3] + b[idx+3]
+	}
+
+	for idx := n; idx < len(dst); idx++ {
+		dst[idx] = a[idx] + b[idx]
+	}
+}
+
+func TestGoBackend(t *testing.T) {
+	Run(t, matrix.GoBackend)
+}
+
+func TestUnrolledBackend(t *testing.T) {
+	Run(t, unrolledBackend{matrix.GoBackend})
+}
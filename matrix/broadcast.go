@@ -0,0 +1,96 @@
+package matrix
+
+// broadcastDimension returns the size of a dimension after broadcasting the sizes "a" and "b" together,
+// which is possible when they are equal, or one of them is one.
+func broadcastDimension(a, b int) (int, bool) {
+	switch {
+	case a == b || b == 1:
+		return a, true
+	case a == 1:
+		return b, true
+	default:
+		return 0, false
+	}
+}
+
+// BroadcastDimensions returns the dimensions of the result of an element-wise operation between "a" and "b".
+// The dimensions are compared one by one, and two dimensions are compatible when they are equal, or one of them is one,
+// in that case the single row (or column) is repeated along the other operand, e.g. a 3 x 4 and a 3 x 1 matrix broadcast to 3 x 4,
+// a 1 x 4 and a 3 x 1 matrix broadcast to 3 x 4, while a 3 x 4 and a 2 x 4 matrix cannot be broadcast together.
+// It will return an error if "a == nil" or "b == nil", or the dimensions are not compatible.
+func BroadcastDimensions(aMat, bMat *Matrix) (int, int, error) {
+	if aMat == nil || bMat == nil {
+		return 0, 0, ErrNilMatrix
+	}
+
+	r, rOk := broadcastDimension(aMat.Rows, bMat.Rows)
+	c, cOk := broadcastDimension(aMat.Columns, bMat.Columns)
+	if !rOk || !cOk {
+		return 0, 0, newShapeError("Broadcast", ErrBroadcastDimensions, aMat.Rows, aMat.Columns, bMat.Rows, bMat.Columns)
+	}
+
+	return r, c, nil
+}
+
+// broadcastRow returns the elements of "aMat" that belong to the "r"-th row of a broadcast result with "c" columns.
+// If "aMat" has a single column, its element is repeated into "buf", which has to have "c" elements.
+func broadcastRow(aMat *Matrix, r, c int, buf []float64) []float64 {
+	if aMat.Rows == 1 {
+		r = 0
+	}
+
+	row := aMat.row(r)
+	if aMat.Columns == c {
+		return row
+	}
+
+	for idx := range buf {
+		buf[idx] = row[0]
+	}
+
+	return buf
+}
+
+// elementWise broadcasts "aMat" and "bMat" together, and calls "kernel" with the rows of the receiver and the broadcast operands.
+// If the operands already have the same dimensions, the kernel is called the same way as in binary.
+// The receiver may be one of the operands, even when the dimensions of the result differ from the dimensions of the receiver.
+func (m *Matrix) elementWise(aMat, bMat *Matrix, kernel func(dst, a, b []float64)) error {
+	r, c, err := BroadcastDimensions(aMat, bMat)
+	if err != nil {
+		return err
+	}
+
+	// The receiver is resized before the operands are read, so a broadcast operand that shares its elements with the receiver is copied first.
+	if (aMat.Rows != r || aMat.Columns != c) && aliases(m.Values, aMat.Values) {
+		aMat, _ = Copy(aMat)
+	}
+
+	if (bMat.Rows != r || bMat.Columns != c) && aliases(m.Values, bMat.Values) {
+		bMat, _ = Copy(bMat)
+	}
+
+	if err := m.reuse(r, c); err != nil {
+		return err
+	}
+
+	aMat, bMat = m.operand(aMat), m.operand(bMat)
+	if aMat.Rows == r && aMat.Columns == c && bMat.Rows == r && bMat.Columns == c {
+		m.binary(aMat, bMat, kernel)
+		return nil
+	}
+
+	var aBuf, bBuf []float64
+	if aMat.Columns != c {
+		aBuf = make([]float64, c)
+	}
+
+	if bMat.Columns != c {
+		bBuf = make([]float64, c)
+	}
+
+	for row := 0; row < r; row++ {
+		kernel(m.row(row), broadcastRow(aMat, row, c, aBuf), broadcastRow(bMat, row, c, bBuf))
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBroadcastDimensions(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		a, b                       *Matrix
+		expectedRows, expectedCols int
+		expectedError              error
+	}{
+		{"Same", &Matrix{Rows: 2, Columns: 3}, &Matrix{Rows: 2, Columns: 3}, 2, 3, nil},
+		{"Column vector", &Matrix{Rows: 2, Columns: 3}, &Matrix{Rows: 2, Columns: 1}, 2, 3, nil},
+		{"Row vector", &Matrix{Rows: 1, Columns: 3}, &Matrix{Rows: 2, Columns: 3}, 2, 3, nil},
+		{"Scalar", &Matrix{Rows: 1, Columns: 1}, &Matrix{Rows: 2, Columns: 3}, 2, 3, nil},
+		{"Outer", &Matrix{Rows: 1, Columns: 3}, &Matrix{Rows: 2, Columns: 1}, 2, 3, nil},
+		{"ErrBroadcastDimensions rows", &Matrix{Rows: 2, Columns: 3}, &Matrix{Rows: 3, Columns: 3}, 0, 0, ErrBroadcastDimensions},
+		{"ErrBroadcastDimensions columns", &Matrix{Rows: 2, Columns: 3}, &Matrix{Rows: 2, Columns: 2}, 0, 0, ErrBroadcastDimensions},
+		{"ErrDifferentDimensions", &Matrix{Rows: 2, Columns: 3}, &Matrix{Rows: 2, Columns: 2}, 0, 0, ErrDifferentDimensions},
+		{"ErrNilMatrix a", nil, &Matrix{Rows: 2, Columns: 2}, 0, 0, ErrNilMatrix},
+		{"ErrNilMatrix b", &Matrix{Rows: 2, Columns: 2}, nil, 0, 0, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, c, err := BroadcastDimensions(tc.a, tc.b)
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+			} else if r != tc.expectedRows || c != tc.expectedCols {
+				t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.expectedRows, tc.expectedCols, r, c)
+			}
+		})
+	}
+}
+
+func TestAdd_broadcastBias(t *testing.T) {
+	activations, _ := New(2, 3, []float64{0, 1, 2, 3, 4, 5})
+	bias, _ := New(2, 1, []float64{10, 20})
+	if err := activations.Add(activations, bias); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	expected := []float64{10, 11, 12, 23, 24, 25}
+	for idx, v := range activations.Values {
+		if v != expected[idx] {
+			t.Errorf("Expected value is %f, but got %f", expected[idx], v)
+		}
+	}
+}
+
+func TestAdd_broadcastIntoOperand(t *testing.T) {
+	// The receiver is the smaller operand, so it has to grow while it is being read.
+	row, _ := New(1, 3, []float64{1, 2, 3})
+	col, _ := New(2, 1, []float64{10, 20})
+	if err := row.Add(row, col); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if row.Rows != 2 || row.Columns != 3 {
+		t.Fatalf("Expected dimensions are %dx%d, but got %dx%d", 2, 3, row.Rows, row.Columns)
+	}
+
+	expected := []float64{11, 12, 13, 21, 22, 23}
+	for idx, v := range row.Values {
+		if v != expected[idx] {
+			t.Errorf("Expected value is %f, but got %f", expected[idx], v)
+		}
+	}
+}
+
+func TestAdd_broadcastView(t *testing.T) {
+	// The first row of the matrix is added to every row, including itself.
+	m, _ := New(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	first, _ := m.Row(0)
+	if err := m.Add(m, first); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	expected := []float64{2, 4, 4, 6, 6, 8}
+	for idx, v := range m.Values {
+		if v != expected[idx] {
+			t.Errorf("Expected value is %f, but got %f", expected[idx], v)
+		}
+	}
+
+	// A view can be the receiver, as long as the result has the same dimensions.
+	col, _ := m.Col(1)
+	scale, _ := New(1, 1, []float64{0.5})
+	if err := col.Multiply(col, scale); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	expected = []float64{2, 2, 4, 3, 6, 4}
+	for idx, v := range m.Values {
+		if v != expected[idx] {
+			t.Errorf("Expected value is %f, but got %f", expected[idx], v)
+		}
+	}
+
+	if err := col.Add(col, &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}); !errors.Is(err, ErrViewDimensions) {
+		t.Errorf("Expected error is %v, but got %v", ErrViewDimensions, err)
+	}
+}
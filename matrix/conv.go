@@ -0,0 +1,310 @@
+package matrix
+
+// Padding selects how the input of a 2-D convolution is padded with zeros.
+type Padding int
+
+const (
+	// ValidPadding does not pad the input, the kernel is only placed where it fits entirely into the input.
+	ValidPadding Padding = iota
+
+	// SamePadding pads the input, so the output has "ceil(n / stride)" rows and columns, which is the size of the input when the stride is one.
+	// When the padding cannot be split evenly, the extra row (or column) goes to the bottom (or right).
+	SamePadding
+
+	// CustomPadding pads the input with the number of zero rows and columns given in ConvOptions.
+	CustomPadding
+)
+
+// ConvOptions configures the 2-D convolutions, the zero value is a convolution with valid padding, and with stride and dilation of one.
+type ConvOptions struct {
+	StrideRows, StrideColumns     int // The distance between two positions of the kernel, zero means one
+	DilationRows, DilationColumns int // The distance between two elements of the kernel on the input, zero means one
+	Padding                       Padding
+	Top, Bottom, Left, Right      int // The number of zero rows and columns around the input, only used with CustomPadding
+}
+
+// convGeometry holds the resolved dimensions of a 2-D convolution.
+type convGeometry struct {
+	inRows, inCols, kRows, kCols int
+	outRows, outCols             int
+	strideRows, strideCols       int
+	dilationRows, dilationCols   int
+	top, left                    int
+}
+
+// convDimension resolves a single dimension of a convolution, "in" is the size of the input and "k" is the size of the kernel.
+// It returns the size of the output, the padding before the first element of the input, and the resolved stride and dilation.
+func convDimension(in, k, stride, dilation int, padding Padding, before, after int) (out, pad, s, d int, err error) {
+	s, d = stride, dilation
+	if s == 0 {
+		s = 1
+	}
+
+	if d == 0 {
+		d = 1
+	}
+
+	if s < 0 || d < 0 {
+		return 0, 0, 0, 0, ErrBadConvOptions
+	}
+
+	span := d*(k-1) + 1
+	switch padding {
+	case ValidPadding:
+		before, after = 0, 0
+	case SamePadding:
+		out = (in + s - 1) / s
+		total := maxInt((out-1)*s+span-in, 0)
+		return out, total / 2, s, d, nil
+	case CustomPadding:
+		if before < 0 || after < 0 {
+			return 0, 0, 0, 0, ErrBadConvOptions
+		}
+	default:
+		return 0, 0, 0, 0, ErrBadConvOptions
+	}
+
+	if in+before+after < span {
+		return 0, 0, 0, 0, ErrKernelDimensions
+	}
+
+	return (in+before+after-span)/s + 1, before, s, d, nil
+}
+
+// geometry resolves the options for an "inRows x inCols" input and a "kRows x kCols" kernel.
+func (o ConvOptions) geometry(inRows, inCols, kRows, kCols int) (convGeometry, error) {
+	if inRows <= 0 || kRows <= 0 {
+		return convGeometry{}, ErrZeroRow
+	}
+
+	if inCols <= 0 || kCols <= 0 {
+		return convGeometry{}, ErrZeroCol
+	}
+
+	g := convGeometry{inRows: inRows, inCols: inCols, kRows: kRows, kCols: kCols}
+
+	var err error
+	g.outRows, g.top, g.strideRows, g.dilationRows, err = convDimension(inRows, kRows, o.StrideRows, o.DilationRows, o.Padding, o.Top, o.Bottom)
+	if err == nil {
+		g.outCols, g.left, g.strideCols, g.dilationCols, err = convDimension(inCols, kCols, o.StrideColumns, o.DilationColumns, o.Padding, o.Left, o.Right)
+	}
+
+	if err == ErrKernelDimensions {
+		return convGeometry{}, o.kernelError(inRows, inCols, kRows, kCols)
+	} else if err != nil {
+		return convGeometry{}, err
+	}
+
+	return g, nil
+}
+
+// kernelError returns the ShapeError of a dilated "kRows x kCols" kernel that does not fit into the padded "inRows x inCols" input,
+// the expected shape is the dilated kernel, and the actual shape is the padded input.
+func (o ConvOptions) kernelError(inRows, inCols, kRows, kCols int) *ShapeError {
+	span := func(k, d int) int {
+		if d == 0 {
+			d = 1
+		}
+
+		return d*(k-1) + 1
+	}
+
+	padded := func(in, before, after int) int {
+		if o.Padding == CustomPadding {
+			return in + before + after
+		}
+
+		return in
+	}
+
+	return newShapeError("ConvDimensions", ErrKernelDimensions, span(kRows, o.DilationRows), span(kCols, o.DilationColumns),
+		padded(inRows, o.Top, o.Bottom), padded(inCols, o.Left, o.Right))
+}
+
+// each calls "fn" with every position of the output and every element of the kernel that falls inside the input, outside of the padding.
+// The positions are "i, j" in the output, "u, v" in the kernel and "ar, ac" in the input.
+func (g convGeometry) each(fn func(i, j, u, v, ar, ac int)) {
+	for i := 0; i < g.outRows; i++ {
+		for u := 0; u < g.kRows; u++ {
+			ar := i*g.strideRows - g.top + u*g.dilationRows
+			if ar < 0 || ar >= g.inRows {
+				continue
+			}
+
+			for j := 0; j < g.outCols; j++ {
+				for v := 0; v < g.kCols; v++ {
+					ac := j*g.strideCols - g.left + v*g.dilationCols
+					if ac < 0 || ac >= g.inCols {
+						continue
+					}
+
+					fn(i, j, u, v, ar, ac)
+				}
+			}
+		}
+	}
+}
+
+// ConvDimensions returns the dimensions of the result of a 2-D convolution of an "r x c" input with a "kr x kc" kernel, configured by "opts".
+// It will return an error if any of the dimensions is not positive, the stride or the dilation is negative, the padding is not valid,
+// or the dilated kernel does not fit into the padded input.
+func ConvDimensions(r, c, kr, kc int, opts ConvOptions) (int, int, error) {
+	g, err := opts.geometry(r, c, kr, kc)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return g.outRows, g.outCols, nil
+}
+
+// Correlate2D calculates the 2-D cross-correlation of "a" with the kernel "k", configured by "opts", placing the result in the receiver.
+// The element of the result at "i, j" is the sum of "k[u][v] * a[i*strideRows-top+u*dilationRows][j*strideColumns-left+v*dilationColumns]",
+// where the elements outside of "a" are zeros. The dimensions of the result are given by ConvDimensions.
+// When it is estimated to be faster (see SetConvFFTCost), the result is calculated with the FFT, whose rounding errors are proportional to
+// "max|a| * max|k|" at every element, so a small element of the result next to a few huge elements of "a" is less precise than the direct sum.
+// If any of the elements of "a" or "k" is NaN or infinity, the direct sums are used, so the non-finite elements affect only the results they are part of.
+// The receiver may be one of the operands.
+// It will return an error if "a == nil" or "k == nil", or the same errors as ConvDimensions.
+func (m *Matrix) Correlate2D(aMat, kMat *Matrix, opts ConvOptions) error {
+	return m.correlate(aMat, kMat, opts, false)
+}
+
+// Convolve2D calculates the 2-D convolution of "a" with the kernel "k", configured by "opts", placing the result in the receiver.
+// It is the same as Correlate2D with the kernel rotated by 180 degrees.
+// The receiver may be one of the operands.
+// It will return an error if "a == nil" or "k == nil", or the same errors as ConvDimensions.
+func (m *Matrix) Convolve2D(aMat, kMat *Matrix, opts ConvOptions) error {
+	return m.correlate(aMat, kMat, opts, true)
+}
+
+// correlate calculates the cross-correlation of "a" and "k", with the kernel rotated by 180 degrees if "flip" is true.
+func (m *Matrix) correlate(aMat, kMat *Matrix, opts ConvOptions, flip bool) error {
+	if aMat == nil || kMat == nil {
+		return ErrNilMatrix
+	}
+
+	g, err := opts.geometry(aMat.Rows, aMat.Columns, kMat.Rows, kMat.Columns)
+	if err != nil {
+		return err
+	}
+
+	if aliases(m.Values, aMat.Values) {
+		aMat, _ = Copy(aMat)
+	}
+
+	if aliases(m.Values, kMat.Values) {
+		kMat, _ = Copy(kMat)
+	}
+
+	if err := m.reuse(g.outRows, g.outCols); err != nil {
+		return err
+	}
+
+	if g.useFFT() && aMat.IsFinite() && kMat.IsFinite() {
+		m.correlateFFT(aMat, kMat, g, flip)
+		return nil
+	}
+
+	m.correlateDirect(aMat, kMat, g, flip)
+	return nil
+}
+
+// correlateDirect is the direct path of correlate, the receiver must already have the dimensions of the result, and it must not alias any of the operands.
+func (m *Matrix) correlateDirect(aMat, kMat *Matrix, g convGeometry, flip bool) {
+	for r := 0; r < m.Rows; r++ {
+		dRow := m.row(r)
+		for idx := range dRow {
+			dRow[idx] = 0
+		}
+	}
+
+	aStride, kStride, dStride := aMat.Stride(), kMat.Stride(), m.Stride()
+	g.each(func(i, j, u, v, ar, ac int) {
+		if flip {
+			u, v = g.kRows-1-u, g.kCols-1-v
+		}
+
+		m.Values[i*dStride+j] += kMat.Values[u*kStride+v] * aMat.Values[ar*aStride+ac]
+	})
+}
+
+// Im2Col rearranges the patches of "a" that a "kr x kc" kernel covers during a 2-D convolution, configured by "opts", into the columns of the receiver.
+// The receiver will be a "kr*kc x outRows*outCols" matrix, where "outRows x outCols" are the dimensions given by ConvDimensions,
+// the patch of the "i, j" output is in the "i*outCols+j"-th column, and its elements are in row-major order, with zeros in the padding.
+// A kernel flattened into a "1 x kr*kc" row vector multiplied with the receiver by Product gives the same elements as Correlate2D.
+// The receiver may be "a".
+// It will return an error if "a == nil", or the same errors as ConvDimensions.
+func (m *Matrix) Im2Col(aMat *Matrix, kr, kc int, opts ConvOptions) error {
+	if aMat == nil {
+		return ErrNilMatrix
+	}
+
+	g, err := opts.geometry(aMat.Rows, aMat.Columns, kr, kc)
+	if err != nil {
+		return err
+	}
+
+	if aliases(m.Values, aMat.Values) {
+		aMat, _ = Copy(aMat)
+	}
+
+	if err := m.reuse(kr*kc, g.outRows*g.outCols); err != nil {
+		return err
+	}
+
+	for r := 0; r < m.Rows; r++ {
+		dRow := m.row(r)
+		for idx := range dRow {
+			dRow[idx] = 0
+		}
+	}
+
+	aStride, dStride := aMat.Stride(), m.Stride()
+	g.each(func(i, j, u, v, ar, ac int) {
+		m.Values[(u*kc+v)*dStride+i*g.outCols+j] = aMat.Values[ar*aStride+ac]
+	})
+
+	return nil
+}
+
+// Col2Im is the adjoint of Im2Col, it adds the elements of the columns in "a" back to their positions in an "r x c" input, placing the result in the receiver.
+// The elements that belong to more than one patch are summed, and the elements in the padding are dropped,
+// which makes it suitable to propagate the gradient of a convolution back to its input.
+// The receiver may be "a".
+// It will return an error if "a == nil", the dimensions of "a" are not the ones Im2Col would produce, or the same errors as ConvDimensions.
+func (m *Matrix) Col2Im(aMat *Matrix, r, c, kr, kc int, opts ConvOptions) error {
+	if aMat == nil {
+		return ErrNilMatrix
+	}
+
+	g, err := opts.geometry(r, c, kr, kc)
+	if err != nil {
+		return err
+	}
+
+	if aMat.Rows != kr*kc || aMat.Columns != g.outRows*g.outCols {
+		return newShapeError("Col2Im", ErrColumnsDimension, kr*kc, g.outRows*g.outCols, aMat.Rows, aMat.Columns)
+	}
+
+	if aliases(m.Values, aMat.Values) {
+		aMat, _ = Copy(aMat)
+	}
+
+	if err := m.reuse(r, c); err != nil {
+		return err
+	}
+
+	for row := 0; row < m.Rows; row++ {
+		dRow := m.row(row)
+		for idx := range dRow {
+			dRow[idx] = 0
+		}
+	}
+
+	aStride, dStride := aMat.Stride(), m.Stride()
+	g.each(func(i, j, u, v, ar, ac int) {
+		m.Values[ar*dStride+ac] += aMat.Values[(u*kc+v)*aStride+i*g.outCols+j]
+	})
+
+	return nil
+}
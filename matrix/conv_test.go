@@ -0,0 +1,231 @@
+package matrix
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// im2colCorrelate calculates the cross-correlation through Im2Col and Product.
+func im2colCorrelate(aMat, kMat *Matrix, opts ConvOptions) (*Matrix, error) {
+	cols := &Matrix{}
+	if err := cols.Im2Col(aMat, kMat.Rows, kMat.Columns, opts); err != nil {
+		return nil, err
+	}
+
+	k, _ := Copy(kMat)
+	k.Rows, k.Columns = 1, kMat.Rows*kMat.Columns
+
+	out := &Matrix{}
+	if err := out.Product(k, cols); err != nil {
+		return nil, err
+	}
+
+	out.Rows, out.Columns, _ = ConvDimensions(aMat.Rows, aMat.Columns, kMat.Rows, kMat.Columns, opts)
+	return out, nil
+}
+
+func TestConvDimensions(t *testing.T) {
+	testCases := []struct {
+		name          string
+		r, c, kr, kc  int
+		opts          ConvOptions
+		expectedRows  int
+		expectedCols  int
+		expectedError error
+	}{
+		{"Valid", 5, 6, 3, 2, ConvOptions{}, 3, 5, nil},
+		{"Valid stride", 7, 7, 3, 3, ConvOptions{StrideRows: 2, StrideColumns: 3}, 3, 2, nil},
+		{"Valid dilation", 7, 7, 3, 3, ConvOptions{DilationRows: 2, DilationColumns: 3}, 3, 1, nil},
+		{"Same", 5, 6, 3, 4, ConvOptions{Padding: SamePadding}, 5, 6, nil},
+		{"Same stride", 5, 6, 3, 3, ConvOptions{StrideRows: 2, StrideColumns: 4, Padding: SamePadding}, 3, 2, nil},
+		{"Same kernel larger than the input", 2, 2, 5, 5, ConvOptions{Padding: SamePadding}, 2, 2, nil},
+		{"Custom", 5, 5, 3, 3, ConvOptions{Padding: CustomPadding, Top: 1, Bottom: 2, Left: 3}, 6, 6, nil},
+		{"ErrZeroRow", 0, 5, 3, 3, ConvOptions{}, 0, 0, ErrZeroRow},
+		{"ErrZeroCol", 5, 5, 3, 0, ConvOptions{}, 0, 0, ErrZeroCol},
+		{"ErrBadConvOptions stride", 5, 5, 3, 3, ConvOptions{StrideRows: -1}, 0, 0, ErrBadConvOptions},
+		{"ErrBadConvOptions dilation", 5, 5, 3, 3, ConvOptions{DilationColumns: -1}, 0, 0, ErrBadConvOptions},
+		{"ErrBadConvOptions padding", 5, 5, 3, 3, ConvOptions{Padding: CustomPadding, Right: -1}, 0, 0, ErrBadConvOptions},
+		{"ErrBadConvOptions unknown padding", 5, 5, 3, 3, ConvOptions{Padding: Padding(-1)}, 0, 0, ErrBadConvOptions},
+		{"ErrKernelDimensions", 5, 5, 6, 3, ConvOptions{}, 0, 0, ErrKernelDimensions},
+		{"ErrKernelDimensions dilation", 5, 5, 3, 3, ConvOptions{DilationRows: 3}, 0, 0, ErrKernelDimensions},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, c, err := ConvDimensions(tc.r, tc.c, tc.kr, tc.kc, tc.opts)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if r != tc.expectedRows || c != tc.expectedCols {
+				t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.expectedRows, tc.expectedCols, r, c)
+			}
+		})
+	}
+}
+
+func TestCorrelate2D(t *testing.T) {
+	a := &Matrix{Values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, Rows: 3, Columns: 3}
+	ones := &Matrix{Values: []float64{1, 1, 1, 1, 1, 1, 1, 1, 1}, Rows: 3, Columns: 3}
+	testCases := []struct {
+		name           string
+		aMat, kMat     *Matrix
+		opts           ConvOptions
+		convolve       bool
+		expectedMatrix *Matrix
+		expectedError  error
+	}{
+		{"Correlate", a, &Matrix{Values: []float64{1, 2, 3, 4}, Rows: 2, Columns: 2}, ConvOptions{}, false,
+			&Matrix{Values: []float64{37, 47, 67, 77}, Rows: 2, Columns: 2}, nil},
+		{"Convolve", a, &Matrix{Values: []float64{1, 2, 3, 4}, Rows: 2, Columns: 2}, ConvOptions{}, true,
+			&Matrix{Values: []float64{23, 33, 53, 63}, Rows: 2, Columns: 2}, nil},
+		{"Same", a, ones, ConvOptions{Padding: SamePadding}, false,
+			&Matrix{Values: []float64{12, 21, 16, 27, 45, 33, 24, 39, 28}, Rows: 3, Columns: 3}, nil},
+		{"Same stride", a, ones, ConvOptions{StrideRows: 2, StrideColumns: 2, Padding: SamePadding}, false,
+			&Matrix{Values: []float64{12, 16, 24, 28}, Rows: 2, Columns: 2}, nil},
+		{"Custom", a, &Matrix{Values: []float64{1}, Rows: 1, Columns: 1}, ConvOptions{Padding: CustomPadding, Top: 1, Right: 1}, false,
+			&Matrix{Values: []float64{0, 0, 0, 0, 1, 2, 3, 0, 4, 5, 6, 0, 7, 8, 9, 0}, Rows: 4, Columns: 4}, nil},
+		{"Dilation", a, &Matrix{Values: []float64{1, 1, 1, 1}, Rows: 2, Columns: 2}, ConvOptions{DilationRows: 2, DilationColumns: 2}, false,
+			&Matrix{Values: []float64{20}, Rows: 1, Columns: 1}, nil},
+		{"ErrNilMatrix", a, nil, ConvOptions{}, false, nil, ErrNilMatrix},
+		{"ErrKernelDimensions", a, &Matrix{Values: make([]float64, 4), Rows: 4, Columns: 1}, ConvOptions{}, false, nil, ErrKernelDimensions},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			var err error
+			if tc.convolve {
+				err = m.Convolve2D(tc.aMat, tc.kMat, tc.opts)
+			} else {
+				err = m.Correlate2D(tc.aMat, tc.kMat, tc.opts)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedMatrix == nil {
+				return
+			}
+
+			if m.Rows != tc.expectedMatrix.Rows || m.Columns != tc.expectedMatrix.Columns {
+				t.Fatalf("Expected dimensions are %dx%d, but got %dx%d", tc.expectedMatrix.Rows, tc.expectedMatrix.Columns, m.Rows, m.Columns)
+			}
+
+			if !isApproxEqual(m.Values, tc.expectedMatrix.Values, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedMatrix.Values, m.Values)
+			}
+		})
+	}
+
+	t.Run("Receiver is the operand", func(t *testing.T) {
+		t.Parallel()
+
+		m, _ := Copy(a)
+		if err := m.Correlate2D(m, ones, ConvOptions{Padding: SamePadding}); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		expected := []float64{12, 21, 16, 27, 45, 33, 24, 39, 28}
+		if !isApproxEqual(m.Values, expected, 1e-12) {
+			t.Errorf("Expected values are %v, but got %v", expected, m.Values)
+		}
+	})
+}
+
+func TestIm2Col(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	options := []ConvOptions{
+		{},
+		{Padding: SamePadding},
+		{StrideRows: 2, StrideColumns: 3, Padding: SamePadding},
+		{DilationRows: 2, DilationColumns: 2, Padding: SamePadding},
+		{StrideRows: 2, DilationColumns: 2},
+		{StrideColumns: 2, Padding: CustomPadding, Top: 2, Bottom: 1, Left: 0, Right: 3},
+	}
+
+	for _, opts := range options {
+		aMat, kMat := randomMatrix(r, 9, 8), randomMatrix(r, 3, 2)
+
+		expected := &Matrix{}
+		if err := expected.Correlate2D(aMat, kMat, opts); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		out, err := im2colCorrelate(aMat, kMat, opts)
+		if err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if out.Rows != expected.Rows || out.Columns != expected.Columns || !isApproxEqual(out.Values, expected.Values, 1e-12) {
+			t.Errorf("Options %+v: expected values are %v, but got %v", opts, expected.Values, out.Values)
+		}
+
+		// Col2Im is the adjoint of Im2Col, so "<Im2Col(a), y> == <a, Col2Im(y)>" for any "y".
+		cols := &Matrix{}
+		cols.Im2Col(aMat, kMat.Rows, kMat.Columns, opts)
+		y := randomMatrix(r, cols.Rows, cols.Columns)
+
+		back := &Matrix{}
+		if err := back.Col2Im(y, aMat.Rows, aMat.Columns, kMat.Rows, kMat.Columns, opts); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		lhs, rhs := &Matrix{}, &Matrix{}
+		lhs.Multiply(cols, y)
+		rhs.Multiply(aMat, back)
+		if !isApproxEqual([]float64{lhs.Sum()}, []float64{rhs.Sum()}, 1e-10) {
+			t.Errorf("Options %+v: expected inner product is %f, but got %f", opts, lhs.Sum(), rhs.Sum())
+		}
+	}
+
+	t.Run("Convolve", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(1))
+		aMat, kMat := randomMatrix(r, 6, 7), randomMatrix(r, 3, 3)
+		opts := ConvOptions{StrideRows: 2, Padding: SamePadding}
+
+		flipped, _ := Copy(kMat)
+		for idx := range flipped.Values {
+			flipped.Values[idx] = kMat.Values[len(kMat.Values)-1-idx]
+		}
+
+		expected := &Matrix{}
+		expected.Convolve2D(aMat, kMat, opts)
+
+		out, _ := im2colCorrelate(aMat, flipped, opts)
+		if !isApproxEqual(out.Values, expected.Values, 1e-12) {
+			t.Errorf("Expected values are %v, but got %v", expected.Values, out.Values)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		m := &Matrix{}
+		if err := m.Im2Col(nil, 2, 2, ConvOptions{}); err != ErrNilMatrix {
+			t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+		}
+
+		if err := m.Col2Im(nil, 3, 3, 2, 2, ConvOptions{}); err != ErrNilMatrix {
+			t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+		}
+
+		if err := m.Col2Im(&Matrix{Values: make([]float64, 4), Rows: 4, Columns: 1}, 3, 3, 2, 2, ConvOptions{}); !errors.Is(err, ErrColumnsDimension) {
+			t.Errorf("Expected error is %v, but got %v", ErrColumnsDimension, err)
+		}
+
+		if err := m.Col2Im(&Matrix{Values: make([]float64, 4), Rows: 4, Columns: 1}, 3, 0, 2, 2, ConvOptions{}); err != ErrZeroCol {
+			t.Errorf("Expected error is %v, but got %v", ErrZeroCol, err)
+		}
+	})
+}
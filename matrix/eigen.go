@@ -0,0 +1,138 @@
+package matrix
+
+import (
+	"math"
+	"sort"
+)
+
+// eigenMaxSweeps is the maximum number of sweeps over the off-diagonal elements made by the Jacobi methods.
+const eigenMaxSweeps = 100
+
+// EigenSym represents the eigendecomposition of a symmetric matrix "A", such that "A = V * diag(values) * V^T",
+// where the columns of the orthogonal matrix "V" are the eigenvectors of "A".
+type EigenSym struct {
+	values  []float64
+	vectors *Matrix
+}
+
+// isSymmetric reports whether "a" is square and equal to its transpose within a tolerance relative to its largest element.
+func isSymmetric(aMat *Matrix) bool {
+	if aMat.Rows != aMat.Columns {
+		return false
+	}
+
+	tolerance := 1e-12 * math.Max(math.Abs(aMat.Max()), math.Abs(aMat.Min()))
+	stride := aMat.Stride()
+	for r := 0; r < aMat.Rows; r++ {
+		for c := r + 1; c < aMat.Columns; c++ {
+			if math.Abs(aMat.Values[r*stride+c]-aMat.Values[c*stride+r]) > tolerance {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// NewEigenSym computes the eigenvalues and the eigenvectors of the symmetric matrix "a" with the cyclic Jacobi method, "a" is left unchanged.
+// The eigenvalues are sorted in descending order, and the eigenvectors are in the same order.
+// It will return an error if "a == nil", or "a" is not symmetric.
+func NewEigenSym(aMat *Matrix) (*EigenSym, error) {
+	if aMat == nil {
+		return nil, ErrNilMatrix
+	}
+
+	if !isSymmetric(aMat) {
+		return nil, ErrNotSymmetric
+	}
+
+	n := aMat.Rows
+	a, _ := Copy(aMat)
+	v, _ := Identity(n)
+	av := a.Values
+
+	for sweep := 0; sweep < eigenMaxSweeps; sweep++ {
+		off, diag := 0.0, 0.0
+		for r := 0; r < n; r++ {
+			diag += av[r*n+r] * av[r*n+r]
+			for c := r + 1; c < n; c++ {
+				off += av[r*n+c] * av[r*n+c]
+			}
+		}
+
+		if off <= 1e-30*diag || off == 0 {
+			break
+		}
+
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				apq := av[p*n+q]
+				if apq == 0 {
+					continue
+				}
+
+				theta := (av[q*n+q] - av[p*n+p]) / (2 * apq)
+				t := math.Copysign(1, theta) / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+
+				for k := 0; k < n; k++ {
+					akp, akq := av[k*n+p], av[k*n+q]
+					av[k*n+p], av[k*n+q] = c*akp-s*akq, s*akp+c*akq
+				}
+
+				for k := 0; k < n; k++ {
+					apk, aqk := av[p*n+k], av[q*n+k]
+					av[p*n+k], av[q*n+k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+
+				for k := 0; k < n; k++ {
+					vkp, vkq := v.Values[k*n+p], v.Values[k*n+q]
+					v.Values[k*n+p], v.Values[k*n+q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+
+	values := make([]float64, n)
+	for idx := range values {
+		values[idx] = av[idx*n+idx]
+	}
+
+	values, vectors := sortColumns(values, v)
+	return &EigenSym{values, vectors}, nil
+}
+
+// sortColumns sorts "values" in descending order, and returns them together with a new Matrix that has the columns of "m" in the same order.
+func sortColumns(values []float64, m *Matrix) ([]float64, *Matrix) {
+	order := make([]int, len(values))
+	for idx := range order {
+		order[idx] = idx
+	}
+
+	sort.SliceStable(order, func(i, j int) bool {
+		return values[order[i]] > values[order[j]]
+	})
+
+	sorted := make([]float64, len(values))
+	sMat, _ := New(m.Rows, m.Columns, nil)
+	for c, o := range order {
+		sorted[c] = values[o]
+		for r := 0; r < m.Rows; r++ {
+			sMat.Values[r*sMat.Columns+c] = m.Values[r*m.Stride()+o]
+		}
+	}
+
+	return sorted, sMat
+}
+
+// Values returns the eigenvalues in descending order.
+func (d *EigenSym) Values() []float64 {
+	return append([]float64(nil), d.values...)
+}
+
+// Vectors returns a new Matrix that holds the eigenvectors as its columns, in the same order as Values.
+func (d *EigenSym) Vectors() *Matrix {
+	v, _ := Copy(d.vectors)
+	return v
+}
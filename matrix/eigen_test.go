@@ -0,0 +1,79 @@
+package matrix
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNewEigenSym(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{2, 1, 1, 2}, Rows: 2, Columns: 2}, []float64{3, 1}, nil},
+		{"Block", &Matrix{Values: []float64{2, 0, 0, 0, 3, 4, 0, 4, 9}, Rows: 3, Columns: 3}, []float64{11, 2, 1}, nil},
+		{"Diagonal", &Matrix{Values: []float64{-1, 0, 0, 5}, Rows: 2, Columns: 2}, []float64{5, -1}, nil},
+		{"Single", &Matrix{Values: []float64{7}, Rows: 1, Columns: 1}, []float64{7}, nil},
+		{"ErrNotSymmetric", &Matrix{Values: []float64{1, 2, 3, 4}, Rows: 2, Columns: 2}, nil, ErrNotSymmetric},
+		{"ErrNotSymmetric not square", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, ErrNotSymmetric},
+		{"ErrNilMatrix", nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := NewEigenSym(tc.matrix)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(d.Values(), tc.expectedValues, 1e-12) {
+				t.Errorf("Expected eigenvalues are %v, but got %v", tc.expectedValues, d.Values())
+			}
+		})
+	}
+
+	t.Run("Random", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(0))
+		for _, n := range []int{2, 5, 20} {
+			// "B + B^T" is always symmetric.
+			bMat := randomMatrix(r, n, n)
+			bT, aMat := &Matrix{}, &Matrix{}
+			bT.Transpose(bMat)
+			aMat.Add(bMat, bT)
+
+			d, err := NewEigenSym(aMat)
+			if err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			v := d.Vectors()
+			if !isOrthonormal(v, 1e-12) {
+				t.Errorf("Eigenvectors should be orthonormal for a %dx%d matrix", n, n)
+			}
+
+			values := d.Values()
+			for idx := 1; idx < n; idx++ {
+				if values[idx-1] < values[idx] {
+					t.Errorf("Eigenvalues should be in descending order, but got %v", values)
+				}
+			}
+
+			// "A * V = V * diag(values)"
+			av, lambda := &Matrix{}, &Matrix{Values: values, Rows: 1, Columns: n}
+			av.Product(aMat, v)
+			v.Multiply(v, lambda)
+			if !isApproxEqual(av.Values, v.Values, 1e-10) {
+				t.Errorf("Expected A * V to be V * diag(values) for a %dx%d matrix", n, n)
+			}
+		}
+	})
+}
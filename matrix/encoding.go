@@ -0,0 +1,122 @@
+package matrix
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"hash/crc32"
+	"math"
+)
+
+// The binary format of a Matrix, all the numbers are little-endian:
+//
+//	magic    [4]byte  "GNMX"
+//	version  uint16   encodingVersion
+//	reserved uint16   zero
+//	rows     uint64
+//	columns  uint64
+//	values   [rows * columns]float64 in row-major order
+//	checksum uint32   CRC-32 (IEEE) of all the preceding bytes
+const (
+	encodingMagic         = "GNMX"
+	encodingVersion       = 1
+	encodingHeaderSize    = 4 + 2 + 2 + 8 + 8
+	encodingChecksumSize  = 4
+	encodingOverheadBytes = encodingHeaderSize + encodingChecksumSize
+)
+
+// *Matrix have to implement the binary and the gob encoding interfaces
+var (
+	_ encoding.BinaryMarshaler   = &Matrix{}
+	_ encoding.BinaryUnmarshaler = &Matrix{}
+	_ gob.GobEncoder             = &Matrix{}
+	_ gob.GobDecoder             = &Matrix{}
+)
+
+// MarshalBinary encodes the matrix into a compact, little-endian binary format with a version header, the dimensions and a CRC-32 checksum.
+// If the matrix is a view, only the elements of the view are encoded, and an empty "&Matrix{}" is encoded as a "0x0" matrix.
+func (m *Matrix) MarshalBinary() ([]byte, error) {
+	n := m.Rows * m.Columns
+	data := make([]byte, encodingOverheadBytes+8*n)
+
+	copy(data, encodingMagic)
+	binary.LittleEndian.PutUint16(data[4:], encodingVersion)
+	binary.LittleEndian.PutUint64(data[8:], uint64(m.Rows))
+	binary.LittleEndian.PutUint64(data[16:], uint64(m.Columns))
+
+	offset := encodingHeaderSize
+	for r := 0; r < m.Rows; r++ {
+		for _, v := range m.row(r) {
+			binary.LittleEndian.PutUint64(data[offset:], math.Float64bits(v))
+			offset += 8
+		}
+	}
+
+	binary.LittleEndian.PutUint32(data[offset:], crc32.ChecksumIEEE(data[:offset]))
+
+	return data, nil
+}
+
+// UnmarshalBinary decodes a matrix encoded by MarshalBinary into the receiver, the underlying slice of the receiver is reused when it is large enough.
+// It will return an error if the data is truncated or corrupted, it was not encoded by MarshalBinary, or its version is not supported.
+// It will also return an error if the receiver is a view with different dimensions.
+func (m *Matrix) UnmarshalBinary(data []byte) error {
+	if len(data) < encodingOverheadBytes {
+		return ErrEncodingTruncated
+	}
+
+	if string(data[:4]) != encodingMagic {
+		return ErrEncodingMagic
+	}
+
+	// A non-zero reserved field belongs to a format that this version does not know.
+	if v := binary.LittleEndian.Uint16(data[4:]); v != encodingVersion || binary.LittleEndian.Uint16(data[6:]) != 0 {
+		return ErrEncodingVersion
+	}
+
+	// An empty matrix is encoded as "0x0", only one of the dimensions cannot be zero.
+	rows, cols := binary.LittleEndian.Uint64(data[8:]), binary.LittleEndian.Uint64(data[16:])
+	if (rows == 0) != (cols == 0) {
+		return ErrEncodingDimensions
+	}
+
+	// The dimensions are validated against the length of the data before anything is allocated.
+	available := uint64(len(data)-encodingOverheadBytes) / 8
+	if rows > available || (rows != 0 && cols > available/rows) {
+		return ErrEncodingTruncated
+	}
+
+	end := encodingHeaderSize + 8*int(rows*cols)
+	if len(data) != end+encodingChecksumSize {
+		return ErrEncodingDimensions
+	}
+
+	if binary.LittleEndian.Uint32(data[end:]) != crc32.ChecksumIEEE(data[:end]) {
+		return ErrEncodingChecksum
+	}
+
+	if err := m.reuse(int(rows), int(cols)); err != nil {
+		return err
+	}
+
+	offset := encodingHeaderSize
+	for r := 0; r < m.Rows; r++ {
+		row := m.row(r)
+		for c := range row {
+			row[c] = math.Float64frombits(binary.LittleEndian.Uint64(data[offset:]))
+			offset += 8
+		}
+	}
+
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface with the same format as MarshalBinary.
+func (m *Matrix) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode implements the gob.GobDecoder interface with the same format as UnmarshalBinary.
+func (m *Matrix) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
@@ -0,0 +1,202 @@
+package matrix
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"hash/crc32"
+	"math"
+	"testing"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	testCases := []struct {
+		name   string
+		matrix *Matrix
+	}{
+		{"Normal", &Matrix{Values: []float64{0, -1.5, math.Inf(1), math.MaxFloat64, math.SmallestNonzeroFloat64, 1e-300}, Rows: 2, Columns: 3}},
+		{"Single", &Matrix{Values: []float64{42}, Rows: 1, Columns: 1}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := tc.matrix.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if expected := encodingOverheadBytes + 8*len(tc.matrix.Values); len(data) != expected {
+				t.Errorf("Expected length is %d, but got %d", expected, len(data))
+			}
+
+			m := &Matrix{}
+			if err := m.UnmarshalBinary(data); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if m.Rows != tc.matrix.Rows || m.Columns != tc.matrix.Columns {
+				t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.matrix.Rows, tc.matrix.Columns, m.Rows, m.Columns)
+			}
+
+			for idx, v := range m.Values {
+				if math.Float64bits(v) != math.Float64bits(tc.matrix.Values[idx]) {
+					t.Errorf("Expected value is %g, but got %g", tc.matrix.Values[idx], v)
+				}
+			}
+		})
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := (&Matrix{}).MarshalBinary()
+		if err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		m := &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}
+		if err := m.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if m.Rows != 0 || m.Columns != 0 || len(m.Values) != 0 {
+			t.Errorf("Expected dimensions are %dx%d, but got %dx%d", 0, 0, m.Rows, m.Columns)
+		}
+	})
+
+	t.Run("NaN", func(t *testing.T) {
+		t.Parallel()
+
+		data, _ := (&Matrix{Values: []float64{math.NaN()}, Rows: 1, Columns: 1}).MarshalBinary()
+		m := &Matrix{}
+		if err := m.UnmarshalBinary(data); err != nil || !math.IsNaN(m.Values[0]) {
+			t.Errorf("Expected value is %f, but got %f", math.NaN(), m.Values[0])
+		}
+	})
+
+	t.Run("View", func(t *testing.T) {
+		t.Parallel()
+
+		parent := &Matrix{Values: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8}, Rows: 3, Columns: 3}
+		v, _ := parent.Slice(1, 3, 1, 3)
+		data, _ := v.MarshalBinary()
+
+		m := &Matrix{}
+		m.UnmarshalBinary(data)
+		if expected := []float64{4, 5, 7, 8}; !isApproxEqual(m.Values, expected, 0) {
+			t.Errorf("Expected values are %v, but got %v", expected, m.Values)
+		}
+
+		// Decoding into the view writes through to the parent.
+		data, _ = (&Matrix{Values: []float64{-1, -2, -3, -4}, Rows: 2, Columns: 2}).MarshalBinary()
+		if err := v.UnmarshalBinary(data); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if expected := []float64{0, 1, 2, 3, -1, -2, 6, -3, -4}; !isApproxEqual(parent.Values, expected, 0) {
+			t.Errorf("Expected values are %v, but got %v", expected, parent.Values)
+		}
+
+		data, _ = (&Matrix{Values: []float64{1}, Rows: 1, Columns: 1}).MarshalBinary()
+		if err := v.UnmarshalBinary(data); !errors.Is(err, ErrViewDimensions) {
+			t.Errorf("Expected error is %v, but got %v", ErrViewDimensions, err)
+		}
+	})
+}
+
+func TestUnmarshalBinary_errors(t *testing.T) {
+	valid, _ := (&Matrix{Values: []float64{1, 2, 3, 4}, Rows: 2, Columns: 2}).MarshalBinary()
+
+	// withChecksum returns a copy of "data" modified by "fn", with a recalculated checksum.
+	withChecksum := func(fn func(data []byte)) []byte {
+		data := append([]byte(nil), valid...)
+		fn(data)
+		end := len(data) - encodingChecksumSize
+		binary.LittleEndian.PutUint32(data[end:], crc32.ChecksumIEEE(data[:end]))
+		return data
+	}
+
+	testCases := []struct {
+		name          string
+		data          []byte
+		expectedError error
+	}{
+		{"ErrEncodingTruncated empty", nil, ErrEncodingTruncated},
+		{"ErrEncodingTruncated header", valid[:encodingHeaderSize], ErrEncodingTruncated},
+		{"ErrEncodingTruncated values", valid[:len(valid)-9], ErrEncodingTruncated},
+		{"ErrEncodingMagic", withChecksum(func(data []byte) { data[0] = 'X' }), ErrEncodingMagic},
+		{"ErrEncodingVersion", withChecksum(func(data []byte) { data[4] = 2 }), ErrEncodingVersion},
+		{"ErrEncodingVersion reserved", withChecksum(func(data []byte) { data[7] = 1 }), ErrEncodingVersion},
+		{"ErrEncodingDimensions zero rows", withChecksum(func(data []byte) { binary.LittleEndian.PutUint64(data[8:], 0) }), ErrEncodingDimensions},
+		{"ErrEncodingDimensions zero columns", withChecksum(func(data []byte) { binary.LittleEndian.PutUint64(data[16:], 0) }), ErrEncodingDimensions},
+		{"ErrEncodingDimensions empty", withChecksum(func(data []byte) {
+			binary.LittleEndian.PutUint64(data[8:], 0)
+			binary.LittleEndian.PutUint64(data[16:], 0)
+		}), ErrEncodingDimensions},
+		{"ErrEncodingDimensions short", withChecksum(func(data []byte) { binary.LittleEndian.PutUint64(data[8:], 1) }), ErrEncodingDimensions},
+		{"ErrEncodingTruncated huge", withChecksum(func(data []byte) { binary.LittleEndian.PutUint64(data[8:], 1<<40) }), ErrEncodingTruncated},
+		{"ErrEncodingTruncated overflow", withChecksum(func(data []byte) {
+			binary.LittleEndian.PutUint64(data[8:], 1<<32)
+			binary.LittleEndian.PutUint64(data[16:], 1<<32)
+		}), ErrEncodingTruncated},
+		{"ErrEncodingChecksum value", func() []byte {
+			data := append([]byte(nil), valid...)
+			data[encodingHeaderSize] ^= 1
+			return data
+		}(), ErrEncodingChecksum},
+		{"ErrEncodingChecksum checksum", func() []byte {
+			data := append([]byte(nil), valid...)
+			data[len(data)-1] ^= 1
+			return data
+		}(), ErrEncodingChecksum},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{Values: []float64{9}, Rows: 1, Columns: 1}
+			if err := m.UnmarshalBinary(tc.data); err != tc.expectedError {
+				t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if m.Rows != 1 || m.Columns != 1 || m.Values[0] != 9 {
+				t.Error("Matrix should not change when the decoding fails")
+			}
+		})
+	}
+}
+
+func TestGob(t *testing.T) {
+	type model struct {
+		Name    string
+		Weights *Matrix
+		Biases  *Matrix
+	}
+
+	in := model{"dense", &Matrix{Values: []float64{1, 2, 3, 4, 5, 6}, Rows: 2, Columns: 3}, &Matrix{Values: []float64{-1, 1}, Rows: 2, Columns: 1}}
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(in); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	out := model{}
+	if err := gob.NewDecoder(buf).Decode(&out); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if out.Name != in.Name {
+		t.Errorf("Expected name is %s, but got %s", in.Name, out.Name)
+	}
+
+	for _, pair := range [][2]*Matrix{{in.Weights, out.Weights}, {in.Biases, out.Biases}} {
+		if pair[1].Rows != pair[0].Rows || pair[1].Columns != pair[0].Columns || !isApproxEqual(pair[1].Values, pair[0].Values, 0) {
+			t.Errorf("Expected matrix is %v, but got %v", pair[0], pair[1])
+		}
+	}
+}
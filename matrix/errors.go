@@ -1,6 +1,10 @@
 package matrix
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ErrZeroRow is returned by New when number of rows is equal to, or less than zero.
 var ErrZeroRow = errors.New("matrix: number of rows must be greater than zero")
@@ -11,7 +15,7 @@ var ErrZeroCol = errors.New("matrix: number of rows must be greater than zero")
 // ErrDataLength is returned by New when lenght of data is not equal to `r * c`
 var ErrDataLength = errors.New("matrix: length of the data must be equal to `r * c`")
 
-// ErrNilFunction is returned by Apply when `fn` is nil.
+// ErrNilFunction is returned by Apply and ApplyVector when `fn` is nil.
 var ErrNilFunction = errors.New("matrix: function must not be nil")
 
 // ErrRowOutOfBounds is returned by At when the supplied row is out of bounds.
@@ -27,4 +31,159 @@ var ErrNilMatrix = errors.New("matrix: matrix must not be nil")
 var ErrBadProductDimension = errors.New("matrix: the number of columns in `a` matrix must be equal to the number of rows in `b` matrix")
 
 // ErrDifferentDimensions is returned by any operation that is require two matrix as argument, and the dimensions of the matrices not the same.
+// The element-wise operations broadcast their operands, so they return the more specific ErrBroadcastDimensions, which still matches ErrDifferentDimensions with errors.Is.
 var ErrDifferentDimensions = errors.New("matrix: the dimensions of the matrices must be the same")
+
+// ErrViewDimensions is returned by any operation that would change the dimensions of a view.
+var ErrViewDimensions = errors.New("matrix: the dimensions of a view cannot be changed")
+
+// ErrSliceBounds is returned by Slice when the bounds are out of range, or the slice would be empty.
+var ErrSliceBounds = errors.New("matrix: slice bounds out of range")
+
+// ErrBroadcastDimensions is returned by the element-wise operations when the dimensions of the matrices cannot be broadcast together.
+// It refines ErrDifferentDimensions, which the element-wise operations returned before they could broadcast, so errors.Is matches both of them.
+var ErrBroadcastDimensions error = &refinedError{"matrix: the dimensions of the matrices cannot be broadcast together, each dimension must be equal or one", ErrDifferentDimensions}
+
+// ErrBadAxis is returned by the reductions when the axis is neither ByRow nor ByColumn.
+var ErrBadAxis = errors.New("matrix: axis must be either ByRow or ByColumn")
+
+// ErrNotSquare is returned by the decompositions and the solvers when the matrix is not square.
+var ErrNotSquare = errors.New("matrix: the matrix must be square")
+
+// ErrSingularMatrix is returned by Inverse and Solve when the matrix is singular, or too close to singular to be inverted reliably.
+var ErrSingularMatrix = errors.New("matrix: the matrix is singular")
+
+// ErrBadSolveDimension is returned by Solve when the number of rows in `b` not equal to the number of rows in `a` matrix.
+var ErrBadSolveDimension = errors.New("matrix: the number of rows in `b` matrix must be equal to the number of rows in `a` matrix")
+
+// ErrNotSymmetric is returned by NewEigenSym when the matrix is not symmetric.
+var ErrNotSymmetric = errors.New("matrix: the matrix must be symmetric")
+
+// ErrBadComponents is returned by NewPCA and Fit when the number of components is not positive, or larger than the number of features or samples.
+var ErrBadComponents = errors.New("matrix: the number of components must be greater than zero, and not greater than the number of features and samples")
+
+// ErrNotFitted is returned by the PCA when it is used before Fit is called.
+var ErrNotFitted = errors.New("matrix: the PCA must be fitted first")
+
+// ErrFeatureDimension is returned by the PCA when the number of rows does not match the number of features or components.
+var ErrFeatureDimension = errors.New("matrix: the number of rows must be equal to the number of features or components")
+
+// ErrEncodingTruncated is returned by UnmarshalBinary when the data is shorter than the encoded matrix.
+var ErrEncodingTruncated = errors.New("matrix: the encoded data is truncated")
+
+// ErrEncodingMagic is returned by UnmarshalBinary when the data does not start with the magic bytes of an encoded matrix.
+var ErrEncodingMagic = errors.New("matrix: the data is not an encoded matrix")
+
+// ErrEncodingVersion is returned by UnmarshalBinary when the version of the encoding is not supported, or its reserved field is not zero.
+var ErrEncodingVersion = errors.New("matrix: the version of the encoded data is not supported")
+
+// ErrEncodingDimensions is returned by UnmarshalBinary when only one of the encoded dimensions is zero, or they do not match the length of the data.
+var ErrEncodingDimensions = errors.New("matrix: the encoded dimensions do not match the data")
+
+// ErrEncodingChecksum is returned by UnmarshalBinary when the checksum of the data does not match, the data is corrupted.
+var ErrEncodingChecksum = errors.New("matrix: checksum mismatch, the encoded data is corrupted")
+
+// ErrNilDistribution is returned by NewRandom when `d` is nil.
+var ErrNilDistribution = errors.New("matrix: distribution must not be nil")
+
+// ErrNilRand is returned by the random constructors when `rnd` is nil.
+var ErrNilRand = errors.New("matrix: random source must not be nil")
+
+// ErrBadConvOptions is returned by the 2-D convolutions when the stride, the dilation or the padding is not valid.
+var ErrBadConvOptions = errors.New("matrix: the stride and the dilation must not be negative, and the padding must be valid")
+
+// ErrKernelDimensions is returned by the 2-D convolutions when the dilated kernel does not fit into the padded input.
+var ErrKernelDimensions = errors.New("matrix: the dilated kernel must fit into the padded input")
+
+// ErrColumnsDimension is returned by Col2Im when the dimensions of the columns are not the ones Im2Col would produce.
+var ErrColumnsDimension = errors.New("matrix: the dimensions of the columns must match the convolution")
+
+// ErrClipRange is returned by Clip when the lower bound is greater than the upper bound.
+var ErrClipRange = errors.New("matrix: the lower bound must not be greater than the upper bound")
+
+// ErrNoMatrices is returned by HStack and VStack when no matrix is given.
+var ErrNoMatrices = errors.New("matrix: at least one matrix must be given")
+
+// ErrStackDimensions is returned by HStack and VStack when the number of rows (or columns) of the matrices are different.
+var ErrStackDimensions = errors.New("matrix: the matrices must have the same number of rows to be stacked horizontally, or columns to be stacked vertically")
+
+// ErrSplitSizes is returned by Split when any of the sizes is not positive, or the sizes do not add up to the split dimension.
+var ErrSplitSizes = errors.New("matrix: the sizes of the parts must be positive, and they must add up to the split dimension")
+
+// ErrReshapeDimensions is returned by Reshape when the new dimensions do not keep the number of the elements.
+var ErrReshapeDimensions = errors.New("matrix: the number of the elements must not change")
+
+// ErrBadGranularity is returned by the quantization functions when the granularity is not valid, and by QuantizedProduct when the second operand is not quantized PerTensor.
+var ErrBadGranularity = errors.New("matrix: the granularity must be PerTensor or PerRow, and the second operand of a quantized product must be quantized PerTensor")
+
+// ErrQuantizationParameters is returned by NewQuantized when the number of scales or zero points does not match the granularity, or a scale is not positive and finite.
+var ErrQuantizationParameters = errors.New("matrix: there must be one positive, finite scale and one zero point for the matrix or for each row")
+
+// ErrQuantizeNotFinite is returned by Quantize when any of the elements is NaN or infinite.
+var ErrQuantizeNotFinite = errors.New("matrix: only finite elements can be quantized")
+
+// ErrQuantizedOverflow is returned by QuantizedProduct when the int32 accumulator could overflow.
+var ErrQuantizedOverflow = errors.New("matrix: the number of columns of the first operand is too large for the int32 accumulator")
+
+// refinedError is a sentinel error that refines a more general sentinel error, errors.Is matches both of them.
+type refinedError struct {
+	msg    string
+	parent error
+}
+
+// Error returns the message of the refined error.
+func (e *refinedError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the more general sentinel error.
+func (e *refinedError) Unwrap() error {
+	return e.parent
+}
+
+// ShapeError is returned by the operations that fail, because the shapes of their operands are not compatible.
+// It tells which operation failed, in which layer of a network, and what the shapes were, while it still satisfies errors.Is
+// with the sentinel error of the failure, e.g. "errors.Is(err, ErrBadProductDimension)".
+// It is shared by the matrix, layer and ann packages, the shapes are the dimensions of a matrix, a tensor or a slice.
+type ShapeError struct {
+	Op       string // The operation that failed, e.g. "Product", empty for the checks shared by many operations, e.g. the dimensions of a view receiver
+	Layer    string // The layer that failed: its UUID in the layer package, its decimal index (see ANN.Stats) in the ann package, empty outside of a network
+	Expected []int  // The shape the operation expected
+	Actual   []int  // The shape the operation got
+	Err      error  // The sentinel error of the failure
+}
+
+// newShapeError creates a ShapeError of "op" with the matrix dimensions "expected" and "actual".
+func newShapeError(op string, err error, expectedRows, expectedCols, actualRows, actualCols int) *ShapeError {
+	return &ShapeError{op, "", []int{expectedRows, expectedCols}, []int{actualRows, actualCols}, err}
+}
+
+// Error returns the message of the sentinel error, prefixed with the layer and the operation, and followed by the shapes.
+func (e *ShapeError) Error() string {
+	var b strings.Builder
+	if e.Layer != "" {
+		fmt.Fprintf(&b, "layer %s: ", e.Layer)
+	}
+
+	if e.Op != "" {
+		fmt.Fprintf(&b, "%s: ", e.Op)
+	}
+
+	fmt.Fprintf(&b, "%v (expected %s, got %s)", e.Err, formatShape(e.Expected), formatShape(e.Actual))
+	return b.String()
+}
+
+// Unwrap returns the sentinel error, so errors.Is and errors.As can look through the ShapeError.
+func (e *ShapeError) Unwrap() error {
+	return e.Err
+}
+
+// formatShape formats a shape as its dimensions joined by "x", e.g. "3x4".
+func formatShape(shape []int) string {
+	dims := make([]string, len(shape))
+	for idx, d := range shape {
+		dims[idx] = fmt.Sprint(d)
+	}
+
+	return strings.Join(dims, "x")
+}
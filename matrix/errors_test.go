@@ -0,0 +1,90 @@
+package matrix
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestShapeError(t *testing.T) {
+	aMat, _ := New(2, 3, nil)
+	bMat, _ := New(2, 4, nil)
+	cMat, _ := New(3, 1, nil)
+	square, _ := New(3, 3, nil)
+	parent, _ := New(4, 4, nil)
+	view, _ := parent.Slice(0, 2, 0, 2)
+	lu, _ := NewLU(square)
+
+	testCases := []struct {
+		name          string
+		fn            func() error
+		op            string
+		expected      []int
+		actual        []int
+		expectedError error
+	}{
+		{"Product", func() error { return (&Matrix{}).Product(aMat, bMat) }, "Product", []int{3, 4}, []int{2, 4}, ErrBadProductDimension},
+		{"Add", func() error { return (&Matrix{}).Add(aMat, cMat) }, "Broadcast", []int{2, 3}, []int{3, 1}, ErrBroadcastDimensions},
+		{"HStack", func() error { return (&Matrix{}).HStack(aMat, cMat) }, "HStack", []int{2, 1}, []int{3, 1}, ErrStackDimensions},
+		{"Reshape", func() error { return (&Matrix{}).Reshape(4, 2, aMat) }, "Reshape", []int{4, 2}, []int{2, 3}, ErrReshapeDimensions},
+		{"Correlate2D", func() error { return (&Matrix{}).Correlate2D(aMat, cMat, ConvOptions{}) }, "ConvDimensions", []int{3, 1}, []int{2, 3}, ErrKernelDimensions},
+		{"Col2Im", func() error { return (&Matrix{}).Col2Im(cMat, 3, 3, 2, 2, ConvOptions{}) }, "Col2Im", []int{4, 4}, []int{3, 1}, ErrColumnsDimension},
+		{"NewLU", func() error { _, err := NewLU(aMat); return err }, "LU", []int{2, 2}, []int{2, 3}, ErrNotSquare},
+		{"Solve", func() error { return lu.Solve(&Matrix{}, bMat) }, "Solve", []int{3, 4}, []int{2, 4}, ErrBadSolveDimension},
+		{"Trace", func() error { _, err := aMat.Trace(); return err }, "Trace", []int{2, 2}, []int{2, 3}, ErrNotSquare},
+		{"View receiver", func() error { return view.Add(aMat, aMat) }, "", []int{2, 2}, []int{2, 3}, ErrViewDimensions},
+	}
+
+	for _, tc := range testCases {
+		err := tc.fn()
+		if !errors.Is(err, tc.expectedError) {
+			t.Fatalf("%s: expected error is %v, but got %v", tc.name, tc.expectedError, err)
+		}
+
+		var shapeErr *ShapeError
+		if !errors.As(err, &shapeErr) {
+			t.Fatalf("%s: expected a ShapeError, but got %T", tc.name, err)
+		}
+
+		if shapeErr.Op != tc.op || fmt.Sprint(shapeErr.Expected) != fmt.Sprint(tc.expected) || fmt.Sprint(shapeErr.Actual) != fmt.Sprint(tc.actual) {
+			t.Errorf("%s: expected ShapeError is %s, %v and %v, but got %s, %v and %v", tc.name,
+				tc.op, tc.expected, tc.actual, shapeErr.Op, shapeErr.Expected, shapeErr.Actual)
+		}
+	}
+}
+
+func TestShapeError_Error(t *testing.T) {
+	err := &ShapeError{"Product", "", []int{3, 4}, []int{2, 4}, ErrBadProductDimension}
+	expected := "Product: " + ErrBadProductDimension.Error() + " (expected 3x4, got 2x4)"
+	if err.Error() != expected {
+		t.Errorf("Expected message is %q, but got %q", expected, err.Error())
+	}
+
+	err.Layer = "ARTIFICIAL_1"
+	expected = "layer ARTIFICIAL_1: " + expected
+	if err.Error() != expected {
+		t.Errorf("Expected message is %q, but got %q", expected, err.Error())
+	}
+
+	if wrapped := fmt.Errorf("epoch 3: %w", err); !errors.Is(wrapped, ErrBadProductDimension) || errors.Is(wrapped, ErrBroadcastDimensions) {
+		t.Errorf("Expected the wrapped error to match only %v, but got %v", ErrBadProductDimension, wrapped)
+	}
+}
+
+// TestShapeError_broadcast checks that a failed broadcast matches both the specific and the general sentinel error.
+func TestShapeError_broadcast(t *testing.T) {
+	aMat, _ := New(2, 3, nil)
+	bMat, _ := New(3, 1, nil)
+
+	err := (&Matrix{}).Add(aMat, bMat)
+	for _, expected := range []error{ErrBroadcastDimensions, ErrDifferentDimensions} {
+		if !errors.Is(err, expected) {
+			t.Errorf("Expected error is %v, but got %v", expected, err)
+		}
+	}
+
+	var shapeErr *ShapeError
+	if !errors.As(err, &shapeErr) || shapeErr.Err != ErrBroadcastDimensions {
+		t.Errorf("Expected a ShapeError of %v, but got %v", ErrBroadcastDimensions, err)
+	}
+}
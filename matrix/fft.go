@@ -0,0 +1,342 @@
+package matrix
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// convFFTCost holds the bits of the float64 set by SetConvFFTCost.
+	convFFTCost = math.Float64bits(2.5)
+
+	// fftPlans caches the plans of the transforms by their length.
+	fftPlans sync.Map
+)
+
+// SetConvFFTCost sets the estimated cost of the FFT path of Correlate2D and Convolve2D relative to their direct path, and returns the previous value.
+// The direct path takes "rows of the result * columns of the result * rows of k * columns of k" multiply-adds,
+// and the FFT path takes "n * log2(n)" steps, where "n" is the number of elements of the padded input, which is at least
+// "(rows of a + dilated rows of k - 1) * (columns of a + dilated columns of k - 1)", whatever the stride is.
+// The FFT is used when the number of its steps multiplied by "c" is less than the number of the multiply-adds, the default is 2.5.
+// If "c <= 0", the FFT is always used, and if "c" is +Inf, it is never used.
+func SetConvFFTCost(c float64) float64 {
+	return math.Float64frombits(atomic.SwapUint64(&convFFTCost, math.Float64bits(c)))
+}
+
+// fftSize returns the dimensions of the padded input of the FFT path of the correlation.
+func (g convGeometry) fftSize() (int, int) {
+	spanRows, spanCols := g.dilationRows*(g.kRows-1)+1, g.dilationCols*(g.kCols-1)+1
+	return fftLength(maxInt(g.inRows+spanRows-1, 2)), fftLength(maxInt(g.inCols+spanCols-1, 2))
+}
+
+// useFFT reports whether the FFT path of the correlation is estimated to be faster than the direct path (see SetConvFFTCost).
+func (g convGeometry) useFFT() bool {
+	direct := float64(g.outRows) * float64(g.outCols) * float64(g.kRows) * float64(g.kCols)
+	rows, cols := g.fftSize()
+	n := float64(rows) * float64(cols)
+
+	return n*math.Log2(n)*math.Float64frombits(atomic.LoadUint64(&convFFTCost)) < direct
+}
+
+// fftPlan holds the factorization and the twiddle factors of a mixed-radix transform of length "n".
+type fftPlan struct {
+	n        int
+	factors  []int        // Pairs of the radix and the remaining length of each stage
+	twiddles []complex128 // The "exp(-2*pi*i*k/n)" roots of unity
+	maxRadix int          // The largest radix, the size of the scratch of the generic butterfly
+}
+
+// planFFT returns the plan of the transforms with length "n", "n > 1" must hold.
+func planFFT(n int) *fftPlan {
+	if p, ok := fftPlans.Load(n); ok {
+		return p.(*fftPlan)
+	}
+
+	p := &fftPlan{n: n, twiddles: make([]complex128, n)}
+	for k := range p.twiddles {
+		sin, cos := math.Sincos(-2 * math.Pi * float64(k) / float64(n))
+		p.twiddles[k] = complex(cos, sin)
+	}
+
+	// Radix 4 first, then 2, then the odd factors, the same way as KISS FFT.
+	sqrt := int(math.Sqrt(float64(n)))
+	for m, radix := n, 4; m > 1; {
+		for m%radix != 0 {
+			switch radix {
+			case 4:
+				radix = 2
+			case 2:
+				radix = 3
+			default:
+				radix += 2
+			}
+
+			if radix > sqrt {
+				radix = m
+			}
+		}
+
+		m /= radix
+		p.factors = append(p.factors, radix, m)
+		p.maxRadix = maxInt(p.maxRadix, radix)
+	}
+
+	actual, _ := fftPlans.LoadOrStore(n, p)
+	return actual.(*fftPlan)
+}
+
+// transform calculates the forward transform of "src" into "dst", they must not overlap,
+// and "scratch" must have at least "maxRadix" elements.
+func (p *fftPlan) transform(dst, src, scratch []complex128) {
+	p.work(dst[:p.n], src, 1, 0, scratch)
+}
+
+// work calculates the transform of the "fstride" strided elements of "src" at the "stage"-th stage of the decimation in time.
+func (p *fftPlan) work(dst, src []complex128, fstride, stage int, scratch []complex128) {
+	radix, m := p.factors[2*stage], p.factors[2*stage+1]
+	if m == 1 {
+		for idx := range dst {
+			dst[idx] = src[idx*fstride]
+		}
+	} else {
+		for q := 0; q < radix; q++ {
+			p.work(dst[q*m:(q+1)*m], src[q*fstride:], fstride*radix, stage+1, scratch)
+		}
+	}
+
+	switch radix {
+	case 2:
+		p.butterfly2(dst, fstride, m)
+	case 4:
+		p.butterfly4(dst, fstride, m)
+	default:
+		p.butterfly(dst, fstride, m, radix, scratch)
+	}
+}
+
+// butterfly2 combines the two "m" length transforms in "dst".
+func (p *fftPlan) butterfly2(dst []complex128, fstride, m int) {
+	for k := 0; k < m; k++ {
+		t := dst[k+m] * p.twiddles[k*fstride]
+		dst[k+m] = dst[k] - t
+		dst[k] += t
+	}
+}
+
+// butterfly4 combines the four "m" length transforms in "dst".
+func (p *fftPlan) butterfly4(dst []complex128, fstride, m int) {
+	for k := 0; k < m; k++ {
+		s0 := dst[k+m] * p.twiddles[k*fstride]
+		s1 := dst[k+2*m] * p.twiddles[2*k*fstride]
+		s2 := dst[k+3*m] * p.twiddles[3*k*fstride]
+
+		s3, s4 := s0+s2, s0-s2
+		s5, s6 := dst[k]-s1, dst[k]+s1
+
+		dst[k], dst[k+2*m] = s6+s3, s6-s3
+		dst[k+m] = complex(real(s5)+imag(s4), imag(s5)-real(s4))
+		dst[k+3*m] = complex(real(s5)-imag(s4), imag(s5)+real(s4))
+	}
+}
+
+// butterfly combines the "radix" number of "m" length transforms in "dst" with a direct DFT of length "radix".
+func (p *fftPlan) butterfly(dst []complex128, fstride, m, radix int, scratch []complex128) {
+	for u := 0; u < m; u++ {
+		for q := 0; q < radix; q++ {
+			scratch[q] = dst[u+q*m]
+		}
+
+		for q1 := 0; q1 < radix; q1++ {
+			k := u + q1*m
+			sum, tw := scratch[0], 0
+			for q := 1; q < radix; q++ {
+				tw += fstride * k
+				if tw >= p.n {
+					tw -= p.n
+				}
+
+				sum += scratch[q] * p.twiddles[tw]
+			}
+
+			dst[k] = sum
+		}
+	}
+}
+
+// FFT calculates the discrete Fourier transform "X[k] = sum(x[j] * exp(-2*pi*i*j*k/n))" of "x" in place.
+// Any length is supported, the mixed-radix algorithm takes "O(n * (p1 + p2 + ...))" steps, where "p1, p2, ..." are the prime factors of the length,
+// so the lengths with only small prime factors, e.g. the powers of two, are the fastest.
+func FFT(x []complex128) {
+	if len(x) < 2 {
+		return
+	}
+
+	p := planFFT(len(x))
+	p.transform(x, append([]complex128(nil), x...), make([]complex128, p.maxRadix))
+}
+
+// InverseFFT calculates the inverse of FFT in place, "x[j] = sum(X[k] * exp(2*pi*i*j*k/n)) / n".
+func InverseFFT(x []complex128) {
+	conjugate(x)
+	FFT(x)
+	conjugate(x)
+
+	scale := complex(1/float64(len(x)), 0)
+	for idx := range x {
+		x[idx] *= scale
+	}
+}
+
+// conjugate replaces each element of "x" with its complex conjugate.
+func conjugate(x []complex128) {
+	for idx, v := range x {
+		x[idx] = complex(real(v), -imag(v))
+	}
+}
+
+// fftLength returns the smallest length that is at least "n", and has no other prime factors than 2, 3 and 5.
+func fftLength(n int) int {
+	for ; ; n++ {
+		m := n
+		for _, p := range [...]int{2, 3, 5} {
+			for m%p == 0 {
+				m /= p
+			}
+		}
+
+		if m == 1 {
+			return n
+		}
+	}
+}
+
+// fft2D calculates the 2-D transforms of "rows x cols" complex matrices in row-major order.
+type fft2D struct {
+	rows, cols       int
+	rowPlan, colPlan *fftPlan
+	buf, scratch     []complex128
+}
+
+// newFFT2D creates the plans and the buffers of the 2-D transforms of "rows x cols" matrices, "rows > 1" and "cols > 1" must hold.
+func newFFT2D(rows, cols int) *fft2D {
+	f := &fft2D{rows: rows, cols: cols, rowPlan: planFFT(cols), colPlan: planFFT(rows)}
+	f.buf = make([]complex128, 2*maxInt(rows, cols))
+	f.scratch = make([]complex128, maxInt(f.rowPlan.maxRadix, f.colPlan.maxRadix))
+	return f
+}
+
+// transform calculates the forward 2-D transform of "x" in place, the transform of each row followed by the transform of each column.
+func (f *fft2D) transform(x []complex128) {
+	src := f.buf[:f.cols]
+	for r := 0; r < f.rows; r++ {
+		row := x[r*f.cols : (r+1)*f.cols]
+		copy(src, row)
+		f.rowPlan.transform(row, src, f.scratch)
+	}
+
+	col, dst := f.buf[:f.rows], f.buf[f.rows:2*f.rows]
+	for c := 0; c < f.cols; c++ {
+		for r := range col {
+			col[r] = x[r*f.cols+c]
+		}
+
+		f.colPlan.transform(dst, col, f.scratch)
+		for r, v := range dst {
+			x[r*f.cols+c] = v
+		}
+	}
+}
+
+// inverse calculates the inverse 2-D transform of "x" in place.
+func (f *fft2D) inverse(x []complex128) {
+	conjugate(x)
+	f.transform(x)
+	conjugate(x)
+
+	scale := complex(1/float64(len(x)), 0)
+	for idx := range x {
+		x[idx] *= scale
+	}
+}
+
+// maxAbs returns the largest absolute value of the elements of "m".
+func maxAbs(m *Matrix) float64 {
+	max := 0.0
+	m.each(func(_, _ int, v float64) {
+		max = math.Max(max, math.Abs(v))
+	})
+
+	return max
+}
+
+// correlateFFT is the FFT path of correlate, the receiver must already have the dimensions of the result, and it must not alias any of the operands.
+// The elements of the operands must be finite.
+// The input and the dilated, flipped kernel are zero-padded to a size where the circular convolution equals the full linear convolution,
+// which is the cross-correlation at every position of the padded input, and the result is subsampled from it by the stride.
+// The two real operands are packed into the real and the imaginary part of a single complex matrix, so only two transforms are needed.
+// The rounding errors of the packing grow with the square of the larger operand, so both operands are scaled below one by a power of two first,
+// and the errors of the result grow only with "max|a| * max|k|", the same as the errors of the transforms of the separate operands.
+func (m *Matrix) correlateFFT(aMat, kMat *Matrix, g convGeometry, flip bool) {
+	spanRows, spanCols := g.dilationRows*(g.kRows-1)+1, g.dilationCols*(g.kCols-1)+1
+	fullRows, fullCols := g.inRows+spanRows-1, g.inCols+spanCols-1
+	rows, cols := g.fftSize()
+
+	_, aExp := math.Frexp(maxAbs(aMat))
+	_, kExp := math.Frexp(maxAbs(kMat))
+
+	z := make([]complex128, rows*cols)
+	for r := 0; r < g.inRows; r++ {
+		for c, v := range aMat.row(r) {
+			z[r*cols+c] = complex(math.Ldexp(v, -aExp), 0)
+		}
+	}
+
+	// The correlation is the convolution with the kernel rotated by 180 degrees, so the kernel of a convolution stays in place.
+	for u := 0; u < g.kRows; u++ {
+		for v, k := range kMat.row(u) {
+			kr, kc := u*g.dilationRows, v*g.dilationCols
+			if !flip {
+				kr, kc = spanRows-1-kr, spanCols-1-kc
+			}
+
+			z[kr*cols+kc] += complex(0, math.Ldexp(k, -kExp))
+		}
+	}
+
+	f := newFFT2D(rows, cols)
+	f.transform(z)
+
+	// With "z = a + i*k", "A[j] = (Z[j] + conj(Z[-j])) / 2" and "K[j] = (Z[j] - conj(Z[-j])) / 2i",
+	// so "A[j] * K[j] = (Z[j]^2 - conj(Z[-j]^2)) / 4i".
+	quarterI := complex(0, -0.25)
+	for r := 0; r < rows; r++ {
+		nr := (rows - r) % rows
+		for c := 0; c < cols; c++ {
+			i, j := r*cols+c, nr*cols+(cols-c)%cols
+			if j < i {
+				continue
+			}
+
+			zi, zj := z[i]*z[i], z[j]*z[j]
+			z[i] = (zi - complex(real(zj), -imag(zj))) * quarterI
+			z[j] = (zj - complex(real(zi), -imag(zi))) * quarterI
+		}
+	}
+
+	f.inverse(z)
+
+	for i := 0; i < g.outRows; i++ {
+		dRow := m.row(i)
+		fr := i*g.strideRows - g.top + spanRows - 1
+		for j := range dRow {
+			fc := j*g.strideCols - g.left + spanCols - 1
+			if fr < 0 || fr >= fullRows || fc < 0 || fc >= fullCols {
+				dRow[j] = 0
+				continue
+			}
+
+			dRow[j] = math.Ldexp(real(z[fr*cols+fc]), aExp+kExp)
+		}
+	}
+}
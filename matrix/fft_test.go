@@ -0,0 +1,243 @@
+package matrix
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// dft calculates the discrete Fourier transform of "x" by its definition.
+func dft(x []complex128) []complex128 {
+	n := len(x)
+	out := make([]complex128, n)
+	for k := range out {
+		for j, v := range x {
+			out[k] += v * cmplx.Rect(1, -2*math.Pi*float64(j*k%n)/float64(n))
+		}
+	}
+
+	return out
+}
+
+func TestFFT(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 12, 16, 30, 49, 64, 97, 100, 128, 270, 1024} {
+		x := make([]complex128, n)
+		for idx := range x {
+			x[idx] = complex(r.Float64()*2-1, r.Float64()*2-1)
+		}
+
+		expected := dft(x)
+		got := append([]complex128(nil), x...)
+		FFT(got)
+		for idx := range got {
+			if cmplx.Abs(got[idx]-expected[idx]) > 1e-9 {
+				t.Fatalf("Length %d: expected element %d is %v, but got %v", n, idx, expected[idx], got[idx])
+			}
+		}
+
+		InverseFFT(got)
+		for idx := range got {
+			if cmplx.Abs(got[idx]-x[idx]) > 1e-12 {
+				t.Fatalf("Length %d: expected element %d is %v, but got %v", n, idx, x[idx], got[idx])
+			}
+		}
+	}
+}
+
+func TestFFTLength(t *testing.T) {
+	testCases := []struct{ n, expected int }{{1, 1}, {7, 8}, {11, 12}, {13, 15}, {257, 270}, {1025, 1080}}
+	for _, tc := range testCases {
+		if l := fftLength(tc.n); l != tc.expected {
+			t.Errorf("Expected length of %d is %d, but got %d", tc.n, tc.expected, l)
+		}
+	}
+}
+
+// TestCorrelate2D_fft compares the FFT path with the direct path of the cross-correlation and the convolution.
+func TestCorrelate2D_fft(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	options := []ConvOptions{
+		{},
+		{Padding: SamePadding},
+		{StrideRows: 2, StrideColumns: 3, Padding: SamePadding},
+		{DilationRows: 2, DilationColumns: 3, Padding: SamePadding},
+		{StrideRows: 3, DilationColumns: 2},
+		{Padding: CustomPadding, Top: 20, Bottom: 1, Left: 0, Right: 25},
+	}
+
+	shapes := [][4]int{{32, 29, 7, 9}, {17, 40, 1, 12}, {5, 5, 5, 5}, {1, 33, 1, 4}}
+	for _, opts := range options {
+		for _, s := range shapes {
+			aMat, kMat := randomMatrix(r, s[0], s[1]), randomMatrix(r, s[2], s[3])
+			g, err := opts.geometry(aMat.Rows, aMat.Columns, kMat.Rows, kMat.Columns)
+			if err != nil {
+				continue
+			}
+
+			for _, flip := range []bool{false, true} {
+				expected, _ := New(g.outRows, g.outCols, nil)
+				expected.correlateDirect(aMat, kMat, g, flip)
+
+				m, _ := New(g.outRows, g.outCols, nil)
+				m.correlateFFT(aMat, kMat, g, flip)
+				if !isApproxEqual(m.Values, expected.Values, 1e-9) {
+					t.Errorf("Options %+v, shape %v, flip %t: expected values are %v, but got %v", opts, s, flip, expected.Values, m.Values)
+				}
+			}
+		}
+	}
+
+	t.Run("Large kernel", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(1))
+		aMat, kMat := randomMatrix(r, 256, 256), randomMatrix(r, 15, 15)
+		opts := ConvOptions{Padding: SamePadding}
+		g, _ := opts.geometry(aMat.Rows, aMat.Columns, kMat.Rows, kMat.Columns)
+
+		expected, _ := New(g.outRows, g.outCols, nil)
+		expected.correlateDirect(aMat, kMat, g, false)
+
+		m := &Matrix{}
+		if err := m.Correlate2D(aMat, kMat, opts); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if !isApproxEqual(m.Values, expected.Values, 1e-9) {
+			t.Errorf("The FFT path differs from the direct path by more than %g", 1e-9)
+		}
+	})
+
+	t.Run("View receiver", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(2))
+		aMat, kMat := randomMatrix(r, 12, 12), randomMatrix(r, 9, 9)
+		parent, _ := New(6, 6, nil)
+		view, _ := parent.Slice(1, 5, 1, 5)
+		if err := view.Convolve2D(aMat, kMat, ConvOptions{}); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		expected := &Matrix{}
+		g, _ := ConvOptions{}.geometry(12, 12, 9, 9)
+		expected.reuse(g.outRows, g.outCols)
+		expected.correlateDirect(aMat, kMat, g, true)
+
+		got, _ := Copy(view)
+		if !isApproxEqual(got.Values, expected.Values, 1e-9) {
+			t.Errorf("Expected values are %v, but got %v", expected.Values, got.Values)
+		}
+
+		if parent.Values[0] != 0 || parent.Values[35] != 0 {
+			t.Errorf("Expected the elements outside of the view to stay zero, but got %v", parent.Values)
+		}
+	})
+}
+
+// TestCorrelate2D_fftScales checks that the errors of the FFT path grow only with "max|a| * max|k|", even if the scales of the operands differ.
+func TestCorrelate2D_fftScales(t *testing.T) {
+	testCases := []struct {
+		name           string
+		aScale, kScale float64
+		pixel          float64
+	}{
+		{"Large input, small kernel", 1e6, 1e-6, 0},
+		{"Small input, large kernel", 1e-6, 1e6, 0},
+		{"Single large pixel", 1, 1, 1e9},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := rand.New(rand.NewSource(3))
+			aMat, kMat := randomMatrix(r, 64, 64), randomMatrix(r, 9, 9)
+			aMat.Scale(tc.aScale, aMat)
+			kMat.Scale(tc.kScale, kMat)
+			if tc.pixel != 0 {
+				aMat.Set(10, 10, tc.pixel)
+			}
+
+			g, _ := ConvOptions{Padding: SamePadding}.geometry(aMat.Rows, aMat.Columns, kMat.Rows, kMat.Columns)
+			expected, _ := New(g.outRows, g.outCols, nil)
+			expected.correlateDirect(aMat, kMat, g, false)
+
+			m, _ := New(g.outRows, g.outCols, nil)
+			m.correlateFFT(aMat, kMat, g, false)
+
+			tolerance := 1e-12 * maxAbs(aMat) * maxAbs(kMat)
+			for idx := range m.Values {
+				if math.Abs(m.Values[idx]-expected.Values[idx]) > tolerance {
+					t.Fatalf("Expected element %d is %g, but got %g", idx, expected.Values[idx], m.Values[idx])
+				}
+			}
+		})
+	}
+}
+
+// TestCorrelate2D_nonFinite checks that a non-finite input is correlated directly, so it does not spread over the whole result.
+func TestCorrelate2D_nonFinite(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	aMat, kMat := randomMatrix(r, 32, 32), randomMatrix(r, 15, 15)
+	aMat.Set(5, 5, math.Inf(1))
+
+	opts := ConvOptions{Padding: SamePadding}
+	g, _ := opts.geometry(aMat.Rows, aMat.Columns, kMat.Rows, kMat.Columns)
+	if !g.useFFT() {
+		t.Fatal("The FFT path should be estimated to be faster")
+	}
+
+	expected, _ := New(g.outRows, g.outCols, nil)
+	expected.correlateDirect(aMat, kMat, g, false)
+
+	m := &Matrix{}
+	if err := m.Correlate2D(aMat, kMat, opts); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	for idx, v := range m.Values {
+		if v != expected.Values[idx] && !(math.IsNaN(v) && math.IsNaN(expected.Values[idx])) {
+			t.Fatalf("Expected element %d is %g, but got %g", idx, expected.Values[idx], v)
+		}
+	}
+
+	if v, _ := m.At(31, 31); math.IsInf(v, 0) || math.IsNaN(v) {
+		t.Errorf("Expected the elements away from the infinity to be finite, but got %g", v)
+	}
+}
+
+func TestConvGeometry_useFFT(t *testing.T) {
+	testCases := []struct {
+		name        string
+		size, kSize int
+		opts        ConvOptions
+		expected    bool
+	}{
+		{"Small kernel", 256, 3, ConvOptions{Padding: SamePadding}, false},
+		{"Large kernel", 256, 15, ConvOptions{Padding: SamePadding}, true},
+		{"Large kernel with a large stride", 256, 15, ConvOptions{StrideRows: 8, StrideColumns: 8, Padding: SamePadding}, false},
+		{"Large dilation", 32, 3, ConvOptions{DilationRows: 10, DilationColumns: 10}, false},
+		{"Small input", 32, 7, ConvOptions{Padding: SamePadding}, true},
+	}
+
+	for _, tc := range testCases {
+		g, err := tc.opts.geometry(tc.size, tc.size, tc.kSize, tc.kSize)
+		if err != nil {
+			t.Fatalf("%s: expected error is %v, but got %v", tc.name, nil, err)
+		}
+
+		if g.useFFT() != tc.expected {
+			t.Errorf("%s: expected choice of the FFT is %t, but got %t", tc.name, tc.expected, g.useFFT())
+		}
+	}
+
+	defer SetConvFFTCost(SetConvFFTCost(0))
+	g, _ := ConvOptions{}.geometry(8, 8, 1, 1)
+	if !g.useFFT() {
+		t.Error("The FFT should always be used with zero cost")
+	}
+}
@@ -0,0 +1,595 @@
+package matrix
+
+import "math"
+
+// Matrix32 is the single-precision counterpart of Matrix, it halves the memory and the bandwidth needed by large models.
+// It only has the subset of the operations of Matrix that the single-precision networks need: the element-wise operations,
+// Product, Transpose and the reductions, and the results are calculated in single precision too.
+// The other operations, e.g. the decompositions and the convolutions, need a conversion with ToFloat64.
+// Unlike Matrix, it cannot be a view, so its Values are always stored contiguously in row-major order.
+// Its kernels are implemented by a Backend32 (see SetBackend32).
+type Matrix32 struct {
+	Values  []float32 // Values of the matrix
+	Rows    int       // Number of rows
+	Columns int       // Number of columns
+
+	backend Backend32 // The backend of the operations that place their result in the matrix, nil for the global backend
+}
+
+// ApplyFn32 is the single-precision counterpart of ApplyFn, it is used by Matrix32.Apply.
+type ApplyFn32 func(value float32) float32
+
+// VectorFn32 is the single-precision counterpart of VectorFn, it is used by Matrix32.ApplyVector.
+// The function must write the result into "dst", which has the same dimensions as "src", and which may be the same matrix as "src".
+type VectorFn32 func(dst, src *Matrix32)
+
+// New32 creates a new Matrix32 with "r" rows and "c" columns, the same way as New.
+// It will return an error if "r <= 0", "c <= 0", or "vals != nil" and the length of "vals" is not "r * c".
+func New32(r, c int, vals []float32) (*Matrix32, error) {
+	if r <= 0 {
+		return nil, ErrZeroRow
+	}
+
+	if c <= 0 {
+		return nil, ErrZeroCol
+	}
+
+	if vals != nil && len(vals) != r*c {
+		return nil, ErrDataLength
+	}
+
+	m := &Matrix32{Values: make([]float32, r*c), Rows: r, Columns: c}
+	copy(m.Values, vals)
+
+	return m, nil
+}
+
+// Copy32 creates a new Matrix32 with the same dimensions and elements as "m".
+// It will return an error if "m == nil".
+func Copy32(m *Matrix32) (*Matrix32, error) {
+	if m == nil {
+		return nil, ErrNilMatrix
+	}
+
+	nMat := &Matrix32{Values: make([]float32, m.Rows*m.Columns), Rows: m.Rows, Columns: m.Columns, backend: m.backend}
+	copy(nMat.Values, m.Values)
+
+	return nMat, nil
+}
+
+// ToFloat32 creates a new Matrix32 from "m", the elements are rounded to the nearest single-precision value,
+// the ones beyond the range of float32 become infinities.
+// If "m" is a view only the elements of the view are converted.
+// It will return an error if "m == nil".
+func ToFloat32(m *Matrix) (*Matrix32, error) {
+	if m == nil {
+		return nil, ErrNilMatrix
+	}
+
+	nMat := &Matrix32{Values: make([]float32, m.Rows*m.Columns), Rows: m.Rows, Columns: m.Columns}
+	for r := 0; r < m.Rows; r++ {
+		dst := nMat.Values[r*m.Columns : (r+1)*m.Columns]
+		for c, v := range m.row(r) {
+			dst[c] = float32(v)
+		}
+	}
+
+	return nMat, nil
+}
+
+// ToFloat64 creates a new Matrix from "m", the conversion is exact.
+// It will return an error if "m == nil".
+func ToFloat64(m *Matrix32) (*Matrix, error) {
+	if m == nil {
+		return nil, ErrNilMatrix
+	}
+
+	nMat := &Matrix{Values: make([]float64, m.Rows*m.Columns), Rows: m.Rows, Columns: m.Columns}
+	for idx, v := range m.Values[:len(nMat.Values)] {
+		nMat.Values[idx] = float64(v)
+	}
+
+	return nMat, nil
+}
+
+// reuse sets the dimensions of the receiver to "r" rows and "c" columns, the same way as Matrix.reuse.
+func (m *Matrix32) reuse(r, c int) {
+	m.Rows, m.Columns = r, c
+	if cap(m.Values) < r*c {
+		m.Values = make([]float32, r*c)
+	} else {
+		m.Values = m.Values[:r*c]
+	}
+}
+
+// aliases32 reports whether the backing arrays of "a" and "b" overlap, the same way as aliases.
+func aliases32(a, b []float32) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+
+	ca, cb := a[:cap(a)], b[:cap(b)]
+	if &ca[len(ca)-1] != &cb[len(cb)-1] {
+		return false
+	}
+
+	aStart, aEnd := cap(a), cap(a)-len(a)
+	bStart, bEnd := cap(b), cap(b)-len(b)
+	return aEnd < bStart && bEnd < aStart
+}
+
+// operand returns "aMat" when it is safe to read it while the receiver is written element by element, otherwise it returns a copy of "aMat".
+func (m *Matrix32) operand(aMat *Matrix32) *Matrix32 {
+	if !aliases32(m.Values, aMat.Values) || (&m.Values[0] == &aMat.Values[0] && len(m.Values) == len(aMat.Values)) {
+		return aMat
+	}
+
+	cMat, _ := Copy32(aMat)
+	return cMat
+}
+
+// mapUnary checks "aMat", resizes the receiver to its dimensions and calls "kernel" with all the elements at once.
+func (m *Matrix32) mapUnary(aMat *Matrix32, kernel func(dst, a []float32)) error {
+	if aMat == nil {
+		return ErrNilMatrix
+	}
+
+	if aliases32(m.Values, aMat.Values) {
+		aMat = m.operand(aMat)
+	}
+
+	m.reuse(aMat.Rows, aMat.Columns)
+	kernel(m.Values, aMat.Values[:len(m.Values)])
+
+	return nil
+}
+
+// elementWise broadcasts "aMat" and "bMat" together the same way as Matrix.elementWise, and calls "kernel" with the rows of the receiver and the broadcast operands.
+func (m *Matrix32) elementWise(aMat, bMat *Matrix32, kernel func(dst, a, b []float32)) error {
+	if aMat == nil || bMat == nil {
+		return ErrNilMatrix
+	}
+
+	r, rOk := broadcastDimension(aMat.Rows, bMat.Rows)
+	c, cOk := broadcastDimension(aMat.Columns, bMat.Columns)
+	if !rOk || !cOk {
+		return newShapeError("Broadcast", ErrBroadcastDimensions, aMat.Rows, aMat.Columns, bMat.Rows, bMat.Columns)
+	}
+
+	if (aMat.Rows != r || aMat.Columns != c) && aliases32(m.Values, aMat.Values) {
+		aMat, _ = Copy32(aMat)
+	}
+
+	if (bMat.Rows != r || bMat.Columns != c) && aliases32(m.Values, bMat.Values) {
+		bMat, _ = Copy32(bMat)
+	}
+
+	m.reuse(r, c)
+	aMat, bMat = m.operand(aMat), m.operand(bMat)
+	if aMat.Rows == r && aMat.Columns == c && bMat.Rows == r && bMat.Columns == c {
+		n := r * c
+		kernel(m.Values, aMat.Values[:n], bMat.Values[:n])
+		return nil
+	}
+
+	var aBuf, bBuf []float32
+	if aMat.Columns != c {
+		aBuf = make([]float32, c)
+	}
+
+	if bMat.Columns != c {
+		bBuf = make([]float32, c)
+	}
+
+	for row := 0; row < r; row++ {
+		kernel(m.Values[row*c:(row+1)*c], broadcastRow32(aMat, row, c, aBuf), broadcastRow32(bMat, row, c, bBuf))
+	}
+
+	return nil
+}
+
+// broadcastRow32 returns the elements of "aMat" that belong to the "r"-th row of a broadcast result with "c" columns, the same way as broadcastRow.
+func broadcastRow32(aMat *Matrix32, r, c int, buf []float32) []float32 {
+	if aMat.Rows == 1 {
+		r = 0
+	}
+
+	row := aMat.Values[r*aMat.Columns : (r+1)*aMat.Columns]
+	if aMat.Columns == c {
+		return row
+	}
+
+	for idx := range buf {
+		buf[idx] = row[0]
+	}
+
+	return buf
+}
+
+// SetValues sets the receiver to a Matrix32 with "r" rows and "c" columns holding a copy of "vals", the same way as Matrix.SetValues.
+// It will return an error if "r <= 0", "c <= 0" or the length of "vals" is not "r * c".
+func (m *Matrix32) SetValues(r, c int, vals []float32) error {
+	if r <= 0 {
+		return ErrZeroRow
+	}
+
+	if c <= 0 {
+		return ErrZeroCol
+	}
+
+	if len(vals) != r*c {
+		return ErrDataLength
+	}
+
+	return m.mapUnary(&Matrix32{Values: vals, Rows: r, Columns: c}, func(dst, a []float32) {
+		copy(dst, a)
+	})
+}
+
+// CopyFrom copies the dimensions and the elements of "aMat" into the receiver.
+// It will return an error if "aMat == nil".
+func (m *Matrix32) CopyFrom(aMat *Matrix32) error {
+	if m == aMat {
+		return nil
+	}
+
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		copy(dst, a)
+	})
+}
+
+// At returns the element at row "r", column "c", the same way as Matrix.At.
+// It will return an error if "r" or "c" is out of bounds.
+func (m *Matrix32) At(r, c int) (float32, error) {
+	if r < 0 || r > m.Rows-1 {
+		return 0, ErrRowOutOfBounds
+	}
+
+	if c < 0 || c > m.Columns-1 {
+		return 0, ErrColOutOfBounds
+	}
+
+	return m.Values[r*m.Columns+c], nil
+}
+
+// Set sets the element at row "r", column "c" to "v", the same way as Matrix.Set.
+// It will return an error if "r" or "c" is out of bounds.
+func (m *Matrix32) Set(r, c int, v float32) error {
+	if r < 0 || r > m.Rows-1 {
+		return ErrRowOutOfBounds
+	}
+
+	if c < 0 || c > m.Columns-1 {
+		return ErrColOutOfBounds
+	}
+
+	m.Values[r*m.Columns+c] = v
+
+	return nil
+}
+
+// Abs calculates the absolute value of each element of "a", placing the result in the receiver.
+// It will return an error if "a == nil".
+func (m *Matrix32) Abs(aMat *Matrix32) error {
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		for idx := range dst {
+			dst[idx] = float32(math.Abs(float64(a[idx])))
+		}
+	})
+}
+
+// Add adds "aMat" and "bMat" element-wise, placing the result in the receiver, the operands are broadcast together the same way as in Matrix.Add.
+// It will return an error if "aMat == nil" or "bMat == nil", or their dimensions cannot be broadcast together.
+func (m *Matrix32) Add(aMat, bMat *Matrix32) error {
+	return m.elementWise(aMat, bMat, m.Backend().Add)
+}
+
+// Subtract subtracts "a" and "b" element-wise, placing the result in the receiver, in the order of "a - b".
+// It will return the same errors as Add.
+func (m *Matrix32) Subtract(aMat, bMat *Matrix32) error {
+	return m.elementWise(aMat, bMat, m.Backend().Subtract)
+}
+
+// Multiply performs element-wise multiplication of "a" and "b", placing the result in the receiver.
+// It will return the same errors as Add.
+func (m *Matrix32) Multiply(aMat, bMat *Matrix32) error {
+	return m.elementWise(aMat, bMat, m.Backend().Multiply)
+}
+
+// Divide performs element-wise division of "a" and "b", placing the result in the receiver, in the order of "a / b".
+// It will return the same errors as Add.
+func (m *Matrix32) Divide(aMat, bMat *Matrix32) error {
+	return m.elementWise(aMat, bMat, m.Backend().Divide)
+}
+
+// Apply applies the function "fn" to each of the elements of "a", placing the resulting matrix in the receiver.
+// It will return an error if "fn == nil" or "a == nil".
+func (m *Matrix32) Apply(fn ApplyFn32, aMat *Matrix32) error {
+	if fn == nil {
+		return ErrNilFunction
+	}
+
+	b := m.Backend()
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		b.Apply(dst, a, fn)
+	})
+}
+
+// ApplyVector applies the function "fn" to the whole "a" matrix, placing the resulting matrix in the receiver.
+// It will return an error if "fn == nil" or "a == nil".
+func (m *Matrix32) ApplyVector(fn VectorFn32, aMat *Matrix32) error {
+	if fn == nil {
+		return ErrNilFunction
+	}
+
+	if aMat == nil {
+		return ErrNilMatrix
+	}
+
+	if m != aMat {
+		aMat = m.operand(aMat)
+		m.reuse(aMat.Rows, aMat.Columns)
+	}
+
+	fn(m, aMat)
+
+	return nil
+}
+
+// Clip limits the elements of "a" to the "[min, max]" interval, placing the result in the receiver, NaN elements remain NaN.
+// It will return an error if "a == nil", or "min > max".
+func (m *Matrix32) Clip(min, max float32, aMat *Matrix32) error {
+	if min > max {
+		return ErrClipRange
+	}
+
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		for idx := range dst {
+			v := a[idx]
+			if v < min {
+				v = min
+			} else if v > max {
+				v = max
+			}
+
+			dst[idx] = v
+		}
+	})
+}
+
+// Exp calculates "e**x" for each element "x" of "a", placing the result in the receiver.
+// It will return an error if "a == nil".
+func (m *Matrix32) Exp(aMat *Matrix32) error {
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		for idx := range dst {
+			dst[idx] = float32(math.Exp(float64(a[idx])))
+		}
+	})
+}
+
+// Log calculates the natural logarithm of each element of "a", placing the result in the receiver.
+// It will return an error if "a == nil".
+func (m *Matrix32) Log(aMat *Matrix32) error {
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		for idx := range dst {
+			dst[idx] = float32(math.Log(float64(a[idx])))
+		}
+	})
+}
+
+// Pow raises each element of "a" to the power of "p", placing the result in the receiver.
+// It will return an error if "a == nil".
+func (m *Matrix32) Pow(p float32, aMat *Matrix32) error {
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		switch p {
+		case 2:
+			for idx := range dst {
+				dst[idx] = a[idx] * a[idx]
+			}
+		default:
+			for idx := range dst {
+				dst[idx] = float32(math.Pow(float64(a[idx]), float64(p)))
+			}
+		}
+	})
+}
+
+// Sqrt calculates the square root of each element of "a", placing the result in the receiver.
+// It will return an error if "a == nil".
+func (m *Matrix32) Sqrt(aMat *Matrix32) error {
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		for idx := range dst {
+			dst[idx] = float32(math.Sqrt(float64(a[idx])))
+		}
+	})
+}
+
+// Scale multiplies the elements of "a" by "s", placing the result in the receiver.
+// It will return an error if "a == nil".
+func (m *Matrix32) Scale(s float32, aMat *Matrix32) error {
+	b := m.Backend()
+	return m.mapUnary(aMat, func(dst, a []float32) {
+		b.Scale(dst, s, a)
+	})
+}
+
+// Product performs matrix multiplication of "a" and "b", placing the result in the receiver.
+// GoBackend32 accumulates the products in single precision, in the same tiles and with the same workers as GoBackend.
+// The receiver may be one of the operands, in that case the result is calculated in a temporary matrix first.
+// It will return an error if "aMat == nil" or "bMat == nil", or the number of columns in "a" not equal with the number of rows in "b".
+func (m *Matrix32) Product(aMat, bMat *Matrix32) error {
+	if aMat == nil || bMat == nil {
+		return ErrNilMatrix
+	}
+
+	if aMat.Columns != bMat.Rows {
+		return newShapeError("Product", ErrBadProductDimension, aMat.Columns, bMat.Columns, bMat.Rows, bMat.Columns)
+	}
+
+	if aliases32(m.Values, aMat.Values) || aliases32(m.Values, bMat.Values) {
+		tmp := &Matrix32{}
+		tmp.reuse(aMat.Rows, bMat.Columns)
+		m.Backend().Product(tmp, aMat, bMat)
+		return m.CopyFrom(tmp)
+	}
+
+	m.reuse(aMat.Rows, bMat.Columns)
+	m.Backend().Product(m, aMat, bMat)
+
+	return nil
+}
+
+// SetBackend sets the Backend32 used by the operations that place their result in the receiver, the same way as Matrix.SetBackend.
+// If "b == nil", the receiver uses the global backend (see SetBackend32).
+func (m *Matrix32) SetBackend(b Backend32) {
+	m.backend = b
+}
+
+// Backend returns the Backend32 used by the operations that place their result in the receiver.
+func (m *Matrix32) Backend() Backend32 {
+	if m.backend != nil {
+		return m.backend
+	}
+
+	return DefaultBackend32()
+}
+
+// product is the kernel of GoBackend32.Product, the receiver must not alias any of the operands.
+func (m *Matrix32) product(aMat, bMat *Matrix32) {
+	m.reuse(aMat.Rows, bMat.Columns)
+	for idx := range m.Values {
+		m.Values[idx] = 0
+	}
+
+	workers, bands := productPlan(m.Rows, int64(aMat.Rows)*int64(aMat.Columns)*int64(bMat.Columns))
+	if workers <= 1 {
+		productBlock32(m, aMat, bMat, 0, m.Rows)
+		return
+	}
+
+	productParallel(m.Rows, workers, bands, func(r0, r1 int) {
+		productBlock32(m, aMat, bMat, r0, r1)
+	})
+}
+
+// productBlock32 accumulates the rows "r0 <= r < r1" of "a * b" into "m", tile by tile, the same way as productBlock.
+func productBlock32(m, aMat, bMat *Matrix32, r0, r1 int) {
+	aCols, bCols := aMat.Columns, bMat.Columns
+	aVals, bVals, mVals := aMat.Values, bMat.Values, m.Values
+
+	for i0 := r0; i0 < r1; i0 += productBlockSize {
+		i1 := minInt(i0+productBlockSize, r1)
+		for k0 := 0; k0 < aCols; k0 += productBlockSize {
+			k1 := minInt(k0+productBlockSize, aCols)
+			for j0 := 0; j0 < bCols; j0 += productBlockSize {
+				j1 := minInt(j0+productBlockSize, bCols)
+				for i := i0; i < i1; i++ {
+					mRow := mVals[i*bCols+j0 : i*bCols+j1]
+					aRow := aVals[i*aCols+k0 : i*aCols+k1]
+					for k, aVal := range aRow {
+						bRow := bVals[(k0+k)*bCols+j0 : (k0+k)*bCols+j1]
+						bRow = bRow[:len(mRow)]
+						for j, bVal := range bRow {
+							mRow[j] += aVal * bVal
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// Transpose switches the row and column indices of "a", placing the result in the receiver.
+// It will return an error if "a == nil".
+func (m *Matrix32) Transpose(aMat *Matrix32) error {
+	if aMat == nil {
+		return ErrNilMatrix
+	}
+
+	aRows, aCols := aMat.Rows, aMat.Columns
+	if m == aMat && (aRows == 1 || aCols == 1) {
+		m.Rows, m.Columns = aCols, aRows
+		return nil
+	}
+
+	if aliases32(m.Values, aMat.Values) {
+		aMat, _ = Copy32(aMat)
+	}
+
+	m.reuse(aCols, aRows)
+	for r := 0; r < m.Rows; r++ {
+		mRow := m.Values[r*aRows : (r+1)*aRows]
+		for c := range mRow {
+			mRow[c] = aMat.Values[c*aCols+r]
+		}
+	}
+
+	return nil
+}
+
+// Sum returns the sum of the elements of the matrix, the elements are added in row-major order in single precision.
+func (m *Matrix32) Sum() float32 {
+	var sum float32
+	for _, v := range m.Values[:m.Rows*m.Columns] {
+		sum += v
+	}
+
+	return sum
+}
+
+// Mean returns the arithmetic mean of the elements of the matrix, or NaN if the matrix has no elements.
+func (m *Matrix32) Mean() float32 {
+	return m.Sum() / float32(m.Rows*m.Columns)
+}
+
+// Max returns the largest element of the matrix, or "-Inf" if the matrix has no elements.
+// If any of the elements is NaN, it returns NaN.
+func (m *Matrix32) Max() float32 {
+	max := math.Inf(-1)
+	for _, v := range m.Values[:m.Rows*m.Columns] {
+		max = math.Max(max, float64(v))
+	}
+
+	return float32(max)
+}
+
+// Min returns the smallest element of the matrix, or "+Inf" if the matrix has no elements.
+// If any of the elements is NaN, it returns NaN.
+func (m *Matrix32) Min() float32 {
+	min := math.Inf(1)
+	for _, v := range m.Values[:m.Rows*m.Columns] {
+		min = math.Min(min, float64(v))
+	}
+
+	return float32(min)
+}
+
+// ArgMax returns the row and the column of the largest element of the matrix, the same way as Matrix.ArgMax.
+func (m *Matrix32) ArgMax() (int, int) {
+	return m.arg(func(v, best float32) bool { return v > best })
+}
+
+// ArgMin returns the row and the column of the smallest element of the matrix, the same way as Matrix.ArgMin.
+func (m *Matrix32) ArgMin() (int, int) {
+	return m.arg(func(v, best float32) bool { return v < best })
+}
+
+// arg returns the position of the non-NaN element for which "better" reports true against every other, earlier elements win ties.
+func (m *Matrix32) arg(better func(v, best float32) bool) (int, int) {
+	bestIdx, best := -1, float32(0)
+	for idx, v := range m.Values[:m.Rows*m.Columns] {
+		if math.IsNaN(float64(v)) {
+			continue
+		}
+
+		if bestIdx == -1 || better(v, best) {
+			bestIdx, best = idx, v
+		}
+	}
+
+	if bestIdx == -1 {
+		return -1, -1
+	}
+
+	return bestIdx / m.Columns, bestIdx % m.Columns
+}
@@ -0,0 +1,310 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randomMatrix32 returns a "rows x cols" single-precision matrix and its exact double-precision counterpart.
+func randomMatrix32(r *rand.Rand, rows, cols int) (*Matrix32, *Matrix) {
+	m64 := randomMatrix(r, rows, cols)
+	m32, _ := ToFloat32(m64)
+	m64, _ = ToFloat64(m32)
+
+	return m32, m64
+}
+
+// isApproxEqual32 reports whether the elements of "a" are within "tol" of the elements of "b".
+func isApproxEqual32(a []float32, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for idx := range a {
+		if math.Abs(float64(a[idx])-b[idx]) > tol && !(math.IsNaN(float64(a[idx])) && math.IsNaN(b[idx])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestNew32(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rows, columns int
+		values        []float32
+		expectedError error
+	}{
+		{"Normal", 2, 2, []float32{1, 2, 3, 4}, nil},
+		{"Nil values", 2, 3, nil, nil},
+		{"ErrZeroRow", 0, 2, nil, ErrZeroRow},
+		{"ErrZeroCol", 2, 0, nil, ErrZeroCol},
+		{"ErrDataLength", 2, 2, []float32{1, 2, 3}, ErrDataLength},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := New32(tc.rows, tc.columns, tc.values)
+			if err != tc.expectedError {
+				t.Fatalf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if m.Rows != tc.rows || m.Columns != tc.columns || len(m.Values) != tc.rows*tc.columns {
+				t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.rows, tc.columns, m.Rows, m.Columns)
+			}
+
+			if tc.values != nil {
+				tc.values[0] = 100
+				if m.Values[0] == 100 {
+					t.Error("The values should be copied")
+				}
+			}
+		})
+	}
+}
+
+func TestConversion32(t *testing.T) {
+	aMat, _ := New(2, 3, []float64{1, -0.1, math.MaxFloat64, math.Inf(-1), math.NaN(), 1e-50})
+	m32, err := ToFloat32(aMat)
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	expected := []float32{1, float32(-0.1), float32(math.Inf(1)), float32(math.Inf(-1)), float32(math.NaN()), 0}
+	for idx, v := range m32.Values {
+		if v != expected[idx] && !(math.IsNaN(float64(v)) && math.IsNaN(float64(expected[idx]))) {
+			t.Errorf("Expected element %d is %v, but got %v", idx, expected[idx], v)
+		}
+	}
+
+	m64, _ := ToFloat64(m32)
+	if m64.Rows != 2 || m64.Columns != 3 || m64.Values[1] != float64(float32(-0.1)) {
+		t.Errorf("Expected matrix is %v, but got %v", m32.Values, m64.Values)
+	}
+
+	// Only the elements of a view are converted.
+	parent, _ := New(3, 3, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8})
+	view, _ := parent.Slice(1, 3, 1, 3)
+	v32, _ := ToFloat32(view)
+	if !isApproxEqual32(v32.Values, []float64{4, 5, 7, 8}, 0) {
+		t.Errorf("Expected values are %v, but got %v", []float64{4, 5, 7, 8}, v32.Values)
+	}
+
+	if _, err := ToFloat32(nil); err != ErrNilMatrix {
+		t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+	}
+
+	if _, err := ToFloat64(nil); err != ErrNilMatrix {
+		t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+	}
+}
+
+// TestMatrix32 compares the single-precision operations with the double-precision ones on the same operands.
+func TestMatrix32(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	a32, a64 := randomMatrix32(r, 5, 4)
+	b32, b64 := randomMatrix32(r, 5, 4)
+	col32, col64 := randomMatrix32(r, 5, 1)
+	row32, row64 := randomMatrix32(r, 1, 4)
+	c32, c64 := randomMatrix32(r, 4, 3)
+
+	testCases := []struct {
+		name string
+		fn32 func(m *Matrix32) error
+		fn64 func(m *Matrix) error
+	}{
+		{"Abs", func(m *Matrix32) error { return m.Abs(a32) }, func(m *Matrix) error { return m.Abs(a64) }},
+		{"Add", func(m *Matrix32) error { return m.Add(a32, b32) }, func(m *Matrix) error { return m.Add(a64, b64) }},
+		{"Add broadcast", func(m *Matrix32) error { return m.Add(col32, row32) }, func(m *Matrix) error { return m.Add(col64, row64) }},
+		{"Subtract broadcast", func(m *Matrix32) error { return m.Subtract(a32, col32) }, func(m *Matrix) error { return m.Subtract(a64, col64) }},
+		{"Multiply broadcast", func(m *Matrix32) error { return m.Multiply(row32, b32) }, func(m *Matrix) error { return m.Multiply(row64, b64) }},
+		{"Divide", func(m *Matrix32) error { return m.Divide(a32, b32) }, func(m *Matrix) error { return m.Divide(a64, b64) }},
+		{"Apply", func(m *Matrix32) error {
+			return m.Apply(func(v float32) float32 { return v * v }, a32)
+		}, func(m *Matrix) error {
+			return m.Apply(func(v float64) float64 { return v * v }, a64)
+		}},
+		{"ApplyVector", func(m *Matrix32) error {
+			return m.ApplyVector(func(dst, src *Matrix32) { dst.Scale(2, src) }, a32)
+		}, func(m *Matrix) error {
+			return m.ApplyVector(func(dst, src *Matrix) { dst.Scale(2, src) }, a64)
+		}},
+		{"Clip", func(m *Matrix32) error { return m.Clip(-0.5, 0.5, a32) }, func(m *Matrix) error { return m.Clip(-0.5, 0.5, a64) }},
+		{"Exp", func(m *Matrix32) error { return m.Exp(a32) }, func(m *Matrix) error { return m.Exp(a64) }},
+		{"Log", func(m *Matrix32) error { return m.Log(a32) }, func(m *Matrix) error { return m.Log(a64) }},
+		{"Pow", func(m *Matrix32) error { return m.Pow(3, a32) }, func(m *Matrix) error { return m.Pow(3, a64) }},
+		{"Pow 2", func(m *Matrix32) error { return m.Pow(2, a32) }, func(m *Matrix) error { return m.Pow(2, a64) }},
+		{"Sqrt", func(m *Matrix32) error { return m.Sqrt(a32) }, func(m *Matrix) error { return m.Sqrt(a64) }},
+		{"Scale", func(m *Matrix32) error { return m.Scale(-3, a32) }, func(m *Matrix) error { return m.Scale(-3, a64) }},
+		{"Product", func(m *Matrix32) error { return m.Product(a32, c32) }, func(m *Matrix) error { return m.Product(a64, c64) }},
+		{"Transpose", func(m *Matrix32) error { return m.Transpose(a32) }, func(m *Matrix) error { return m.Transpose(a64) }},
+		{"CopyFrom", func(m *Matrix32) error { return m.CopyFrom(a32) }, func(m *Matrix) error { return m.CopyFrom(a64) }},
+		{"SetValues", func(m *Matrix32) error { return m.SetValues(4, 5, a32.Values) }, func(m *Matrix) error { return m.SetValues(4, 5, a64.Values) }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m32, m64 := &Matrix32{}, &Matrix{}
+			if err := tc.fn32(m32); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+			tc.fn64(m64)
+
+			if m32.Rows != m64.Rows || m32.Columns != m64.Columns {
+				t.Fatalf("Expected dimensions are %dx%d, but got %dx%d", m64.Rows, m64.Columns, m32.Rows, m32.Columns)
+			}
+
+			if !isApproxEqual32(m32.Values, m64.Values, 1e-6) {
+				t.Errorf("Expected values are %v, but got %v", m64.Values, m32.Values)
+			}
+		})
+	}
+}
+
+func TestMatrix32_inPlace(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a32, a64 := randomMatrix32(r, 3, 4)
+	col32, col64 := randomMatrix32(r, 3, 1)
+
+	m32, _ := Copy32(col32)
+	m64, _ := Copy(col64)
+	m32.Add(m32, a32)
+	m64.Add(m64, a64)
+	if !isApproxEqual32(m32.Values, m64.Values, 1e-6) {
+		t.Errorf("Expected values are %v, but got %v", m64.Values, m32.Values)
+	}
+
+	m32.Product(m32, &Matrix32{Values: []float32{1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1}, Rows: 4, Columns: 4})
+	if !isApproxEqual32(m32.Values, m64.Values, 1e-6) {
+		t.Errorf("Expected values are %v, but got %v", m64.Values, m32.Values)
+	}
+
+	m32.Transpose(m32)
+	m64.Transpose(m64)
+	if m32.Rows != 4 || !isApproxEqual32(m32.Values, m64.Values, 1e-6) {
+		t.Errorf("Expected values are %v, but got %v", m64.Values, m32.Values)
+	}
+}
+
+func TestMatrix32_errors(t *testing.T) {
+	aMat, _ := New32(2, 3, nil)
+	bMat, _ := New32(3, 3, nil)
+	m := &Matrix32{}
+
+	testCases := []struct {
+		name          string
+		err           error
+		expectedError error
+	}{
+		{"Add nil", m.Add(nil, aMat), ErrNilMatrix},
+		{"Add broadcast", m.Add(aMat, bMat), ErrBroadcastDimensions},
+		{"Apply nil function", m.Apply(nil, aMat), ErrNilFunction},
+		{"ApplyVector nil", m.ApplyVector(func(dst, src *Matrix32) {}, nil), ErrNilMatrix},
+		{"Clip range", m.Clip(1, 0, aMat), ErrClipRange},
+		{"Exp nil", m.Exp(nil), ErrNilMatrix},
+		{"Product dimensions", m.Product(aMat, aMat), ErrBadProductDimension},
+		{"Product nil", m.Product(nil, aMat), ErrNilMatrix},
+		{"SetValues length", m.SetValues(2, 2, []float32{1}), ErrDataLength},
+		{"Transpose nil", m.Transpose(nil), ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		if !errors.Is(tc.err, tc.expectedError) {
+			t.Errorf("%s: expected error is %v, but got %v", tc.name, tc.expectedError, tc.err)
+		}
+	}
+
+	if _, err := aMat.At(2, 0); err != ErrRowOutOfBounds {
+		t.Errorf("Expected error is %v, but got %v", ErrRowOutOfBounds, err)
+	}
+
+	if err := aMat.Set(0, 3, 1); err != ErrColOutOfBounds {
+		t.Errorf("Expected error is %v, but got %v", ErrColOutOfBounds, err)
+	}
+}
+
+func TestMatrix32_reduce(t *testing.T) {
+	m, _ := New32(2, 3, []float32{1, -2, 6, 4, 6, -3})
+	if sum := m.Sum(); sum != 12 {
+		t.Errorf("Expected sum is %v, but got %v", 12, sum)
+	}
+
+	if mean := m.Mean(); mean != 2 {
+		t.Errorf("Expected mean is %v, but got %v", 2, mean)
+	}
+
+	if max, min := m.Max(), m.Min(); max != 6 || min != -3 {
+		t.Errorf("Expected maximum and minimum are %v and %v, but got %v and %v", 6, -3, max, min)
+	}
+
+	if r, c := m.ArgMax(); r != 0 || c != 2 {
+		t.Errorf("Expected position is %d, %d, but got %d, %d", 0, 2, r, c)
+	}
+
+	if r, c := m.ArgMin(); r != 1 || c != 2 {
+		t.Errorf("Expected position is %d, %d, but got %d, %d", 1, 2, r, c)
+	}
+
+	m.Set(1, 1, float32(math.NaN()))
+	if max := m.Max(); !math.IsNaN(float64(max)) {
+		t.Errorf("Expected maximum is %v, but got %v", math.NaN(), max)
+	}
+
+	if r, c := m.ArgMax(); r != 0 || c != 2 {
+		t.Errorf("Expected position is %d, %d, but got %d, %d", 0, 2, r, c)
+	}
+}
+
+func TestProduct32_parallel(t *testing.T) {
+	defer SetProductWorkers(SetProductWorkers(4))
+	defer SetProductThreshold(SetProductThreshold(0))
+
+	r := rand.New(rand.NewSource(2))
+	a32, a64 := randomMatrix32(r, 150, 70)
+	b32, b64 := randomMatrix32(r, 70, 130)
+
+	m32, m64 := &Matrix32{}, &Matrix{}
+	m32.Product(a32, b32)
+	m64.Product(a64, b64)
+	if !isApproxEqual32(m32.Values, m64.Values, 1e-4) {
+		t.Error("The single-precision product differs from the double-precision product")
+	}
+}
+
+func TestMatrix32_allocations(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	a32, _ := randomMatrix32(r, 8, 8)
+	col32, _ := randomMatrix32(r, 8, 1)
+	m := &Matrix32{}
+
+	testCases := []struct {
+		name string
+		fn   func()
+	}{
+		{"Add", func() { m.Add(a32, a32) }},
+		{"Add in-place", func() { m.Add(m, m) }},
+		{"Product", func() { m.Product(a32, col32) }},
+		{"Scale", func() { m.Scale(2, a32) }},
+		{"Transpose", func() { m.Transpose(a32) }},
+	}
+
+	for _, tc := range testCases {
+		tc.fn()
+		if allocs := testing.AllocsPerRun(100, tc.fn); allocs != 0 {
+			t.Errorf("%s: expected number of allocations is %d, but got %f", tc.name, 0, allocs)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package matrix
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// *Matrix have to implement fmt.Formatter and fmt.Stringer
+var _ fmt.Formatter = &Matrix{}
+var _ fmt.Stringer = &Matrix{}
+
+const (
+	// formatMaxItems is the largest number of rows (or columns) that is printed without truncation.
+	formatMaxItems = 10
+
+	// formatEdgeItems is the number of rows (or columns) printed at both ends of a truncated dimension.
+	formatEdgeItems = 3
+
+	// formatEllipsis replaces the omitted rows and columns of a truncated matrix.
+	formatEllipsis = "..."
+)
+
+// formatIndices returns the indices of the rows (or columns) that are printed from a dimension of size "n", "-1" marks the omitted ones.
+func formatIndices(n int, all bool) []int {
+	if all || n <= formatMaxItems {
+		idxs := make([]int, n)
+		for idx := range idxs {
+			idxs[idx] = idx
+		}
+
+		return idxs
+	}
+
+	idxs := make([]int, 0, 2*formatEdgeItems+1)
+	for idx := 0; idx < formatEdgeItems; idx++ {
+		idxs = append(idxs, idx)
+	}
+
+	idxs = append(idxs, -1)
+	for idx := n - formatEdgeItems; idx < n; idx++ {
+		idxs = append(idxs, idx)
+	}
+
+	return idxs
+}
+
+// Format implements the fmt.Formatter interface, it prints the matrix row by row, with the columns right-aligned.
+// The "%v", "%s", "%e", "%E", "%f", "%F", "%g" and "%G" verbs are supported, "%v" and "%s" print the elements the same way as "%g".
+// The precision is applied to each element (e.g. "%.3v"), and the width is the minimum width of a column.
+// The matrices with more than ten rows (or columns) are truncated, only the first and last three rows (or columns) are printed,
+// the space flag (e.g. "% v") prints the whole matrix without truncation.
+// The "+" flag (e.g. "%+v") prints the dimensions and the minimum, maximum and mean of the elements before the matrix, and it can be combined with the space flag.
+// The "%#v" verb prints the Go syntax representation of the matrix, the same way as for other values, with the elements of a view copied into a contiguous slice.
+func (m *Matrix) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('#') {
+		m.goSyntax(s)
+		return
+	}
+
+	if m == nil {
+		fmt.Fprint(s, "<nil>")
+		return
+	}
+
+	var f byte
+	switch verb {
+	case 'v', 's':
+		f = 'g'
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		f = byte(verb)
+	default:
+		fmt.Fprintf(s, "%%!%c(*matrix.Matrix=%dx%d)", verb, m.Rows, m.Columns)
+		return
+	}
+
+	prec, ok := s.Precision()
+	if !ok {
+		prec = -1
+	}
+
+	element := func(v float64) string {
+		return strconv.FormatFloat(v, f, prec, 64)
+	}
+
+	if s.Flag('+') {
+		fmt.Fprintf(s, "Matrix %dx%d", m.Rows, m.Columns)
+		if m.Rows*m.Columns > 0 {
+			fmt.Fprintf(s, ", min: %s, max: %s, mean: %s", element(m.Min()), element(m.Max()), element(m.Mean()))
+		}
+
+		fmt.Fprint(s, "\n")
+	}
+
+	if m.Rows*m.Columns == 0 {
+		fmt.Fprint(s, "[]")
+		return
+	}
+
+	rows, cols := formatIndices(m.Rows, s.Flag(' ')), formatIndices(m.Columns, s.Flag(' '))
+	widths := make([]int, len(cols))
+	if w, ok := s.Width(); ok {
+		for idx := range widths {
+			widths[idx] = w
+		}
+	}
+
+	cells := make([][]string, len(rows))
+	for i, r := range rows {
+		cells[i] = make([]string, len(cols))
+		for j, c := range cols {
+			if r < 0 || c < 0 {
+				cells[i][j] = formatEllipsis
+			} else {
+				cells[i][j] = element(m.Values[r*m.Stride()+c])
+			}
+
+			if len(cells[i][j]) > widths[j] {
+				widths[j] = len(cells[i][j])
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, row := range cells {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		b.WriteByte('[')
+		for j, cell := range row {
+			if j > 0 {
+				b.WriteString("  ")
+			}
+
+			b.WriteString(strings.Repeat(" ", widths[j]-len(cell)))
+			b.WriteString(cell)
+		}
+
+		b.WriteByte(']')
+	}
+
+	fmt.Fprint(s, b.String())
+}
+
+// goSyntax prints the Go syntax representation of the matrix, a composite literal of its exported fields.
+func (m *Matrix) goSyntax(s fmt.State) {
+	if m == nil {
+		fmt.Fprint(s, "(*matrix.Matrix)(nil)")
+		return
+	}
+
+	var vals []float64
+	if m.contiguous() {
+		vals = m.Values[:m.Rows*m.Columns]
+	} else {
+		vals = make([]float64, 0, m.Rows*m.Columns)
+		for r := 0; r < m.Rows; r++ {
+			vals = append(vals, m.row(r)...)
+		}
+	}
+
+	fmt.Fprintf(s, "&matrix.Matrix{Values:%#v, Rows:%d, Columns:%d}", vals, m.Rows, m.Columns)
+}
+
+// String implements the fmt.Stringer interface, it returns the matrix formatted by the "%v" verb.
+func (m *Matrix) String() string {
+	return fmt.Sprintf("%v", m)
+}
@@ -0,0 +1,68 @@
+package matrix
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	m := &Matrix{Values: []float64{1, -2.5, 3, 400, 5, 6}, Rows: 2, Columns: 3}
+	big, _ := New(12, 11, nil)
+	for idx := range big.Values {
+		big.Values[idx] = float64(idx)
+	}
+
+	testCases := []struct {
+		name     string
+		format   string
+		matrix   *Matrix
+		expected string
+	}{
+		{"Value", "%v", m, "[  1  -2.5  3]\n[400     5  6]"},
+		{"String", "%s", m, "[  1  -2.5  3]\n[400     5  6]"},
+		{"Precision", "%.2f", m, "[  1.00  -2.50  3.00]\n[400.00   5.00  6.00]"},
+		{"Significant digits", "%.2v", &Matrix{Values: []float64{3.14159, 1234}, Rows: 1, Columns: 2}, "[3.1  1.2e+03]"},
+		{"Exponent", "%.1e", &Matrix{Values: []float64{1, 20}, Rows: 2, Columns: 1}, "[1.0e+00]\n[2.0e+01]"},
+		{"Width", "%4v", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, "[   1     2]"},
+		{"Statistics", "%+v", m, "Matrix 2x3, min: -2.5, max: 400, mean: 68.75\n[  1  -2.5  3]\n[400     5  6]"},
+		{"View", "%v", &Matrix{Values: []float64{1, 2, 9, 3, 4}, Rows: 2, Columns: 2, stride: 3}, "[1  2]\n[3  4]"},
+		{"Truncated", "%v", big, "" +
+			"[  0    1    2  ...    8    9   10]\n" +
+			"[ 11   12   13  ...   19   20   21]\n" +
+			"[ 22   23   24  ...   30   31   32]\n" +
+			"[...  ...  ...  ...  ...  ...  ...]\n" +
+			"[ 99  100  101  ...  107  108  109]\n" +
+			"[110  111  112  ...  118  119  120]\n" +
+			"[121  122  123  ...  129  130  131]"},
+		{"Truncated statistics", "%+v", &Matrix{Values: make([]float64, 11), Rows: 11, Columns: 1},
+			"Matrix 11x1, min: 0, max: 0, mean: 0\n[  0]\n[  0]\n[  0]\n[...]\n[  0]\n[  0]\n[  0]"},
+		{"Not truncated", "% v", &Matrix{Values: make([]float64, 11), Rows: 11, Columns: 1}, "[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]"},
+		{"Not truncated statistics", "%+ v", &Matrix{Values: make([]float64, 11), Rows: 11, Columns: 1},
+			"Matrix 11x1, min: 0, max: 0, mean: 0\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]\n[0]"},
+		{"Go syntax", "%#v", m, "&matrix.Matrix{Values:[]float64{1, -2.5, 3, 400, 5, 6}, Rows:2, Columns:3}"},
+		{"Go syntax view", "%#v", &Matrix{Values: []float64{1, 2, 9, 3, 4}, Rows: 2, Columns: 2, stride: 3}, "&matrix.Matrix{Values:[]float64{1, 2, 3, 4}, Rows:2, Columns:2}"},
+		{"Go syntax nil", "%#v", (*Matrix)(nil), "(*matrix.Matrix)(nil)"},
+		{"Empty", "%+v", &Matrix{}, "Matrix 0x0\n[]"},
+		{"Nil", "%v", (*Matrix)(nil), "<nil>"},
+		{"Bad verb", "%d", m, "%!d(*matrix.Matrix=2x3)"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if s := fmt.Sprintf(tc.format, tc.matrix); s != tc.expected {
+				t.Errorf("Expected output is\n%s\nbut got\n%s", tc.expected, s)
+			}
+		})
+	}
+
+	t.Run("String", func(t *testing.T) {
+		t.Parallel()
+
+		if s := m.String(); s != fmt.Sprint(m) {
+			t.Errorf("Expected output is\n%s\nbut got\n%s", fmt.Sprint(m), s)
+		}
+	})
+}
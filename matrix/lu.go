@@ -0,0 +1,229 @@
+package matrix
+
+import "math"
+
+// LU represents the LU decomposition with partial pivoting of a square matrix "A", such that "P * A = L * U",
+// where "P" is a permutation matrix, "L" is a unit lower triangular matrix and "U" is an upper triangular matrix.
+type LU struct {
+	lu       *Matrix // L below the diagonal (without its unit diagonal) and U on and above the diagonal
+	pivots   []int   // The "i"-th row of "P * A" is the "pivots[i]"-th row of "A"
+	sign     float64 // The determinant of "P", "1" or "-1"
+	singular bool
+}
+
+// singularityTolerance is the relative size of a pivot, compared to the largest element of the matrix, below which the matrix is considered singular.
+const singularityTolerance = 1e-14
+
+// NewLU computes the LU decomposition of "a" with partial pivoting, "a" is left unchanged.
+// The decomposition of a singular matrix succeeds, but it can not be used to solve systems of equations.
+// It will return an error if "a == nil", or "a" is not square.
+func NewLU(aMat *Matrix) (*LU, error) {
+	if aMat == nil {
+		return nil, ErrNilMatrix
+	}
+
+	if aMat.Rows != aMat.Columns {
+		return nil, newShapeError("LU", ErrNotSquare, aMat.Rows, aMat.Rows, aMat.Rows, aMat.Columns)
+	}
+
+	n := aMat.Rows
+	lu, _ := Copy(aMat)
+	pivots := make([]int, n)
+	for idx := range pivots {
+		pivots[idx] = idx
+	}
+
+	max := 0.0
+	for _, v := range lu.Values {
+		max = math.Max(max, math.Abs(v))
+	}
+
+	d := &LU{lu, pivots, 1, max == 0}
+	tolerance := singularityTolerance * max * float64(n)
+	for k := 0; k < n; k++ {
+		p := k
+		for r := k + 1; r < n; r++ {
+			if math.Abs(lu.Values[r*n+k]) > math.Abs(lu.Values[p*n+k]) {
+				p = r
+			}
+		}
+
+		if p != k {
+			pRow, kRow := lu.row(p), lu.row(k)
+			for c := range kRow {
+				pRow[c], kRow[c] = kRow[c], pRow[c]
+			}
+
+			pivots[p], pivots[k] = pivots[k], pivots[p]
+			d.sign = -d.sign
+		}
+
+		pivot := lu.Values[k*n+k]
+		if math.Abs(pivot) <= tolerance {
+			d.singular = true
+			continue
+		}
+
+		kRow := lu.row(k)[k+1:]
+		for r := k + 1; r < n; r++ {
+			rRow := lu.row(r)
+			f := rRow[k] / pivot
+			rRow[k] = f
+			rRow = rRow[k+1:]
+			for c, v := range kRow {
+				rRow[c] -= f * v
+			}
+		}
+	}
+
+	return d, nil
+}
+
+// IsSingular reports whether the decomposed matrix is singular, or so close to singular that it cannot be inverted reliably.
+func (d *LU) IsSingular() bool {
+	return d.singular
+}
+
+// L returns a new Matrix holding the unit lower triangular factor of the decomposition.
+func (d *LU) L() *Matrix {
+	n := d.lu.Rows
+	l, _ := New(n, n, nil)
+	for r := 0; r < n; r++ {
+		copy(l.row(r)[:r], d.lu.row(r)[:r])
+		l.Values[r*n+r] = 1
+	}
+
+	return l
+}
+
+// U returns a new Matrix holding the upper triangular factor of the decomposition.
+func (d *LU) U() *Matrix {
+	n := d.lu.Rows
+	u, _ := New(n, n, nil)
+	for r := 0; r < n; r++ {
+		copy(u.row(r)[r:], d.lu.row(r)[r:])
+	}
+
+	return u
+}
+
+// P returns a new Matrix holding the permutation matrix of the decomposition.
+func (d *LU) P() *Matrix {
+	n := d.lu.Rows
+	p, _ := New(n, n, nil)
+	for r, pivot := range d.pivots {
+		p.Values[r*n+pivot] = 1
+	}
+
+	return p
+}
+
+// Pivots returns the row permutation of the decomposition, the "i"-th row of "P * A" is the "pivots[i]"-th row of "A".
+func (d *LU) Pivots() []int {
+	return append([]int(nil), d.pivots...)
+}
+
+// Det returns the determinant of the decomposed matrix.
+func (d *LU) Det() float64 {
+	det := d.sign
+	n := d.lu.Rows
+	for k := 0; k < n; k++ {
+		det *= d.lu.Values[k*n+k]
+	}
+
+	return det
+}
+
+// Solve solves the "A * X = B" system of equations, where "A" is the decomposed matrix, placing "X" in the receiver "m".
+// Every column of "b" is a separate right-hand side, so "X" has the same dimensions as "b".
+// The receiver may be "b".
+// It will return an error if "m == nil" or "b == nil", the number of rows in "b" is not the same as in "A", or "A" is singular.
+func (d *LU) Solve(m, bMat *Matrix) error {
+	if m == nil || bMat == nil {
+		return ErrNilMatrix
+	}
+
+	n := d.lu.Rows
+	if bMat.Rows != n {
+		return newShapeError("Solve", ErrBadSolveDimension, n, bMat.Columns, bMat.Rows, bMat.Columns)
+	}
+
+	if d.singular {
+		return ErrSingularMatrix
+	}
+
+	// The rows of "b" are permuted into a new matrix, so the substitutions can work in-place.
+	x, _ := New(n, bMat.Columns, nil)
+	for r, pivot := range d.pivots {
+		copy(x.row(r), bMat.row(pivot))
+	}
+
+	// Forward substitution with the unit lower triangular "L".
+	for r := 1; r < n; r++ {
+		xRow := x.row(r)
+		for k, f := range d.lu.row(r)[:r] {
+			for c, v := range x.row(k) {
+				xRow[c] -= f * v
+			}
+		}
+	}
+
+	// Backward substitution with the upper triangular "U".
+	for r := n - 1; r >= 0; r-- {
+		xRow, luRow := x.row(r), d.lu.row(r)
+		for k := r + 1; k < n; k++ {
+			f := luRow[k]
+			for c, v := range x.row(k) {
+				xRow[c] -= f * v
+			}
+		}
+
+		pivot := luRow[r]
+		for c := range xRow {
+			xRow[c] /= pivot
+		}
+	}
+
+	return m.CopyFrom(x)
+}
+
+// Det returns the determinant of the matrix.
+// It will return an error if the matrix is not square.
+func (m *Matrix) Det() (float64, error) {
+	d, err := NewLU(m)
+	if err != nil {
+		return 0, err
+	}
+
+	return d.Det(), nil
+}
+
+// Inverse calculates the inverse of "a", placing the result in the receiver.
+// The receiver may be the operand.
+// It will return an error if "a == nil", "a" is not square, or "a" is singular.
+func (m *Matrix) Inverse(aMat *Matrix) error {
+	d, err := NewLU(aMat)
+	if err != nil {
+		return err
+	}
+
+	i, _ := Identity(aMat.Rows)
+	return d.Solve(m, i)
+}
+
+// Solve solves the "A * X = B" system of equations, placing "X" in the receiver.
+// Every column of "b" is a separate right-hand side, so the result has the same dimensions as "b".
+// The receiver may be one of the operands.
+// It will return an error if "a == nil" or "b == nil", "a" is not square, the number of rows in "a" and "b" are different, or "a" is singular.
+func (m *Matrix) Solve(aMat, bMat *Matrix) error {
+	if bMat == nil {
+		return ErrNilMatrix
+	}
+
+	d, err := NewLU(aMat)
+	if err != nil {
+		return err
+	}
+
+	return d.Solve(m, bMat)
+}
@@ -0,0 +1,213 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func isApproxEqual(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for idx := range a {
+		if math.Abs(a[idx]-b[idx]) > tol {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestIdentity(t *testing.T) {
+	m, err := Identity(3)
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	expected := []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}
+	if !isApproxEqual(m.Values, expected, 0) {
+		t.Errorf("Expected values are %v, but got %v", expected, m.Values)
+	}
+
+	if _, err := Identity(0); err != ErrZeroRow {
+		t.Errorf("Expected error is %v, but got %v", ErrZeroRow, err)
+	}
+}
+
+func TestNewLU(t *testing.T) {
+	testCases := []struct {
+		name             string
+		matrix           *Matrix
+		expectedSingular bool
+		expectedError    error
+	}{
+		{"Normal", &Matrix{Values: []float64{2, 1, 1, 4, -6, 0, -2, 7, 2}, Rows: 3, Columns: 3}, false, nil},
+		{"Needs pivoting", &Matrix{Values: []float64{0, 1, 1, 0}, Rows: 2, Columns: 2}, false, nil},
+		{"Singular", &Matrix{Values: []float64{1, 2, 2, 4}, Rows: 2, Columns: 2}, true, nil},
+		{"Zero", &Matrix{Values: []float64{0, 0, 0, 0}, Rows: 2, Columns: 2}, true, nil},
+		{"ErrNotSquare", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, false, ErrNotSquare},
+		{"ErrNilMatrix", nil, false, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := NewLU(tc.matrix)
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if d.IsSingular() != tc.expectedSingular {
+					t.Errorf("Expected singularity is %v, but got %v", tc.expectedSingular, d.IsSingular())
+				}
+
+				pa, lu := &Matrix{}, &Matrix{}
+				pa.Product(d.P(), tc.matrix)
+				lu.Product(d.L(), d.U())
+				if !isApproxEqual(pa.Values, lu.Values, 1e-12) {
+					t.Errorf("Expected P * A to be %v, but L * U is %v", pa.Values, lu.Values)
+				}
+			}
+		})
+	}
+}
+
+func TestDet(t *testing.T) {
+	testCases := []struct {
+		name          string
+		matrix        *Matrix
+		expectedDet   float64
+		expectedError error
+	}{
+		{"Normal", &Matrix{Values: []float64{2, 1, 1, 4, -6, 0, -2, 7, 2}, Rows: 3, Columns: 3}, -16, nil},
+		{"Odd permutation", &Matrix{Values: []float64{0, 1, 1, 0}, Rows: 2, Columns: 2}, -1, nil},
+		{"Singular", &Matrix{Values: []float64{1, 2, 2, 4}, Rows: 2, Columns: 2}, 0, nil},
+		{"ErrNotSquare", &Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1}, 0, ErrNotSquare},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			det, err := tc.matrix.Det()
+			if !errors.Is(err, tc.expectedError) {
+				t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+			} else if math.Abs(det-tc.expectedDet) > 1e-12 {
+				t.Errorf("Expected determinant is %f, but got %f", tc.expectedDet, det)
+			}
+		})
+	}
+}
+
+func TestInverse(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{4, 7, 2, 6}, Rows: 2, Columns: 2}, []float64{0.6, -0.7, -0.2, 0.4}, nil},
+		{"Needs pivoting", &Matrix{Values: []float64{0, 2, 4, 0}, Rows: 2, Columns: 2}, []float64{0, 0.25, 0.5, 0}, nil},
+		{"ErrSingularMatrix", &Matrix{Values: []float64{1, 2, 2, 4}, Rows: 2, Columns: 2}, nil, ErrSingularMatrix},
+		{"ErrNotSquare", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, ErrNotSquare},
+		{"ErrNilMatrix", nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Inverse(tc.matrix)
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(m.Values, tc.expectedValues, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+			}
+		})
+	}
+
+	t.Run("Random", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(0))
+		for _, n := range []int{1, 3, 10, 50} {
+			aMat := randomMatrix(r, n, n)
+			inv, product := &Matrix{}, &Matrix{}
+			if err := inv.Inverse(aMat); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			product.Product(aMat, inv)
+			i, _ := Identity(n)
+			if !isApproxEqual(product.Values, i.Values, 1e-9) {
+				t.Errorf("Expected A * A^-1 to be the %dx%d identity, but got %v", n, n, product.Values)
+			}
+		}
+	})
+
+	t.Run("In-place", func(t *testing.T) {
+		t.Parallel()
+
+		m := &Matrix{Values: []float64{4, 7, 2, 6}, Rows: 2, Columns: 2}
+		if err := m.Inverse(m); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		expected := []float64{0.6, -0.7, -0.2, 0.4}
+		if !isApproxEqual(m.Values, expected, 1e-12) {
+			t.Errorf("Expected values are %v, but got %v", expected, m.Values)
+		}
+	})
+}
+
+func TestSolve(t *testing.T) {
+	aMat := &Matrix{Values: []float64{2, 1, 1, 4, -6, 0, -2, 7, 2}, Rows: 3, Columns: 3}
+	testCases := []struct {
+		name           string
+		a, b           *Matrix
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", aMat, &Matrix{Values: []float64{5, -2, 9}, Rows: 3, Columns: 1}, []float64{1, 1, 2}, nil},
+		{"Multiple right-hand sides", aMat, &Matrix{Values: []float64{5, 2, -2, -2, 9, 7}, Rows: 3, Columns: 2}, []float64{1, -0.5, 1, 0, 2, 3}, nil},
+		{"ErrBadSolveDimension", aMat, &Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1}, nil, ErrBadSolveDimension},
+		{"ErrSingularMatrix", &Matrix{Values: []float64{1, 2, 2, 4}, Rows: 2, Columns: 2}, &Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1}, nil, ErrSingularMatrix},
+		{"ErrNotSquare", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1}, Rows: 1, Columns: 1}, nil, ErrNotSquare},
+		{"ErrNilMatrix a", nil, &Matrix{Values: []float64{1}, Rows: 1, Columns: 1}, nil, ErrNilMatrix},
+		{"ErrNilMatrix b", aMat, nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Solve(tc.a, tc.b)
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(m.Values, tc.expectedValues, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+			}
+		})
+	}
+}
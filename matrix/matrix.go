@@ -1,14 +1,27 @@
 package matrix
 
+import "math"
+
 // Matrix represents a mathematical Matrix.
+// A Matrix can also be a view of an other Matrix (see Row, Col and Slice), in that case the elements are shared with the parent,
+// and the consecutive rows are "Stride()" elements apart from each other in Values.
 type Matrix struct {
 	Values  []float64 // Values of the matrix
 	Rows    int       // Number of rows
 	Columns int       // Number of columns
+
+	stride  int     // Distance between the first elements of two consecutive rows, zero for non-view matrices
+	backend Backend // The backend of the operations that place their result in the matrix, nil for the global backend
 }
 
-// ApplyFn represents a function that is applied to the matrix when Apply is called
-type ApplyFn func(value float64, idx int, self []float64) float64
+// ApplyFn represents a function that is applied to each element of the matrix independently when Apply is called.
+type ApplyFn func(value float64) float64
+
+// VectorFn represents a function that is applied to the whole matrix at once when ApplyVector is called,
+// it is meant for operations where the new value of an element depends on the other elements too (e.g. softmax).
+// The function must write the result into "dst", which has the same dimensions as "src", and which may be the same matrix as "src".
+// The Values of both "dst" and "src" are always stored contiguously in row-major order.
+type VectorFn func(dst, src *Matrix)
 
 // New creates a new Matrix with "r" rows and "c" columns, the "vals" must be arranged in row-major order.
 // If "vals == nil", a new slice will be allocated with "r * c" size.
@@ -35,12 +48,13 @@ func New(r, c int, vals []float64) (*Matrix, error) {
 		return nil, ErrDataLength
 	}
 
-	m := &Matrix{vals, r, c}
+	m := &Matrix{Values: vals, Rows: r, Columns: c}
 
 	return m, nil
 }
 
 // Copy creates a new Matrix with the same properties as the given Matrix.
+// If "m" is a view the elements are copied into a new, non-view Matrix.
 // It will return an error if "m == nil".
 func Copy(m *Matrix) (*Matrix, error) {
 	if m == nil {
@@ -48,41 +62,234 @@ func Copy(m *Matrix) (*Matrix, error) {
 	}
 
 	vals := make([]float64, m.Rows*m.Columns)
-	nMat := &Matrix{vals, m.Rows, m.Columns}
-	copy(nMat.Values, m.Values)
+	nMat := &Matrix{Values: vals, Rows: m.Rows, Columns: m.Columns, backend: m.backend}
+	if m.contiguous() {
+		copy(nMat.Values, m.Values)
+	} else {
+		for r := 0; r < m.Rows; r++ {
+			copy(nMat.row(r), m.row(r))
+		}
+	}
+
 	return nMat, nil
 }
 
-// Add adds "aMat" and "bMat" element-wise, placing the result in the receiver.
-// It will return an error if the two matrices do not hase the same dimensions.
-// It will also return an error if "aMat == nil" or "bMat == nil".
-func (m *Matrix) Add(aMat, bMat *Matrix) error {
-	if aMat == nil || bMat == nil {
+// Identity creates a new "n x n" identity Matrix.
+// It will return an error if "n <= 0".
+func Identity(n int) (*Matrix, error) {
+	m, err := New(n, n, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := 0; idx < n; idx++ {
+		m.Values[idx*n+idx] = 1
+	}
+
+	return m, nil
+}
+
+// Stride returns the distance between the first elements of two consecutive rows in Values.
+func (m *Matrix) Stride() int {
+	if m.stride == 0 {
+		return m.Columns
+	}
+
+	return m.stride
+}
+
+// IsView reports whether the matrix shares its elements with a parent matrix.
+func (m *Matrix) IsView() bool {
+	return m.stride != 0
+}
+
+// contiguous reports whether the elements of the matrix are stored in row-major order without gaps.
+func (m *Matrix) contiguous() bool {
+	return m.stride == 0 || m.stride == m.Columns || m.Rows == 1
+}
+
+// row returns the elements of the "r"-th row.
+func (m *Matrix) row(r int) []float64 {
+	offset := r * m.Stride()
+	return m.Values[offset : offset+m.Columns]
+}
+
+// reuse sets the dimensions of the receiver to "r" rows and "c" columns.
+// The underlying slice is reused when its capacity is at least "r * c", otherwise a new one is allocated.
+// The content of the reused slice is left untouched, so every kernel has to overwrite all of the elements.
+// It will return an error if the receiver is a view and its dimensions would change.
+func (m *Matrix) reuse(r, c int) error {
+	if m.stride != 0 {
+		if m.Rows != r || m.Columns != c {
+			return newShapeError("", ErrViewDimensions, m.Rows, m.Columns, r, c)
+		}
+
+		return nil
+	}
+
+	m.Rows, m.Columns = r, c
+	if cap(m.Values) < r*c {
+		m.Values = make([]float64, r*c)
+	} else {
+		m.Values = m.Values[:r*c]
+	}
+
+	return nil
+}
+
+// aliases reports whether the backing arrays of "a" and "b" overlap.
+// Two slices that share a backing array also share the address of its last element,
+// so the overlap can be decided from the distance of each slice from the end of the array.
+func aliases(a, b []float64) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return false
+	}
+
+	ca, cb := a[:cap(a)], b[:cap(b)]
+	if &ca[len(ca)-1] != &cb[len(cb)-1] {
+		return false
+	}
+
+	aStart, aEnd := cap(a), cap(a)-len(a)
+	bStart, bEnd := cap(b), cap(b)-len(b)
+	return aEnd < bStart && bEnd < aStart
+}
+
+// operand returns "aMat" when it is safe to read it while the receiver is written element by element,
+// which is the case when the two matrices do not overlap or they have exactly the same layout and dimensions, otherwise it returns a copy of "aMat".
+func (m *Matrix) operand(aMat *Matrix) *Matrix {
+	if !aliases(m.Values, aMat.Values) {
+		return aMat
+	}
+
+	if &m.Values[0] == &aMat.Values[0] && m.Stride() == aMat.Stride() && m.Rows == aMat.Rows && m.Columns == aMat.Columns {
+		return aMat
+	}
+
+	cMat, _ := Copy(aMat)
+	return cMat
+}
+
+// unary calls "kernel" with the rows of the receiver and "aMat", or with all the elements at once when both are contiguous.
+// The receiver must already have the same dimensions as "aMat".
+func (m *Matrix) unary(aMat *Matrix, kernel func(dst, a []float64)) {
+	aMat = m.operand(aMat)
+	if m.contiguous() && aMat.contiguous() {
+		n := m.Rows * m.Columns
+		kernel(m.Values[:n], aMat.Values[:n])
+		return
+	}
+
+	for r := 0; r < m.Rows; r++ {
+		kernel(m.row(r), aMat.row(r))
+	}
+}
+
+// mapUnary checks "aMat", resizes the receiver to its dimensions and calls "kernel" the same way as unary.
+func (m *Matrix) mapUnary(aMat *Matrix, kernel func(dst, a []float64)) error {
+	if aMat == nil {
 		return ErrNilMatrix
 	}
 
-	aRows, aCols, bRows, bCols := aMat.Rows, aMat.Columns, bMat.Rows, bMat.Columns
-	if aRows != bRows || aCols != bCols {
-		return ErrDifferentDimensions
+	if err := m.reuse(aMat.Rows, aMat.Columns); err != nil {
+		return err
 	}
 
-	aVals, bVals := make([]float64, aRows*aCols), make([]float64, bRows*bCols)
-	copy(aVals, aMat.Values)
-	copy(bVals, bMat.Values)
+	m.unary(aMat, kernel)
+
+	return nil
+}
 
-	m.Rows = aRows
-	m.Columns = aCols
-	m.Values = make([]float64, m.Rows*m.Columns)
+// binary calls "kernel" with the rows of the receiver, "aMat" and "bMat", or with all the elements at once when all of them are contiguous.
+// The receiver must already have the same dimensions as the operands.
+func (m *Matrix) binary(aMat, bMat *Matrix, kernel func(dst, a, b []float64)) {
+	aMat, bMat = m.operand(aMat), m.operand(bMat)
+	if m.contiguous() && aMat.contiguous() && bMat.contiguous() {
+		n := m.Rows * m.Columns
+		kernel(m.Values[:n], aMat.Values[:n], bMat.Values[:n])
+		return
+	}
 
-	for idx := range m.Values {
-		m.Values[idx] = aVals[idx] + bVals[idx]
+	for r := 0; r < m.Rows; r++ {
+		kernel(m.row(r), aMat.row(r), bMat.row(r))
 	}
+}
+
+// SetValues sets the receiver to a Matrix with "r" rows and "c" columns holding a copy of "vals", which must be arranged in row-major order.
+// The underlying slice of the receiver is reused when it is large enough.
+// It will return an error if "r <= 0", "c <= 0" or the length of "vals" is not "r * c".
+// It will also return an error if the receiver is a view with different dimensions.
+func (m *Matrix) SetValues(r, c int, vals []float64) error {
+	if r <= 0 {
+		return ErrZeroRow
+	}
+
+	if c <= 0 {
+		return ErrZeroCol
+	}
+
+	if len(vals) != r*c {
+		return ErrDataLength
+	}
+
+	if err := m.reuse(r, c); err != nil {
+		return err
+	}
+
+	m.unary(&Matrix{Values: vals, Rows: r, Columns: c}, func(dst, a []float64) {
+		copy(dst, a)
+	})
 
 	return nil
 }
 
+// CopyFrom copies the dimensions and the elements of "aMat" into the receiver.
+// The underlying slice of the receiver is reused when it is large enough.
+// It will return an error if "aMat == nil", or the receiver is a view with different dimensions.
+func (m *Matrix) CopyFrom(aMat *Matrix) error {
+	if aMat == nil {
+		return ErrNilMatrix
+	}
+
+	if m == aMat {
+		return nil
+	}
+
+	if err := m.reuse(aMat.Rows, aMat.Columns); err != nil {
+		return err
+	}
+
+	m.unary(aMat, func(dst, a []float64) {
+		copy(dst, a)
+	})
+
+	return nil
+}
+
+// Abs calculates the absolute value of each element of "a", placing the result in the receiver.
+// The receiver may be the operand.
+// It will return an error if "a == nil".
+func (m *Matrix) Abs(aMat *Matrix) error {
+	return m.mapUnary(aMat, func(dst, a []float64) {
+		a = a[:len(dst)]
+		for idx := range dst {
+			dst[idx] = math.Abs(a[idx])
+		}
+	})
+}
+
+// Add adds "aMat" and "bMat" element-wise, placing the result in the receiver.
+// The operands are broadcast together (see BroadcastDimensions), e.g. a column vector can be added to every column of a matrix.
+// The receiver may be one of the operands.
+// It will return an error if the dimensions of the two matrices cannot be broadcast together.
+// It will also return an error if "aMat == nil" or "bMat == nil".
+func (m *Matrix) Add(aMat, bMat *Matrix) error {
+	return m.elementWise(aMat, bMat, m.Backend().Add)
+}
+
 // Apply applies the function "fn" to each of the elements of "a", placing the resulting matrix in the receiver.
-// The function "fn" takes the value of the element, the index of the row and the column, and it returns a new value for that element.
+// The function "fn" takes the value of an element, and it returns the new value for that element.
+// The receiver may be the operand.
 // It will return an error if "fn == nil" or "a == nil".
 func (m *Matrix) Apply(fn ApplyFn, aMat *Matrix) error {
 	if fn == nil {
@@ -93,16 +300,48 @@ func (m *Matrix) Apply(fn ApplyFn, aMat *Matrix) error {
 		return ErrNilMatrix
 	}
 
-	aRows, aCols := aMat.Rows, aMat.Columns
-	aVals := make([]float64, aRows*aCols)
-	copy(aVals, aMat.Values)
+	if err := m.reuse(aMat.Rows, aMat.Columns); err != nil {
+		return err
+	}
+
+	b := m.Backend()
+	m.unary(aMat, func(dst, a []float64) {
+		b.Apply(dst, a, fn)
+	})
+
+	return nil
+}
+
+// ApplyVector applies the function "fn" to the whole "a" matrix, placing the resulting matrix in the receiver.
+// The receiver may be the operand.
+// If the receiver or "a" is a non-contiguous view, "fn" is called with contiguous copies of them.
+// It will return an error if "fn == nil" or "a == nil".
+func (m *Matrix) ApplyVector(fn VectorFn, aMat *Matrix) error {
+	if fn == nil {
+		return ErrNilFunction
+	}
+
+	if aMat == nil {
+		return ErrNilMatrix
+	}
 
-	m.Rows, m.Columns = aRows, aCols
-	m.Values = make([]float64, m.Rows*m.Columns)
+	if err := m.reuse(aMat.Rows, aMat.Columns); err != nil {
+		return err
+	}
+
+	src, dst := aMat, m
+	if !aMat.contiguous() || (m != aMat && aliases(m.Values, aMat.Values)) {
+		src, _ = Copy(aMat)
+	}
+
+	if !m.contiguous() {
+		dst, _ = New(m.Rows, m.Columns, nil)
+	}
+
+	fn(dst, src)
 
-	for idx := range m.Values {
-		s := append(m.Values[:idx], aVals[idx:]...)
-		m.Values[idx] = fn(aVals[idx], idx, s)
+	if dst != m {
+		m.CopyFrom(dst)
 	}
 
 	return nil
@@ -112,46 +351,123 @@ func (m *Matrix) Apply(fn ApplyFn, aMat *Matrix) error {
 // Indexing is zero-based, so the first row will be at "0" and the last row will be at "numberOfRows - 1" same for the columns.
 // It will return an error if "r" bigger than the number of rows or "c" is bigger than the number columns.
 func (m *Matrix) At(r, c int) (float64, error) {
-	if r > m.Rows-1 {
+	if r < 0 || r > m.Rows-1 {
 		return 0, ErrRowOutOfBounds
 	}
 
-	if c > m.Columns-1 {
+	if c < 0 || c > m.Columns-1 {
 		return 0, ErrColOutOfBounds
 	}
 
-	return m.Values[r*m.Columns+c], nil
+	return m.Values[r*m.Stride()+c], nil
 }
 
-// Multiply performs element-wise multiplication of "a" and "b", placing the result in the receiver.
-// It will return an error if the two matrices does not hase the same dimensions.
-// It will also return an error if "b == nil" or "a == nil".
-func (m *Matrix) Multiply(aMat, bMat *Matrix) error {
-	if aMat == nil || bMat == nil {
-		return ErrNilMatrix
+// Set sets the element at row "r", column "c" to "v", if the receiver is a view the change is reflected in the parent matrix.
+// Indexing is zero-based, the same way as in At.
+// It will return an error if "r" bigger than the number of rows or "c" is bigger than the number columns.
+func (m *Matrix) Set(r, c int, v float64) error {
+	if r < 0 || r > m.Rows-1 {
+		return ErrRowOutOfBounds
 	}
 
-	aRows, aCols, bRows, bCols := aMat.Rows, aMat.Columns, bMat.Rows, bMat.Columns
-	if aRows != bRows || aCols != bCols {
-		return ErrDifferentDimensions
+	if c < 0 || c > m.Columns-1 {
+		return ErrColOutOfBounds
 	}
 
-	aVals, bVals := make([]float64, aRows*aCols), make([]float64, bRows*bCols)
-	copy(aVals, aMat.Values)
-	copy(bVals, bMat.Values)
+	m.Values[r*m.Stride()+c] = v
 
-	m.Rows = aRows
-	m.Columns = aCols
-	m.Values = make([]float64, m.Rows*m.Columns)
+	return nil
+}
 
-	for idx := range m.Values {
-		m.Values[idx] = aVals[idx] * bVals[idx]
+// Clip limits the elements of "a" to the "[min, max]" interval, placing the result in the receiver.
+// NaN elements remain NaN.
+// The receiver may be the operand.
+// It will return an error if "a == nil", or "min > max".
+func (m *Matrix) Clip(min, max float64, aMat *Matrix) error {
+	if min > max {
+		return ErrClipRange
 	}
 
-	return nil
+	return m.mapUnary(aMat, func(dst, a []float64) {
+		a = a[:len(dst)]
+		for idx := range dst {
+			v := a[idx]
+			if v < min {
+				v = min
+			} else if v > max {
+				v = max
+			}
+
+			dst[idx] = v
+		}
+	})
+}
+
+// Exp calculates "e**x" for each element "x" of "a", placing the result in the receiver.
+// The receiver may be the operand.
+// It will return an error if "a == nil".
+func (m *Matrix) Exp(aMat *Matrix) error {
+	return m.mapUnary(aMat, func(dst, a []float64) {
+		a = a[:len(dst)]
+		for idx := range dst {
+			dst[idx] = math.Exp(a[idx])
+		}
+	})
+}
+
+// Log calculates the natural logarithm of each element of "a", placing the result in the receiver.
+// The logarithm of zero is "-Inf", and the logarithm of a negative element is NaN.
+// The receiver may be the operand.
+// It will return an error if "a == nil".
+func (m *Matrix) Log(aMat *Matrix) error {
+	return m.mapUnary(aMat, func(dst, a []float64) {
+		a = a[:len(dst)]
+		for idx := range dst {
+			dst[idx] = math.Log(a[idx])
+		}
+	})
+}
+
+// Multiply performs element-wise multiplication of "a" and "b", placing the result in the receiver.
+// The operands are broadcast together the same way as in Add.
+// The receiver may be one of the operands.
+// It will return an error if the dimensions of the two matrices cannot be broadcast together.
+// It will also return an error if "b == nil" or "a == nil".
+func (m *Matrix) Multiply(aMat, bMat *Matrix) error {
+	return m.elementWise(aMat, bMat, m.Backend().Multiply)
+}
+
+// Divide performs element-wise division of "a" and "b", placing the result in the receiver, in the order of "a / b".
+// The operands are broadcast together the same way as in Add, division by zero results in an infinity or NaN element.
+// The receiver may be one of the operands.
+// It will return an error if the dimensions of the two matrices cannot be broadcast together.
+// It will also return an error if "b == nil" or "a == nil".
+func (m *Matrix) Divide(aMat, bMat *Matrix) error {
+	return m.elementWise(aMat, bMat, m.Backend().Divide)
+}
+
+// Pow raises each element of "a" to the power of "p", placing the result in the receiver.
+// The special cases are the same as in math.Pow, the squares are calculated by a multiplication.
+// The receiver may be the operand.
+// It will return an error if "a == nil".
+func (m *Matrix) Pow(p float64, aMat *Matrix) error {
+	return m.mapUnary(aMat, func(dst, a []float64) {
+		a = a[:len(dst)]
+		switch p {
+		case 2:
+			for idx := range dst {
+				dst[idx] = a[idx] * a[idx]
+			}
+		default:
+			for idx := range dst {
+				dst[idx] = math.Pow(a[idx], p)
+			}
+		}
+	})
 }
 
 // Product performs matrix multiplication of "a" and "b", placing the result in the receiver.
+// The receiver may be one of the operands, in that case the result is calculated in a temporary matrix first.
 // It will return an error if the number of columns in "a" not equal with the number of rows in "b".
 // It will also return an error if "b == nil" or "a == nil".
 func (m *Matrix) Product(aMat, bMat *Matrix) error {
@@ -159,78 +475,74 @@ func (m *Matrix) Product(aMat, bMat *Matrix) error {
 		return ErrNilMatrix
 	}
 
-	aRows, aCols, bRows, bCols := aMat.Rows, aMat.Columns, bMat.Rows, bMat.Columns
-	if aCols != bRows {
-		return ErrBadProductDimension
+	if aMat.Columns != bMat.Rows {
+		return newShapeError("Product", ErrBadProductDimension, aMat.Columns, bMat.Columns, bMat.Rows, bMat.Columns)
 	}
 
-	aVals, bVals := make([]float64, aRows*aCols), make([]float64, bRows*bCols)
-	copy(aVals, aMat.Values)
-	copy(bVals, bMat.Values)
+	if aliases(m.Values, aMat.Values) || aliases(m.Values, bMat.Values) {
+		if m.IsView() && (m.Rows != aMat.Rows || m.Columns != bMat.Columns) {
+			return newShapeError("Product", ErrViewDimensions, m.Rows, m.Columns, aMat.Rows, bMat.Columns)
+		}
 
-	m.Rows = aRows
-	m.Columns = bCols
-	m.Values = make([]float64, m.Rows*m.Columns)
+		tmp := &Matrix{}
+		tmp.reuse(aMat.Rows, bMat.Columns)
+		m.Backend().Product(tmp, aMat, bMat)
+		return m.CopyFrom(tmp)
+	}
 
-	for mIdx := range m.Values {
-		for bIdx := 0; bIdx < bRows; bIdx++ {
-			m.Values[mIdx] += aVals[((mIdx/bCols)*aCols)+bIdx] * bVals[(bIdx*bCols)+(mIdx%bCols)]
-		}
+	if err := m.reuse(aMat.Rows, bMat.Columns); err != nil {
+		return err
 	}
 
+	m.Backend().Product(m, aMat, bMat)
+
 	return nil
 }
 
 // Scale multiplies the elements of "a" by "s", placing the result in the receiver.
+// The receiver may be the operand.
 // It will return an error if "a == nil".
 func (m *Matrix) Scale(s float64, aMat *Matrix) error {
 	if aMat == nil {
 		return ErrNilMatrix
 	}
 
-	aRows, aCols := aMat.Rows, aMat.Columns
-	aVals := make([]float64, aRows*aCols)
-	copy(aVals, aMat.Values)
-
-	m.Rows, m.Columns = aRows, aCols
-	m.Values = make([]float64, m.Rows*m.Columns)
-
-	for idx := range m.Values {
-		m.Values[idx] = s * aVals[idx]
+	if err := m.reuse(aMat.Rows, aMat.Columns); err != nil {
+		return err
 	}
 
+	b := m.Backend()
+	m.unary(aMat, func(dst, a []float64) {
+		b.Scale(dst, s, a)
+	})
+
 	return nil
 }
 
+// Sqrt calculates the square root of each element of "a", placing the result in the receiver.
+// The square root of a negative element is NaN.
+// The receiver may be the operand.
+// It will return an error if "a == nil".
+func (m *Matrix) Sqrt(aMat *Matrix) error {
+	return m.mapUnary(aMat, func(dst, a []float64) {
+		a = a[:len(dst)]
+		for idx := range dst {
+			dst[idx] = math.Sqrt(a[idx])
+		}
+	})
+}
+
 // Subtract subtracts "a" and "b" element-wise, placing the result in the receiver, in the order of "a - b"
-// It will return an error if the two matrices do not hase the same dimensions.
+// The operands are broadcast together the same way as in Add.
+// The receiver may be one of the operands.
+// It will return an error if the dimensions of the two matrices cannot be broadcast together.
 // It will also return an error if "b == nil" or "a == nil".
 func (m *Matrix) Subtract(aMat, bMat *Matrix) error {
-	if aMat == nil || bMat == nil {
-		return ErrNilMatrix
-	}
-
-	aRows, aCols, bRows, bCols := aMat.Rows, aMat.Columns, bMat.Rows, bMat.Columns
-	if aRows != bRows || aCols != bCols {
-		return ErrDifferentDimensions
-	}
-
-	aVals, bVals := make([]float64, aRows*aCols), make([]float64, bRows*bCols)
-	copy(aVals, aMat.Values)
-	copy(bVals, bMat.Values)
-
-	m.Rows = aRows
-	m.Columns = aCols
-	m.Values = make([]float64, m.Rows*m.Columns)
-
-	for idx := range m.Values {
-		m.Values[idx] = aVals[idx] - bVals[idx]
-	}
-
-	return nil
+	return m.elementWise(aMat, bMat, m.Backend().Subtract)
 }
 
 // Transpose switches the row and column indices of the matrix, placing the result in the receiver.
+// The receiver may be the operand, a temporary matrix is only allocated when a non-square matrix with more than one row and column is transposed in-place.
 // It will return an error if "a == nil".
 func (m *Matrix) Transpose(aMat *Matrix) error {
 	if aMat == nil {
@@ -238,14 +550,36 @@ func (m *Matrix) Transpose(aMat *Matrix) error {
 	}
 
 	aRows, aCols := aMat.Rows, aMat.Columns
-	aVals := make([]float64, aRows*aCols)
-	copy(aVals, aMat.Values)
+	if m == aMat {
+		switch {
+		case !m.IsView() && (aRows == 1 || aCols == 1):
+			m.Rows, m.Columns = aCols, aRows
+			return nil
+		case aRows == aCols:
+			stride := m.Stride()
+			for r := 0; r < aRows; r++ {
+				for c := r + 1; c < aCols; c++ {
+					m.Values[r*stride+c], m.Values[c*stride+r] = m.Values[c*stride+r], m.Values[r*stride+c]
+				}
+			}
+			return nil
+		}
+	}
 
-	m.Rows, m.Columns = aCols, aRows
-	m.Values = make([]float64, m.Rows*m.Columns)
+	if aliases(m.Values, aMat.Values) {
+		aMat, _ = Copy(aMat)
+	}
 
-	for idx := range m.Values {
-		m.Values[idx] = aVals[(idx%aCols*aRows)+(idx/aCols)]
+	if err := m.reuse(aCols, aRows); err != nil {
+		return err
+	}
+
+	aStride := aMat.Stride()
+	for r := 0; r < m.Rows; r++ {
+		mRow := m.row(r)
+		for c := range mRow {
+			mRow[c] = aMat.Values[c*aStride+r]
+		}
 	}
 
 	return nil
@@ -1,7 +1,9 @@
 package matrix
 
 import (
+	"math"
 	"math/rand"
+	"runtime"
 	"testing"
 )
 
@@ -9,6 +11,7 @@ func BenchmarkNew(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	vals := []float64{r.Float64()}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		New(b.N, 1, vals)
@@ -18,6 +21,7 @@ func BenchmarkNew(b *testing.B) {
 func BenchmarkNew_zeros(b *testing.B) {
 	vals := make([]float64, 1)
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		New(b.N, 1, vals)
@@ -27,8 +31,9 @@ func BenchmarkNew_zeros(b *testing.B) {
 func BenchmarkCopy(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	vals := []float64{r.Float64()}
-	mat := &Matrix{vals, 1, 1}
+	mat := &Matrix{Values: vals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		Copy(mat)
@@ -39,9 +44,10 @@ func BenchmarkAdd(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	aVals := []float64{r.Float64()}
 	bVals := []float64{r.Float64()}
-	aMat := &Matrix{aVals, 1, 1}
-	bMat := &Matrix{bVals, 1, 1}
+	aMat := &Matrix{Values: aVals, Rows: 1, Columns: 1}
+	bMat := &Matrix{Values: bVals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		aMat.Add(aMat, bMat)
@@ -51,34 +57,140 @@ func BenchmarkAdd(b *testing.B) {
 func BenchmarkApply(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	vals := []float64{r.Float64()}
-	mat := &Matrix{vals, 1, 1}
+	mat := &Matrix{Values: vals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		mat.Apply(func(v float64, _ int, _ []float64) float64 {
+		mat.Apply(func(v float64) float64 {
 			return v
 		}, mat)
 	}
 }
 
+func benchmarkApplySize(b *testing.B, n int) {
+	r := rand.New(rand.NewSource(0))
+	mat, out := randomMatrix(r, n, 1), &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		out.Apply(math.Exp, mat)
+	}
+}
+
+func BenchmarkApply_100(b *testing.B)   { benchmarkApplySize(b, 100) }
+func BenchmarkApply_1000(b *testing.B)  { benchmarkApplySize(b, 1000) }
+func BenchmarkApply_10000(b *testing.B) { benchmarkApplySize(b, 10000) }
+
+func BenchmarkApplyVector(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	vals := []float64{r.Float64()}
+	mat := &Matrix{Values: vals, Rows: 1, Columns: 1}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.ApplyVector(func(dst, src *Matrix) {
+			dst.Scale(1, src)
+		}, mat)
+	}
+}
+
 func BenchmarkAt(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	vals := []float64{r.Float64()}
-	mat := &Matrix{vals, 1, 1}
+	mat := &Matrix{Values: vals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		mat.At(i, 1)
 	}
 }
 
+func BenchmarkAbs_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 32)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Abs(aMat)
+	}
+}
+
+func BenchmarkClip_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 32)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Clip(-0.5, 0.5, aMat)
+	}
+}
+
+func BenchmarkDivide(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aVals := []float64{r.Float64()}
+	bVals := []float64{r.Float64()}
+	aMat := &Matrix{Values: aVals, Rows: 1, Columns: 1}
+	bMat := &Matrix{Values: bVals, Rows: 1, Columns: 1}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		aMat.Divide(aMat, bMat)
+	}
+}
+
+func BenchmarkExp_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 32)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Exp(aMat)
+	}
+}
+
+func BenchmarkExp_apply_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 32)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Apply(math.Exp, aMat)
+	}
+}
+
+func BenchmarkLog_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 32)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Log(aMat)
+	}
+}
+
 func BenchmarkMultiply(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	aVals := []float64{r.Float64()}
 	bVals := []float64{r.Float64()}
-	aMat := &Matrix{aVals, 1, 1}
-	bMat := &Matrix{bVals, 1, 1}
+	aMat := &Matrix{Values: aVals, Rows: 1, Columns: 1}
+	bMat := &Matrix{Values: bVals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		aMat.Multiply(aMat, bMat)
@@ -89,20 +201,61 @@ func BenchmarkProduct(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	aVals := []float64{r.Float64()}
 	bVals := []float64{r.Float64()}
-	aMat := &Matrix{aVals, 1, 1}
-	bMat := &Matrix{bVals, 1, 1}
+	aMat := &Matrix{Values: aVals, Rows: 1, Columns: 1}
+	bMat := &Matrix{Values: bVals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		aMat.Product(aMat, bMat)
 	}
 }
 
+func BenchmarkProduct_destination(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aVals := []float64{r.Float64()}
+	bVals := []float64{r.Float64()}
+	aMat := &Matrix{Values: aVals, Rows: 1, Columns: 1}
+	bMat := &Matrix{Values: bVals, Rows: 1, Columns: 1}
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Product(aMat, bMat)
+	}
+}
+
+func BenchmarkPow_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 32)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Pow(3, aMat)
+	}
+}
+
+func BenchmarkSqrt_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 32)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Sqrt(aMat)
+	}
+}
+
 func BenchmarkScale(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	vals := []float64{r.Float64()}
-	mat := &Matrix{vals, 1, 1}
+	mat := &Matrix{Values: vals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		mat.Scale(2, mat)
@@ -113,9 +266,10 @@ func BenchmarkSubtract(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	aVals := []float64{r.Float64()}
 	bVals := []float64{r.Float64()}
-	aMat := &Matrix{aVals, 1, 1}
-	bMat := &Matrix{bVals, 1, 1}
+	aMat := &Matrix{Values: aVals, Rows: 1, Columns: 1}
+	bMat := &Matrix{Values: bVals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		aMat.Subtract(aMat, bMat)
@@ -125,10 +279,283 @@ func BenchmarkSubtract(b *testing.B) {
 func BenchmarkTranspose(b *testing.B) {
 	r := rand.New(rand.NewSource(0))
 	vals := []float64{r.Float64()}
-	mat := &Matrix{vals, 1, 1}
+	mat := &Matrix{Values: vals, Rows: 1, Columns: 1}
 
+	b.ReportAllocs()
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
 		mat.Transpose(mat)
 	}
 }
+
+func benchmarkProductSize(b *testing.B, n, workers int) {
+	defer SetProductWorkers(SetProductWorkers(workers))
+
+	r := rand.New(rand.NewSource(0))
+	aMat, bMat, mat := randomMatrix(r, n, n), randomMatrix(r, n, n), &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Product(aMat, bMat)
+	}
+}
+
+func BenchmarkProduct_64(b *testing.B)           { benchmarkProductSize(b, 64, 1) }
+func BenchmarkProduct_256(b *testing.B)          { benchmarkProductSize(b, 256, 1) }
+func BenchmarkProduct_256_parallel(b *testing.B) { benchmarkProductSize(b, 256, runtime.GOMAXPROCS(0)) }
+func BenchmarkProduct_512(b *testing.B)          { benchmarkProductSize(b, 512, 1) }
+func BenchmarkProduct_512_parallel(b *testing.B) { benchmarkProductSize(b, 512, runtime.GOMAXPROCS(0)) }
+
+func BenchmarkAdd_broadcast(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat, bMat := randomMatrix(r, 256, 32), randomMatrix(r, 256, 1)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Add(aMat, bMat)
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	mat := randomMatrix(r, 100, 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Sum()
+	}
+}
+
+func BenchmarkArgMax(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	mat := randomMatrix(r, 100, 100)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.ArgMax()
+	}
+}
+
+func BenchmarkSumAxis(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 100, 100)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.SumAxis(ByColumn, aMat)
+	}
+}
+
+func BenchmarkInverse_64(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 64, 64)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Inverse(aMat)
+	}
+}
+
+func BenchmarkNewSVD_64(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 64, 64)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewSVD(aMat)
+	}
+}
+
+func BenchmarkDenseSparseProduct_50000(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 50000)
+	bSparse, _ := SparseFromMatrix(randomSparse(r, 50000, 1, 0.001))
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.DenseSparseProduct(aMat, bSparse)
+	}
+}
+
+func BenchmarkProduct_50000(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat := randomMatrix(r, 32, 50000)
+	bMat := randomSparse(r, 50000, 1, 0.001)
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Product(aMat, bMat)
+	}
+}
+
+func BenchmarkMarshalBinary_256(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	mat := randomMatrix(r, 256, 256)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.MarshalBinary()
+	}
+}
+
+func BenchmarkUnmarshalBinary_256(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	data, _ := randomMatrix(r, 256, 256).MarshalBinary()
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.UnmarshalBinary(data)
+	}
+}
+
+func BenchmarkCorrelate2D_64(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat, kMat := randomMatrix(r, 64, 64), randomMatrix(r, 5, 5)
+	mat := &Matrix{}
+	opts := ConvOptions{Padding: SamePadding}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Correlate2D(aMat, kMat, opts)
+	}
+}
+
+func BenchmarkIm2Col_64(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat, kMat := randomMatrix(r, 64, 64), randomMatrix(r, 1, 25)
+	cols, mat := &Matrix{}, &Matrix{}
+	opts := ConvOptions{Padding: SamePadding}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cols.Im2Col(aMat, 5, 5, opts)
+		mat.Product(kMat, cols)
+	}
+}
+
+func BenchmarkIsFinite_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	mat := randomMatrix(r, 32, 32)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.IsFinite()
+	}
+}
+
+func BenchmarkHStack_32(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	cols := make([]*Matrix, 32)
+	for idx := range cols {
+		cols[idx] = randomMatrix(r, 256, 1)
+	}
+
+	mat := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.HStack(cols...)
+	}
+}
+
+func BenchmarkPool_32(b *testing.B) {
+	p := &Pool{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m, _ := p.Get(32, 32)
+		p.Put(m)
+	}
+}
+
+func BenchmarkProduct32_256(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat, _ := randomMatrix32(r, 256, 256)
+	bMat, _ := randomMatrix32(r, 256, 256)
+	m := &Matrix32{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Product(aMat, bMat)
+	}
+}
+
+func BenchmarkQuantizedProduct_256(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	aMat, _ := Quantize(randomMatrix(r, 256, 256), PerRow)
+	bMat, _ := Quantize(randomMatrix(r, 256, 256), PerTensor)
+	m := &Matrix{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.QuantizedProduct(aMat, bMat)
+	}
+}
+
+func BenchmarkFFT_1024(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	x := make([]complex128, 1024)
+	for idx := range x {
+		x[idx] = complex(r.Float64(), r.Float64())
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FFT(x)
+	}
+}
+
+func benchmarkCorrelate2D(b *testing.B, size, kSize int, fft bool) {
+	r := rand.New(rand.NewSource(0))
+	aMat, kMat := randomMatrix(r, size, size), randomMatrix(r, kSize, kSize)
+	mat := &Matrix{}
+	opts := ConvOptions{Padding: SamePadding}
+
+	cost := math.Inf(1)
+	if fft {
+		cost = 0
+	}
+	defer SetConvFFTCost(SetConvFFTCost(cost))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		mat.Correlate2D(aMat, kMat, opts)
+	}
+}
+
+func BenchmarkCorrelate2D_256x3Direct(b *testing.B)  { benchmarkCorrelate2D(b, 256, 3, false) }
+func BenchmarkCorrelate2D_256x3FFT(b *testing.B)     { benchmarkCorrelate2D(b, 256, 3, true) }
+func BenchmarkCorrelate2D_256x7Direct(b *testing.B)  { benchmarkCorrelate2D(b, 256, 7, false) }
+func BenchmarkCorrelate2D_256x7FFT(b *testing.B)     { benchmarkCorrelate2D(b, 256, 7, true) }
+func BenchmarkCorrelate2D_256x9Direct(b *testing.B)  { benchmarkCorrelate2D(b, 256, 9, false) }
+func BenchmarkCorrelate2D_256x9FFT(b *testing.B)     { benchmarkCorrelate2D(b, 256, 9, true) }
+func BenchmarkCorrelate2D_256x15Direct(b *testing.B) { benchmarkCorrelate2D(b, 256, 15, false) }
+func BenchmarkCorrelate2D_256x15FFT(b *testing.B)    { benchmarkCorrelate2D(b, 256, 15, true) }
+func BenchmarkCorrelate2D_32x7Direct(b *testing.B)   { benchmarkCorrelate2D(b, 32, 7, false) }
+func BenchmarkCorrelate2D_32x7FFT(b *testing.B)      { benchmarkCorrelate2D(b, 32, 7, true) }
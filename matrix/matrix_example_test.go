@@ -54,37 +54,35 @@ func Example_add() {
 func Example_apply() {
 	mat, _ := matrix.New(2, 3, []float64{0, 1, 2, 3, 4, 5})
 
-	mat.Apply(func(v float64, idx int, s []float64) float64 {
-		fmt.Printf("Slice: %v;\n", s)
-		fmt.Printf("Index: %d; Value: %f;\n", idx, v)
-		newValue := v + 2
-		fmt.Printf("Index: %d; New value: %f;\n", idx, newValue)
-		return newValue
+	mat.Apply(func(v float64) float64 {
+		return v + 2
 	}, mat)
 
 	fmt.Println(mat.Values)
 	// Output:
-	// Slice: [0 1 2 3 4 5];
-	// Index: 0; Value: 0.000000;
-	// Index: 0; New value: 2.000000;
-	// Slice: [2 1 2 3 4 5];
-	// Index: 1; Value: 1.000000;
-	// Index: 1; New value: 3.000000;
-	// Slice: [2 3 2 3 4 5];
-	// Index: 2; Value: 2.000000;
-	// Index: 2; New value: 4.000000;
-	// Slice: [2 3 4 3 4 5];
-	// Index: 3; Value: 3.000000;
-	// Index: 3; New value: 5.000000;
-	// Slice: [2 3 4 5 4 5];
-	// Index: 4; Value: 4.000000;
-	// Index: 4; New value: 6.000000;
-	// Slice: [2 3 4 5 6 5];
-	// Index: 5; Value: 5.000000;
-	// Index: 5; New value: 7.000000;
 	// [2 3 4 5 6 7]
 }
 
+func Example_applyVector() {
+	mat, _ := matrix.New(4, 1, []float64{1, 2, 3, 4})
+
+	// Divide each element by the sum of all the elements.
+	mat.ApplyVector(func(dst, src *matrix.Matrix) {
+		sum := 0.0
+		for _, v := range src.Values {
+			sum += v
+		}
+
+		dst.Apply(func(v float64) float64 {
+			return v / sum
+		}, src)
+	}, mat)
+
+	fmt.Println(mat.Values)
+	// Output:
+	// [0.1 0.2 0.3 0.4]
+}
+
 func Example_at() {
 	mat, _ := matrix.New(2, 3, []float64{0, 1, 2, 3, 4, 5})
 
@@ -149,5 +147,161 @@ func Example_transpose() {
 	mat.Transpose(mat)
 	fmt.Println(mat.Values)
 	// Output:
-	// [0 2 4 1 3 5]
+	// [0 3 1 4 2 5]
+}
+
+func Example_view() {
+	weights, _ := matrix.New(2, 3, []float64{0, 1, 2, 3, 4, 5})
+
+	// The incoming weights of the second neuron.
+	row, _ := weights.Row(1)
+	row.Scale(10, row)
+
+	// The second column of the weights.
+	col, _ := weights.Col(1)
+	col.Scale(-1, col)
+
+	fmt.Println(weights.Values)
+	// Output:
+	// [0 -1 2 30 -40 50]
+}
+
+func Example_broadcast() {
+	// Three samples of two neurons, one sample per column.
+	activations, _ := matrix.New(2, 3, []float64{0, 1, 2, 3, 4, 5})
+
+	// The biases of the neurons are added to every sample.
+	biases, _ := matrix.New(2, 1, []float64{10, 20})
+	activations.Add(activations, biases)
+
+	fmt.Println(activations.Values)
+	// Output:
+	// [10 11 12 23 24 25]
+}
+
+func Example_reduce() {
+	// Two samples of three classes, one sample per column.
+	scores, _ := matrix.New(3, 2, []float64{0.25, 0.75, 0.5, 0.25, 0.25, 0})
+
+	// The predicted class of each sample.
+	classes, _ := scores.ArgMaxAxis(matrix.ByColumn)
+
+	// The mean score of each class.
+	means := &matrix.Matrix{}
+	means.MeanAxis(matrix.ByRow, scores)
+
+	fmt.Println(classes)
+	fmt.Println(means.Values)
+	// Output:
+	// [1 0]
+	// [0.5 0.375 0.125]
+}
+
+func Example_solve() {
+	// Ridge regression on three samples of two features, the weights are the solution of "(X^T * X + lambda * I) * w = X^T * y".
+	x, _ := matrix.New(3, 2, []float64{1, 0, 0, 1, 1, 1})
+	y, _ := matrix.New(3, 1, []float64{1, 2, 3})
+	lambda := 1.0
+
+	xT := &matrix.Matrix{}
+	xT.Transpose(x)
+
+	a, b := &matrix.Matrix{}, &matrix.Matrix{}
+	a.Product(xT, x)
+	i, _ := matrix.Identity(2)
+	i.Scale(lambda, i)
+	a.Add(a, i)
+	b.Product(xT, y)
+
+	w := &matrix.Matrix{}
+	w.Solve(a, b)
+
+	fmt.Printf("%.4f\n", w.Values)
+	// Output:
+	// [0.8750 1.3750]
+}
+
+func Example_pca() {
+	// Four samples of two features, one sample per column, all of them on the "y = 2x + 1" line.
+	samples, _ := matrix.New(2, 4, []float64{-1, 0, 1, 2, -1, 1, 3, 5})
+
+	// Reduce the samples into a single feature.
+	pca, _ := matrix.NewPCA(1)
+	pca.Fit(samples)
+
+	reduced := &matrix.Matrix{}
+	pca.Transform(reduced, samples)
+
+	fmt.Println(reduced.Rows, reduced.Columns)
+	fmt.Printf("%.4f\n", pca.ExplainedVariance())
+	// Output:
+	// 1 4
+	// [6.2500]
+}
+
+func Example_sparse() {
+	// A bag-of-words vector with 6 words, two of them are present.
+	words, _ := matrix.NewSparse(6, 1, []int{4, 1}, []int{0, 0}, []float64{2, 1})
+
+	weights, _ := matrix.New(2, 6, []float64{1, 2, 3, 4, 5, 6, -1, -2, -3, -4, -5, -6})
+	out := &matrix.Matrix{}
+	out.DenseSparseProduct(weights, words)
+
+	fmt.Println(words.NNZ())
+	fmt.Println(out.Values)
+	// Output:
+	// 2
+	// [12 -12]
+}
+
+func Example_convolution() {
+	image, _ := matrix.New(4, 4, []float64{
+		0, 0, 1, 1,
+		0, 0, 1, 1,
+		0, 0, 1, 1,
+		0, 0, 1, 1,
+	})
+
+	// A horizontal gradient kernel, it responds to the vertical edge in the middle of the image.
+	kernel, _ := matrix.New(1, 2, []float64{-1, 1})
+
+	out := &matrix.Matrix{}
+	out.Correlate2D(image, kernel, matrix.ConvOptions{StrideRows: 2})
+
+	fmt.Println(out.Rows, out.Columns)
+	fmt.Println(out.Values)
+	// Output:
+	// 2 3
+	// [0 1 0 0 1 0]
+}
+
+func Example_stack() {
+	// Three samples as column vectors are stacked into a mini-batch, one sample per column.
+	x1, _ := matrix.New(2, 1, []float64{1, 2})
+	x2, _ := matrix.New(2, 1, []float64{3, 4})
+	x3, _ := matrix.New(2, 1, []float64{5, 6})
+
+	batch := &matrix.Matrix{}
+	batch.HStack(x1, x2, x3)
+	fmt.Println(batch.Values)
+
+	// The outputs of two heads are split back apart.
+	heads, _ := batch.Split(matrix.ByRow, 1, 1)
+	fmt.Println(heads[0].Values, heads[1].Values)
+	// Output:
+	// [1 3 5 2 4 6]
+	// [1 3 5] [2 4 6]
+}
+
+func Example_format() {
+	m, _ := matrix.New(2, 3, []float64{1, -2.5, 3, 400, 5, 6})
+
+	fmt.Printf("%.2f\n", m)
+	fmt.Printf("%+v\n", m)
+	// Output:
+	// [  1.00  -2.50  3.00]
+	// [400.00   5.00  6.00]
+	// Matrix 2x3, min: -2.5, max: 400, mean: 68.75
+	// [  1  -2.5  3]
+	// [400     5  6]
 }
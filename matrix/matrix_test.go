@@ -1,6 +1,8 @@
 package matrix
 
 import (
+	"errors"
+	"math"
 	"math/rand"
 	"testing"
 )
@@ -75,7 +77,7 @@ func TestCopy(t *testing.T) {
 		matrix        *Matrix
 		expectedError error
 	}{
-		{"Normal", &Matrix{[]float64{1, 2, 3, 4, 5}, 1, 5}, nil},
+		{"Normal", &Matrix{Values: []float64{1, 2, 3, 4, 5}, Rows: 1, Columns: 5}, nil},
 		{"ErrNilMatrix", nil, ErrNilMatrix},
 	}
 
@@ -121,6 +123,39 @@ func TestCopy(t *testing.T) {
 	})
 }
 
+func TestAbs(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{-1.5, 0, 2, -3}, Rows: 2, Columns: 2}, []float64{1.5, 0, 2, 3}, nil},
+		{"View", &Matrix{Values: []float64{-1, -2, 9, -3, -4}, Rows: 2, Columns: 2, stride: 3}, []float64{1, 2, 3, 4}, nil},
+		{"ErrNilMatrix", nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Abs(tc.matrix)
+
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(m.Values, tc.expectedValues, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+			}
+		})
+	}
+}
+
 func TestAdd(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -128,10 +163,12 @@ func TestAdd(t *testing.T) {
 		expectedValues []float64
 		expectedError  error
 	}{
-		{"Normal", &Matrix{[]float64{1, 2}, 1, 2}, &Matrix{[]float64{1, 2}, 1, 2}, []float64{2, 4}, nil},
-		{"ErrNilMatrix a", nil, &Matrix{[]float64{1, 2}, 1, 2}, nil, ErrNilMatrix},
-		{"ErrNilMatrix b", &Matrix{[]float64{1, 2}, 1, 2}, nil, nil, ErrNilMatrix},
-		{"ErrDifferentDimensions", &Matrix{[]float64{1, 2}, 1, 2}, &Matrix{[]float64{1, 2}, 2, 1}, nil, ErrDifferentDimensions},
+		{"Normal", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, []float64{2, 4}, nil},
+		{"ErrNilMatrix a", nil, &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, ErrNilMatrix},
+		{"ErrNilMatrix b", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, nil, ErrNilMatrix},
+		{"Broadcast", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1}, []float64{2, 3, 3, 4}, nil},
+		{"ErrBroadcastDimensions", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1, 2, 3}, Rows: 1, Columns: 3}, nil, ErrBroadcastDimensions},
+		{"ErrDifferentDimensions", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1, 2, 3}, Rows: 1, Columns: 3}, nil, ErrDifferentDimensions},
 	}
 
 	for _, tc := range testCases {
@@ -143,7 +180,7 @@ func TestAdd(t *testing.T) {
 			err := m.Add(tc.a, tc.b)
 
 			if tc.expectedError != nil {
-				if err != tc.expectedError {
+				if !errors.Is(err, tc.expectedError) {
 					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
 				}
 			} else if err != nil {
@@ -180,7 +217,7 @@ func TestAdd(t *testing.T) {
 }
 
 func TestApply(t *testing.T) {
-	addFn := func(v float64, _ int, _ []float64) float64 {
+	addFn := func(v float64) float64 {
 		return v * 2
 	}
 	testCases := []struct {
@@ -190,8 +227,8 @@ func TestApply(t *testing.T) {
 		expectedValues []float64
 		expectedError  error
 	}{
-		{"Normal", &Matrix{[]float64{1, 2}, 1, 2}, addFn, []float64{2, 4}, nil},
-		{"ErrNilFunction", &Matrix{[]float64{1, 2}, 1, 2}, nil, nil, ErrNilFunction},
+		{"Normal", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, addFn, []float64{2, 4}, nil},
+		{"ErrNilFunction", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, nil, ErrNilFunction},
 		{"ErrNilMatrix", nil, addFn, nil, ErrNilMatrix},
 	}
 
@@ -227,7 +264,7 @@ func TestApply(t *testing.T) {
 		r := rand.New(rand.NewSource(0))
 		v := []float64{r.Float64()}
 		a, _ := New(1, 1, v)
-		err := a.Apply(func(v float64, _ int, _ []float64) float64 {
+		err := a.Apply(func(v float64) float64 {
 			return v * 2
 		}, a)
 		if err != nil {
@@ -242,6 +279,75 @@ func TestApply(t *testing.T) {
 	})
 }
 
+func TestApplyVector(t *testing.T) {
+	reverseFn := func(dst, src *Matrix) {
+		l := len(src.Values)
+		for idx := 0; idx < l/2; idx++ {
+			dst.Values[idx], dst.Values[l-idx-1] = src.Values[l-idx-1], src.Values[idx]
+		}
+
+		if l%2 == 1 {
+			dst.Values[l/2] = src.Values[l/2]
+		}
+	}
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		function       VectorFn
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{1, 2, 3}, Rows: 1, Columns: 3}, reverseFn, []float64{3, 2, 1}, nil},
+		{"ErrNilFunction", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, nil, ErrNilFunction},
+		{"ErrNilMatrix", nil, reverseFn, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.ApplyVector(tc.function, tc.matrix)
+
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if m.Rows != tc.matrix.Rows || m.Columns != tc.matrix.Columns {
+					t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.matrix.Rows, tc.matrix.Columns, m.Rows, m.Columns)
+				}
+
+				for idx, v := range m.Values {
+					if v != tc.expectedValues[idx] {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("Shared backing array", func(t *testing.T) {
+		t.Parallel()
+
+		vals := []float64{1, 2, 3, 4}
+		a := &Matrix{Values: vals[:3], Rows: 1, Columns: 3}
+		m := &Matrix{Values: vals[1:], Rows: 1, Columns: 3}
+		if err := m.ApplyVector(reverseFn, a); err != nil {
+			t.Errorf("Expected error is %v, but got %v", nil, err)
+		}
+
+		for idx, v := range []float64{3, 2, 1} {
+			if m.Values[idx] != v {
+				t.Errorf("Expected value is %f, but got %f", v, m.Values[idx])
+			}
+		}
+	})
+}
+
 func TestAt(t *testing.T) {
 	type dimension struct {
 		row, column int
@@ -253,10 +359,10 @@ func TestAt(t *testing.T) {
 		expectedValues []float64
 		expectedError  error
 	}{
-		{"Normal [3][2]", &Matrix{[]float64{1, 2, 3, 4, 5, 6}, 3, 2}, []dimension{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {2, 0}, {2, 1}}, []float64{1, 2, 3, 4, 5, 6}, nil},
-		{"Normal [2][3]", &Matrix{[]float64{1, 2, 3, 4, 5, 6}, 2, 3}, []dimension{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}}, []float64{1, 2, 3, 4, 5, 6}, nil},
-		{"ErrRowOutOfBounds", &Matrix{[]float64{1, 2}, 1, 2}, []dimension{{1, 0}}, nil, ErrRowOutOfBounds},
-		{"ErrColOutOfBounds", &Matrix{[]float64{1, 2}, 1, 2}, []dimension{{0, 3}}, nil, ErrColOutOfBounds},
+		{"Normal [3][2]", &Matrix{Values: []float64{1, 2, 3, 4, 5, 6}, Rows: 3, Columns: 2}, []dimension{{0, 0}, {0, 1}, {1, 0}, {1, 1}, {2, 0}, {2, 1}}, []float64{1, 2, 3, 4, 5, 6}, nil},
+		{"Normal [2][3]", &Matrix{Values: []float64{1, 2, 3, 4, 5, 6}, Rows: 2, Columns: 3}, []dimension{{0, 0}, {0, 1}, {0, 2}, {1, 0}, {1, 1}, {1, 2}}, []float64{1, 2, 3, 4, 5, 6}, nil},
+		{"ErrRowOutOfBounds", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, []dimension{{1, 0}}, nil, ErrRowOutOfBounds},
+		{"ErrColOutOfBounds", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, []dimension{{0, 3}}, nil, ErrColOutOfBounds},
 	}
 
 	for _, tc := range testCases {
@@ -282,6 +388,148 @@ func TestAt(t *testing.T) {
 	}
 }
 
+func TestClip(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		min, max       float64
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{-2, -0.5, 0.5, 2}, Rows: 2, Columns: 2}, -1, 1, []float64{-1, -0.5, 0.5, 1}, nil},
+		{"Infinity", &Matrix{Values: []float64{math.Inf(-1), math.Inf(1)}, Rows: 1, Columns: 2}, 0, 0, []float64{0, 0}, nil},
+		{"ErrClipRange", &Matrix{Values: []float64{0}, Rows: 1, Columns: 1}, 1, -1, nil, ErrClipRange},
+		{"ErrNilMatrix", nil, 0, 1, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Clip(tc.min, tc.max, tc.matrix)
+
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(m.Values, tc.expectedValues, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+			}
+		})
+	}
+}
+
+func TestDivide(t *testing.T) {
+	testCases := []struct {
+		name           string
+		a, b           *Matrix
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{1, 6}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{2, 3}, Rows: 1, Columns: 2}, []float64{0.5, 2}, nil},
+		{"Broadcast", &Matrix{Values: []float64{2, 4, 6, 8}, Rows: 2, Columns: 2}, &Matrix{Values: []float64{2}, Rows: 1, Columns: 1}, []float64{1, 2, 3, 4}, nil},
+		{"Division by zero", &Matrix{Values: []float64{1, -1}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{0, 0}, Rows: 1, Columns: 2}, []float64{math.Inf(1), math.Inf(-1)}, nil},
+		{"ErrNilMatrix a", nil, &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, ErrNilMatrix},
+		{"ErrNilMatrix b", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, nil, ErrNilMatrix},
+		{"ErrBroadcastDimensions", &Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1}, &Matrix{Values: []float64{1, 2, 3}, Rows: 3, Columns: 1}, nil, ErrBroadcastDimensions},
+		{"ErrDifferentDimensions", &Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1}, &Matrix{Values: []float64{1, 2, 3}, Rows: 3, Columns: 1}, nil, ErrDifferentDimensions},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Divide(tc.a, tc.b)
+
+			if tc.expectedError != nil {
+				if !errors.Is(err, tc.expectedError) {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if m == nil {
+				t.Error("Matrix should not be nil")
+			} else {
+				for idx, v := range m.Values {
+					if v != tc.expectedValues[idx] {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestExp(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{0, 1, -1, 2}, Rows: 2, Columns: 2}, []float64{1, math.E, 1 / math.E, math.E * math.E}, nil},
+		{"ErrNilMatrix", nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Exp(tc.matrix)
+
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(m.Values, tc.expectedValues, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+			}
+		})
+	}
+}
+
+func TestLog(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{1, math.E, 1 / math.E, math.E * math.E}, Rows: 2, Columns: 2}, []float64{0, 1, -1, 2}, nil},
+		{"ErrNilMatrix", nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Log(tc.matrix)
+
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(m.Values, tc.expectedValues, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+			}
+		})
+	}
+}
+
 func TestMultiply(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -289,10 +537,12 @@ func TestMultiply(t *testing.T) {
 		expectedValues []float64
 		expectedError  error
 	}{
-		{"Normal", &Matrix{[]float64{0, 1, 2, 3}, 2, 2}, &Matrix{[]float64{0, 1, 2, 3}, 2, 2}, []float64{0, 1, 4, 9}, nil},
-		{"ErrNilMatrix a", nil, &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 3, 2}, nil, ErrNilMatrix},
-		{"ErrNilMatrix b", &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 2, 3}, nil, nil, ErrNilMatrix},
-		{"ErrDifferentDimensions", &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 2, 3}, &Matrix{[]float64{0, -1}, 1, 2}, nil, ErrDifferentDimensions},
+		{"Normal", &Matrix{Values: []float64{0, 1, 2, 3}, Rows: 2, Columns: 2}, &Matrix{Values: []float64{0, 1, 2, 3}, Rows: 2, Columns: 2}, []float64{0, 1, 4, 9}, nil},
+		{"ErrNilMatrix a", nil, &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 3, Columns: 2}, nil, ErrNilMatrix},
+		{"ErrNilMatrix b", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}, nil, nil, ErrNilMatrix},
+		{"Broadcast", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}, &Matrix{Values: []float64{-1, 2}, Rows: 2, Columns: 1}, []float64{0, -1, -2, 6, 8, 10}, nil},
+		{"ErrBroadcastDimensions", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}, &Matrix{Values: []float64{0, -1}, Rows: 1, Columns: 2}, nil, ErrBroadcastDimensions},
+		{"ErrDifferentDimensions", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}, &Matrix{Values: []float64{0, -1}, Rows: 1, Columns: 2}, nil, ErrDifferentDimensions},
 	}
 
 	for _, tc := range testCases {
@@ -304,7 +554,7 @@ func TestMultiply(t *testing.T) {
 			err := m.Multiply(tc.a, tc.b)
 
 			if tc.expectedError != nil {
-				if err != tc.expectedError {
+				if !errors.Is(err, tc.expectedError) {
 					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
 				}
 			} else if err != nil {
@@ -341,6 +591,41 @@ func TestMultiply(t *testing.T) {
 	})
 }
 
+func TestPow(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		p              float64
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Square", &Matrix{Values: []float64{-2, 0, 1.5, 3}, Rows: 2, Columns: 2}, 2, []float64{4, 0, 2.25, 9}, nil},
+		{"Cube", &Matrix{Values: []float64{-2, 0, 1.5, 3}, Rows: 2, Columns: 2}, 3, []float64{-8, 0, 3.375, 27}, nil},
+		{"Reciprocal", &Matrix{Values: []float64{-2, 4, 0.5, 1}, Rows: 2, Columns: 2}, -1, []float64{-0.5, 0.25, 2, 1}, nil},
+		{"ErrNilMatrix", nil, 2, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Pow(tc.p, tc.matrix)
+
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(m.Values, tc.expectedValues, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+			}
+		})
+	}
+}
+
 func TestProduct(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -348,11 +633,11 @@ func TestProduct(t *testing.T) {
 		expectedValues []float64
 		expectedError  error
 	}{
-		{"Normal short", &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 2, 3}, &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 3, 2}, []float64{10, 13, 28, 40}, nil},
-		{"Normal long", &Matrix{[]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, 4, 3}, &Matrix{[]float64{0, -1, -2, -3, -4, -5}, 3, 2}, []float64{-10, -13, -28, -40, -46, -67, -64, -94}, nil},
-		{"ErrNilMatrix a", nil, &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 3, 2}, nil, ErrNilMatrix},
-		{"ErrNilMatrix b", &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 2, 3}, nil, nil, ErrNilMatrix},
-		{"ErrBadProductDimension", &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 2, 3}, &Matrix{[]float64{0, -1}, 1, 2}, nil, ErrBadProductDimension},
+		{"Normal short", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}, &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 3, Columns: 2}, []float64{10, 13, 28, 40}, nil},
+		{"Normal long", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, Rows: 4, Columns: 3}, &Matrix{Values: []float64{0, -1, -2, -3, -4, -5}, Rows: 3, Columns: 2}, []float64{-10, -13, -28, -40, -46, -67, -64, -94}, nil},
+		{"ErrNilMatrix a", nil, &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 3, Columns: 2}, nil, ErrNilMatrix},
+		{"ErrNilMatrix b", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}, nil, nil, ErrNilMatrix},
+		{"ErrBadProductDimension", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}, &Matrix{Values: []float64{0, -1}, Rows: 1, Columns: 2}, nil, ErrBadProductDimension},
 	}
 
 	for _, tc := range testCases {
@@ -364,7 +649,7 @@ func TestProduct(t *testing.T) {
 			err := m.Product(tc.a, tc.b)
 
 			if tc.expectedError != nil {
-				if err != tc.expectedError {
+				if !errors.Is(err, tc.expectedError) {
 					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
 				}
 			} else if err != nil {
@@ -409,7 +694,7 @@ func TestScale(t *testing.T) {
 		expectedValues []float64
 		expectedError  error
 	}{
-		{"Normal", &Matrix{[]float64{0, 1, 2, 3}, 2, 2}, 2, []float64{0, 2, 4, 6}, nil},
+		{"Normal", &Matrix{Values: []float64{0, 1, 2, 3}, Rows: 2, Columns: 2}, 2, []float64{0, 2, 4, 6}, nil},
 		{"ErrNilMatrix", nil, 0, nil, ErrNilMatrix},
 	}
 
@@ -459,6 +744,38 @@ func TestScale(t *testing.T) {
 	})
 }
 
+func TestSqrt(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{0, 1, 2.25, 9}, Rows: 2, Columns: 2}, []float64{0, 1, 1.5, 3}, nil},
+		{"ErrNilMatrix", nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Sqrt(tc.matrix)
+
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if !isApproxEqual(m.Values, tc.expectedValues, 1e-12) {
+				t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+			}
+		})
+	}
+}
+
 func TestSubtract(t *testing.T) {
 	testCases := []struct {
 		name           string
@@ -466,10 +783,12 @@ func TestSubtract(t *testing.T) {
 		expectedValues []float64
 		expectedError  error
 	}{
-		{"Normal", &Matrix{[]float64{1, 2}, 1, 2}, &Matrix{[]float64{1, 2}, 1, 2}, []float64{0, 0}, nil},
-		{"ErrNilMatrix a", nil, &Matrix{[]float64{1, 2}, 1, 2}, nil, ErrNilMatrix},
-		{"ErrNilMatrix b", &Matrix{[]float64{1, 2}, 1, 2}, nil, nil, ErrNilMatrix},
-		{"ErrDifferentDimensions", &Matrix{[]float64{1, 2}, 1, 2}, &Matrix{[]float64{1, 2}, 2, 1}, nil, ErrDifferentDimensions},
+		{"Normal", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, []float64{0, 0}, nil},
+		{"ErrNilMatrix a", nil, &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, ErrNilMatrix},
+		{"ErrNilMatrix b", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, nil, nil, ErrNilMatrix},
+		{"Broadcast", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1, 2}, Rows: 2, Columns: 1}, []float64{0, 1, -1, 0}, nil},
+		{"ErrBroadcastDimensions", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1, 2, 3}, Rows: 1, Columns: 3}, nil, ErrBroadcastDimensions},
+		{"ErrDifferentDimensions", &Matrix{Values: []float64{1, 2}, Rows: 1, Columns: 2}, &Matrix{Values: []float64{1, 2, 3}, Rows: 1, Columns: 3}, nil, ErrDifferentDimensions},
 	}
 
 	for _, tc := range testCases {
@@ -481,7 +800,7 @@ func TestSubtract(t *testing.T) {
 			err := m.Subtract(tc.a, tc.b)
 
 			if tc.expectedError != nil {
-				if err != tc.expectedError {
+				if !errors.Is(err, tc.expectedError) {
 					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
 				}
 			} else if err != nil {
@@ -525,7 +844,7 @@ func TestTranspose(t *testing.T) {
 		expectedValues             []float64
 		expectedError              error
 	}{
-		{"Normal", &Matrix{[]float64{0, 1, 2, 3, 4, 5}, 3, 2}, 2, 3, []float64{0, 3, 1, 4, 2, 5}, nil},
+		{"Normal", &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 3, Columns: 2}, 2, 3, []float64{0, 2, 4, 1, 3, 5}, nil},
 		{"ErrNilMatrix", nil, 0, 0, nil, ErrNilMatrix},
 	}
 
@@ -563,3 +882,96 @@ func TestTranspose(t *testing.T) {
 		})
 	}
 }
+
+func TestAllocations(t *testing.T) {
+	aMat := &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}
+	bMat := &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 3, Columns: 2}
+	sqMat := &Matrix{Values: []float64{0, 1, 2, 3}, Rows: 2, Columns: 2}
+	testCases := []struct {
+		name string
+		fn   func(m *Matrix)
+	}{
+		{"Add", func(m *Matrix) { m.Add(aMat, aMat) }},
+		{"Add in-place", func(m *Matrix) { m.Add(m, m) }},
+		{"Apply", func(m *Matrix) { m.Apply(func(v float64) float64 { return v }, aMat) }},
+		{"ApplyVector", func(m *Matrix) { m.ApplyVector(func(dst, src *Matrix) { dst.Scale(2, src) }, aMat) }},
+		{"CopyFrom", func(m *Matrix) { m.CopyFrom(aMat) }},
+		{"Clip", func(m *Matrix) { m.Clip(1, 4, aMat) }},
+		{"Exp", func(m *Matrix) { m.Exp(aMat) }},
+		{"Multiply", func(m *Matrix) { m.Multiply(aMat, aMat) }},
+		{"Pow", func(m *Matrix) { m.Pow(3, aMat) }},
+		{"Product", func(m *Matrix) { m.Product(aMat, bMat) }},
+		{"Scale", func(m *Matrix) { m.Scale(2, aMat) }},
+		{"SetValues", func(m *Matrix) { m.SetValues(2, 3, aMat.Values) }},
+		{"Sqrt in-place", func(m *Matrix) { m.Sqrt(m) }},
+		{"Subtract", func(m *Matrix) { m.Subtract(aMat, aMat) }},
+		{"Transpose", func(m *Matrix) { m.Transpose(aMat) }},
+		{"Transpose in-place square", func(m *Matrix) { m.Transpose(sqMat); m.Transpose(m) }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Matrix{}
+			tc.fn(m)
+
+			if allocs := testing.AllocsPerRun(100, func() { tc.fn(m) }); allocs != 0 {
+				t.Errorf("Expected number of allocations is %d, but got %f", 0, allocs)
+			}
+		})
+	}
+}
+
+func TestAliasing(t *testing.T) {
+	t.Run("Product", func(t *testing.T) {
+		t.Parallel()
+
+		a, _ := New(2, 2, []float64{1, 2, 3, 4})
+		b, _ := New(2, 2, []float64{5, 6, 7, 8})
+		if err := a.Product(a, b); err != nil {
+			t.Errorf("Expected error is %v, but got %v", nil, err)
+		}
+
+		for idx, v := range []float64{19, 22, 43, 50} {
+			if a.Values[idx] != v {
+				t.Errorf("Expected value is %f, but got %f", v, a.Values[idx])
+			}
+		}
+	})
+
+	t.Run("Transpose", func(t *testing.T) {
+		t.Parallel()
+
+		a, _ := New(2, 3, []float64{0, 1, 2, 3, 4, 5})
+		if err := a.Transpose(a); err != nil {
+			t.Errorf("Expected error is %v, but got %v", nil, err)
+		}
+
+		for idx, v := range []float64{0, 3, 1, 4, 2, 5} {
+			if a.Values[idx] != v {
+				t.Errorf("Expected value is %f, but got %f", v, a.Values[idx])
+			}
+		}
+
+		if a.Rows != 3 || a.Columns != 2 {
+			t.Errorf("Expected dimensions are %dx%d, but got %dx%d", 3, 2, a.Rows, a.Columns)
+		}
+	})
+
+	t.Run("Shared backing array", func(t *testing.T) {
+		t.Parallel()
+
+		vals := []float64{1, 2, 3, 4, 5, 6}
+		a := &Matrix{Values: vals[:4], Rows: 2, Columns: 2}
+		m := &Matrix{Values: vals[2:], Rows: 2, Columns: 2}
+		if err := m.Product(a, a); err != nil {
+			t.Errorf("Expected error is %v, but got %v", nil, err)
+		}
+
+		for idx, v := range []float64{7, 10, 15, 22} {
+			if m.Values[idx] != v {
+				t.Errorf("Expected value is %f, but got %f", v, m.Values[idx])
+			}
+		}
+	})
+}
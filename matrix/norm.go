@@ -0,0 +1,144 @@
+package matrix
+
+import "math"
+
+// EqualApprox reports whether "a" and "b" have the same dimensions, and each pair of their elements differ by at most "tol".
+// Infinite elements are only equal to the same infinity, and NaN elements are not equal to anything.
+// Two nil matrices are equal, but a nil matrix is not equal to a non-nil one.
+func EqualApprox(aMat, bMat *Matrix, tol float64) bool {
+	if aMat == nil || bMat == nil {
+		return aMat == bMat
+	}
+
+	if aMat.Rows != bMat.Rows || aMat.Columns != bMat.Columns {
+		return false
+	}
+
+	for r := 0; r < aMat.Rows; r++ {
+		bRow := bMat.row(r)
+		for c, v := range aMat.row(r) {
+			if v == bRow[c] {
+				continue
+			}
+
+			if math.IsInf(v, 0) || math.IsInf(bRow[c], 0) || !(math.Abs(v-bRow[c]) <= tol) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// any reports whether "fn" returns true for any of the elements of the matrix.
+func (m *Matrix) any(fn func(v float64) bool) bool {
+	for r := 0; r < m.Rows; r++ {
+		for _, v := range m.row(r) {
+			if fn(v) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// HasNaN reports whether any of the elements of the matrix is NaN.
+func (m *Matrix) HasNaN() bool {
+	return m.any(math.IsNaN)
+}
+
+// HasInf reports whether any of the elements of the matrix is positive or negative infinity.
+func (m *Matrix) HasInf() bool {
+	return m.any(func(v float64) bool { return math.IsInf(v, 0) })
+}
+
+// IsFinite reports whether all of the elements of the matrix are finite, neither NaN nor infinity.
+func (m *Matrix) IsFinite() bool {
+	// "v - v" is zero for every finite "v", and NaN for NaN and the infinities.
+	return !m.any(func(v float64) bool { return v-v != 0 })
+}
+
+// FrobeniusNorm returns the square root of the sum of the squares of the elements of the matrix.
+func (m *Matrix) FrobeniusNorm() float64 {
+	// The elements are scaled by the largest absolute value, so the squares do not overflow.
+	scale := 0.0
+	m.each(func(_, _ int, v float64) {
+		scale = math.Max(scale, math.Abs(v))
+	})
+
+	if scale == 0 || math.IsInf(scale, 1) || math.IsNaN(scale) {
+		return scale
+	}
+
+	sum := 0.0
+	m.each(func(_, _ int, v float64) {
+		v /= scale
+		sum += v * v
+	})
+
+	return scale * math.Sqrt(sum)
+}
+
+// L1Norm returns the induced 1-norm of the matrix, which is the largest sum of the absolute values in a column.
+// For a column vector it is the sum of the absolute values of the elements.
+func (m *Matrix) L1Norm() float64 {
+	sums := make([]float64, m.Columns)
+	m.each(func(_, c int, v float64) {
+		sums[c] += math.Abs(v)
+	})
+
+	norm := 0.0
+	for _, v := range sums {
+		norm = math.Max(norm, v)
+	}
+
+	return norm
+}
+
+// L2Norm returns the induced 2-norm (spectral norm) of the matrix, which is its largest singular value.
+// For a row or column vector it is the Euclidean length of the vector, and it is calculated without a decomposition.
+func (m *Matrix) L2Norm() float64 {
+	if m.Rows*m.Columns == 0 {
+		return 0
+	}
+
+	if m.Rows == 1 || m.Columns == 1 {
+		return m.FrobeniusNorm()
+	}
+
+	d, _ := NewSVD(m)
+	return d.values[0]
+}
+
+// InfNorm returns the induced infinity-norm of the matrix, which is the largest sum of the absolute values in a row.
+// For a column vector it is the largest absolute value of the elements.
+func (m *Matrix) InfNorm() float64 {
+	norm := 0.0
+	for r := 0; r < m.Rows; r++ {
+		sum := 0.0
+		for _, v := range m.row(r) {
+			sum += math.Abs(v)
+		}
+
+		norm = math.Max(norm, sum)
+	}
+
+	return norm
+}
+
+// Trace returns the sum of the elements on the main diagonal of the matrix.
+// It will return an error if the matrix is not square.
+func (m *Matrix) Trace() (float64, error) {
+	if m.Rows != m.Columns {
+		return 0, newShapeError("Trace", ErrNotSquare, m.Rows, m.Rows, m.Rows, m.Columns)
+	}
+
+	trace := 0.0
+	stride := m.Stride()
+	for idx := 0; idx < m.Rows; idx++ {
+		trace += m.Values[idx*stride+idx]
+	}
+
+	return trace, nil
+}
@@ -0,0 +1,127 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEqualApprox(t *testing.T) {
+	a := &Matrix{Values: []float64{1, 2, 3, math.Inf(1)}, Rows: 2, Columns: 2}
+	testCases := []struct {
+		name       string
+		aMat, bMat *Matrix
+		tol        float64
+		expected   bool
+	}{
+		{"Equal", a, &Matrix{Values: []float64{1, 2, 3, math.Inf(1)}, Rows: 2, Columns: 2}, 0, true},
+		{"Within tolerance", a, &Matrix{Values: []float64{1 + 1e-10, 2, 3 - 1e-10, math.Inf(1)}, Rows: 2, Columns: 2}, 1e-9, true},
+		{"Outside of tolerance", a, &Matrix{Values: []float64{1 + 1e-8, 2, 3, math.Inf(1)}, Rows: 2, Columns: 2}, 1e-9, false},
+		{"Different infinity", a, &Matrix{Values: []float64{1, 2, 3, math.Inf(-1)}, Rows: 2, Columns: 2}, math.Inf(1), false},
+		{"NaN", &Matrix{Values: []float64{math.NaN()}, Rows: 1, Columns: 1}, &Matrix{Values: []float64{math.NaN()}, Rows: 1, Columns: 1}, 1, false},
+		{"Different dimensions", a, &Matrix{Values: []float64{1, 2, 3, math.Inf(1)}, Rows: 1, Columns: 4}, 0, false},
+		{"View", &Matrix{Values: []float64{1, 2, 0, 3, math.Inf(1)}, Rows: 2, Columns: 2, stride: 3}, a, 0, true},
+		{"Nil", nil, nil, 0, true},
+		{"One nil", a, nil, 0, false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if eq := EqualApprox(tc.aMat, tc.bMat, tc.tol); eq != tc.expected {
+				t.Errorf("Expected result is %t, but got %t", tc.expected, eq)
+			}
+		})
+	}
+}
+
+func TestIsFinite(t *testing.T) {
+	testCases := []struct {
+		name                               string
+		matrix                             *Matrix
+		expectedNaN, expectedInf, expected bool
+	}{
+		{"Finite", &Matrix{Values: []float64{1, -2, math.MaxFloat64, 0}, Rows: 2, Columns: 2}, false, false, true},
+		{"NaN", &Matrix{Values: []float64{1, math.NaN(), 3, 4}, Rows: 2, Columns: 2}, true, false, false},
+		{"Inf", &Matrix{Values: []float64{1, 2, math.Inf(-1), 4}, Rows: 2, Columns: 2}, false, true, false},
+		{"Outside of the view", &Matrix{Values: []float64{1, math.NaN(), 2, math.Inf(1)}, Rows: 2, Columns: 1, stride: 2}, false, false, true},
+		{"Empty", &Matrix{}, false, false, true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if v := tc.matrix.HasNaN(); v != tc.expectedNaN {
+				t.Errorf("Expected HasNaN is %t, but got %t", tc.expectedNaN, v)
+			}
+
+			if v := tc.matrix.HasInf(); v != tc.expectedInf {
+				t.Errorf("Expected HasInf is %t, but got %t", tc.expectedInf, v)
+			}
+
+			if v := tc.matrix.IsFinite(); v != tc.expected {
+				t.Errorf("Expected IsFinite is %t, but got %t", tc.expected, v)
+			}
+		})
+	}
+}
+
+func TestNorms(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		matrix                 *Matrix
+		frobenius, l1, l2, inf float64
+	}{
+		{"Matrix", &Matrix{Values: []float64{1, -2, -3, 4}, Rows: 2, Columns: 2}, math.Sqrt(30), 6, math.Sqrt(15 + math.Sqrt(221)), 7},
+		{"Diagonal", &Matrix{Values: []float64{3, 0, 0, 0, -5, 0}, Rows: 2, Columns: 3}, math.Sqrt(34), 5, 5, 5},
+		{"Column vector", &Matrix{Values: []float64{3, -4}, Rows: 2, Columns: 1}, 5, 7, 5, 4},
+		{"Row vector", &Matrix{Values: []float64{3, -4}, Rows: 1, Columns: 2}, 5, 4, 5, 7},
+		{"Large elements", &Matrix{Values: []float64{3e200, 4e200}, Rows: 2, Columns: 1}, 5e200, 7e200, 5e200, 4e200},
+		{"Empty", &Matrix{}, 0, 0, 0, 0},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := []float64{tc.matrix.FrobeniusNorm(), tc.matrix.L1Norm(), tc.matrix.L2Norm(), tc.matrix.InfNorm()}
+			expected := []float64{tc.frobenius, tc.l1, tc.l2, tc.inf}
+			for idx := range got {
+				if math.Abs(got[idx]-expected[idx]) > 1e-12*math.Max(1, expected[idx]) {
+					t.Errorf("Expected norms are %v, but got %v", expected, got)
+					break
+				}
+			}
+		})
+	}
+
+	t.Run("NaN", func(t *testing.T) {
+		t.Parallel()
+
+		m := &Matrix{Values: []float64{1, math.NaN()}, Rows: 1, Columns: 2}
+		if v := m.FrobeniusNorm(); !math.IsNaN(v) {
+			t.Errorf("Expected norm is %f, but got %f", math.NaN(), v)
+		}
+	})
+}
+
+func TestTrace(t *testing.T) {
+	m := &Matrix{Values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, Rows: 3, Columns: 3}
+	if trace, err := m.Trace(); err != nil || trace != 15 {
+		t.Errorf("Expected trace is %f, but got %f (%v)", 15.0, trace, err)
+	}
+
+	v, _ := m.Slice(1, 3, 1, 3)
+	if trace, err := v.Trace(); err != nil || trace != 14 {
+		t.Errorf("Expected trace is %f, but got %f (%v)", 14.0, trace, err)
+	}
+
+	if _, err := (&Matrix{Values: make([]float64, 2), Rows: 1, Columns: 2}).Trace(); !errors.Is(err, ErrNotSquare) {
+		t.Errorf("Expected error is %v, but got %v", ErrNotSquare, err)
+	}
+}
@@ -0,0 +1,110 @@
+package matrix
+
+// PCA performs principal component analysis, it projects the samples onto the directions in which the training samples vary the most.
+// The samples are the columns of the matrices, the same way as the inputs of the networks are column vectors.
+type PCA struct {
+	components int
+	mean       *Matrix   // The mean of the training samples, a "features x 1" matrix
+	basis      *Matrix   // The principal components as columns, a "features x components" matrix
+	variances  []float64 // The variance of the training samples along each principal component
+}
+
+// NewPCA creates a new PCA that reduces the samples into "components" dimensions.
+// It will return an error if "components <= 0".
+func NewPCA(components int) (*PCA, error) {
+	if components <= 0 {
+		return nil, ErrBadComponents
+	}
+
+	return &PCA{components: components}, nil
+}
+
+// Fit calculates the principal components of the "features x samples" matrix "x", replacing the results of the previous Fit.
+// The components are the left singular vectors of the centered samples, and the variances are the population variances along them.
+// It will return an error if "x == nil", or the number of components is larger than the number of features or samples.
+func (p *PCA) Fit(xMat *Matrix) error {
+	if xMat == nil {
+		return ErrNilMatrix
+	}
+
+	if p.components > xMat.Rows || p.components > xMat.Columns {
+		return ErrBadComponents
+	}
+
+	mean, centered := &Matrix{}, &Matrix{}
+	mean.MeanAxis(ByRow, xMat)
+	centered.Subtract(xMat, mean)
+
+	d, _ := NewSVD(centered)
+	basis, _ := d.u.Slice(0, d.u.Rows, 0, p.components)
+	basis, _ = Copy(basis)
+
+	variances := make([]float64, p.components)
+	for idx := range variances {
+		variances[idx] = d.values[idx] * d.values[idx] / float64(xMat.Columns)
+	}
+
+	p.mean, p.basis, p.variances = mean, basis, variances
+	return nil
+}
+
+// Transform projects the "features x samples" matrix "x" onto the principal components, placing the "components x samples" result in "m".
+// The "m" matrix may be "x".
+// It will return an error if "m == nil" or "x == nil", Fit was not called yet, or the number of rows in "x" is not the number of features.
+func (p *PCA) Transform(m, xMat *Matrix) error {
+	if m == nil || xMat == nil {
+		return ErrNilMatrix
+	}
+
+	if p.basis == nil {
+		return ErrNotFitted
+	}
+
+	if xMat.Rows != p.basis.Rows {
+		return newShapeError("Transform", ErrFeatureDimension, p.basis.Rows, xMat.Columns, xMat.Rows, xMat.Columns)
+	}
+
+	centered, bT := &Matrix{}, &Matrix{}
+	centered.Subtract(xMat, p.mean)
+	bT.Transpose(p.basis)
+
+	return m.Product(bT, centered)
+}
+
+// InverseTransform maps the "components x samples" matrix "y" back into the space of the features, placing the "features x samples" result in "m".
+// The "m" matrix may be "y".
+// It will return an error if "m == nil" or "y == nil", Fit was not called yet, or the number of rows in "y" is not the number of components.
+func (p *PCA) InverseTransform(m, yMat *Matrix) error {
+	if m == nil || yMat == nil {
+		return ErrNilMatrix
+	}
+
+	if p.basis == nil {
+		return ErrNotFitted
+	}
+
+	if yMat.Rows != p.components {
+		return newShapeError("InverseTransform", ErrFeatureDimension, p.components, yMat.Columns, yMat.Rows, yMat.Columns)
+	}
+
+	x := &Matrix{}
+	x.Product(p.basis, yMat)
+	x.Add(x, p.mean)
+
+	return m.CopyFrom(x)
+}
+
+// Components returns a new Matrix holding the principal components as its columns, or nil if Fit was not called yet.
+func (p *PCA) Components() *Matrix {
+	if p.basis == nil {
+		return nil
+	}
+
+	b, _ := Copy(p.basis)
+	return b
+}
+
+// ExplainedVariance returns the variance of the training samples along each of the principal components, in descending order.
+func (p *PCA) ExplainedVariance() []float64 {
+	return append([]float64(nil), p.variances...)
+}
@@ -0,0 +1,103 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestNewPCA(t *testing.T) {
+	if _, err := NewPCA(0); err != ErrBadComponents {
+		t.Errorf("Expected error is %v, but got %v", ErrBadComponents, err)
+	}
+
+	if p, err := NewPCA(2); err != nil || p == nil {
+		t.Errorf("Expected error is %v, but got %v", nil, err)
+	}
+}
+
+func TestPCA(t *testing.T) {
+	// Four samples on the "y = 2x + 1" line, one sample per column.
+	x := &Matrix{Values: []float64{-1, 0, 1, 2, -1, 1, 3, 5}, Rows: 2, Columns: 4}
+
+	t.Run("Fit", func(t *testing.T) {
+		t.Parallel()
+
+		p, _ := NewPCA(1)
+		if err := p.Fit(x); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		c := p.Components()
+		direction := []float64{1 / math.Sqrt(5), 2 / math.Sqrt(5)}
+		if c.Values[0] < 0 {
+			c.Scale(-1, c)
+		}
+
+		if !isApproxEqual(c.Values, direction, 1e-12) {
+			t.Errorf("Expected component is %v, but got %v", direction, c.Values)
+		}
+
+		// The projections are -1.5, -0.5, 0.5, 1.5 times the length of the direction vector (sqrt(5)).
+		variance := []float64{5 * 1.25}
+		if !isApproxEqual(p.ExplainedVariance(), variance, 1e-12) {
+			t.Errorf("Expected explained variance is %v, but got %v", variance, p.ExplainedVariance())
+		}
+	})
+
+	t.Run("Transform", func(t *testing.T) {
+		t.Parallel()
+
+		p, _ := NewPCA(1)
+		p.Fit(x)
+
+		y, back := &Matrix{}, &Matrix{}
+		if err := p.Transform(y, x); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if y.Rows != 1 || y.Columns != 4 {
+			t.Fatalf("Expected dimensions are %dx%d, but got %dx%d", 1, 4, y.Rows, y.Columns)
+		}
+
+		// All the samples are on a line, so a single component reconstructs them.
+		if err := p.InverseTransform(back, y); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if !isApproxEqual(back.Values, x.Values, 1e-12) {
+			t.Errorf("Expected reconstruction is %v, but got %v", x.Values, back.Values)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		p, _ := NewPCA(1)
+		if err := p.Transform(&Matrix{}, x); err != ErrNotFitted {
+			t.Errorf("Expected error is %v, but got %v", ErrNotFitted, err)
+		}
+
+		if err := p.InverseTransform(&Matrix{}, x); err != ErrNotFitted {
+			t.Errorf("Expected error is %v, but got %v", ErrNotFitted, err)
+		}
+
+		if err := p.Fit(nil); err != ErrNilMatrix {
+			t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+		}
+
+		p.Fit(x)
+		if err := p.Transform(&Matrix{}, &Matrix{Values: []float64{1, 2, 3}, Rows: 3, Columns: 1}); !errors.Is(err, ErrFeatureDimension) {
+			t.Errorf("Expected error is %v, but got %v", ErrFeatureDimension, err)
+		}
+
+		if err := p.InverseTransform(&Matrix{}, x); !errors.Is(err, ErrFeatureDimension) {
+			t.Errorf("Expected error is %v, but got %v", ErrFeatureDimension, err)
+		}
+
+		big, _ := NewPCA(3)
+		if err := big.Fit(x); err != ErrBadComponents {
+			t.Errorf("Expected error is %v, but got %v", ErrBadComponents, err)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package matrix
+
+import "sync"
+
+// Pool keeps the scratch matrices that are no longer used, so the next Get with the same dimensions can reuse them instead of allocating new ones.
+// It is meant for the short-lived matrices of a training loop, which would otherwise be garbage after every sample.
+// The zero value is an empty pool ready to use, and it is safe for concurrent use.
+type Pool struct {
+	mu   sync.Mutex
+	free map[[2]int][]*Matrix
+}
+
+// Get borrows an "r x c" matrix from the pool, or allocates a new one if the pool has no matrix with these dimensions.
+// The elements of a reused matrix are not cleared, so every element has to be written before it is read, e.g. by using the matrix as the receiver of an operation.
+// The matrix should be returned by Put when it is no longer used.
+// It will return an error if "r <= 0" or "c <= 0".
+func (p *Pool) Get(r, c int) (*Matrix, error) {
+	if r <= 0 {
+		return nil, ErrZeroRow
+	}
+
+	if c <= 0 {
+		return nil, ErrZeroCol
+	}
+
+	key := [2]int{r, c}
+
+	p.mu.Lock()
+	free := p.free[key]
+	if len(free) > 0 {
+		m := free[len(free)-1]
+		free[len(free)-1] = nil
+		p.free[key] = free[:len(free)-1]
+		p.mu.Unlock()
+
+		return m, nil
+	}
+	p.mu.Unlock()
+
+	return New(r, c, nil)
+}
+
+// Put returns "m" to the pool, so a later Get with the same dimensions can reuse it, "m" must not be used after that.
+// The backend of the matrix is reset to the global backend.
+// Views and nil matrices are ignored, since their elements are owned by an other matrix.
+//
+// A matrix must be put back only once per Get. Putting back a matrix that is still in the pool is detected and ignored,
+// but once a later Get has handed it out again, a second Put returns the borrowed matrix to the pool,
+// and the next Get gives the same elements to two borrowers that overwrite each other.
+func (p *Pool) Put(m *Matrix) {
+	if m == nil || m.IsView() || m.Rows <= 0 || m.Columns <= 0 {
+		return
+	}
+
+	m.backend = nil
+	key := [2]int{m.Rows, m.Columns}
+
+	p.mu.Lock()
+	if p.free == nil {
+		p.free = make(map[[2]int][]*Matrix)
+	}
+
+	for _, f := range p.free[key] {
+		if f == m {
+			p.mu.Unlock()
+			return
+		}
+	}
+
+	p.free[key] = append(p.free[key], m)
+	p.mu.Unlock()
+}
@@ -0,0 +1,141 @@
+package matrix
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestPoolGet(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rows, columns int
+		expectedError error
+	}{
+		{"Normal", 2, 3, nil},
+		{"ErrZeroRow", 0, 3, ErrZeroRow},
+		{"ErrZeroCol", 2, 0, ErrZeroCol},
+		{"Negative rows", -1, 3, ErrZeroRow},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := &Pool{}
+			m, err := p.Get(tc.rows, tc.columns)
+			if err != tc.expectedError {
+				t.Fatalf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if m.Rows != tc.rows || m.Columns != tc.columns || len(m.Values) != tc.rows*tc.columns {
+				t.Errorf("Expected dimensions are %dx%d, but got %dx%d with %d elements", tc.rows, tc.columns, m.Rows, m.Columns, len(m.Values))
+			}
+		})
+	}
+}
+
+func TestPoolReuse(t *testing.T) {
+	p := &Pool{}
+	a, _ := p.Get(2, 3)
+	a.SetBackend(newCountingBackend())
+	p.Put(a)
+
+	if b, _ := p.Get(3, 2); b == a {
+		t.Error("A matrix with different dimensions should not be reused")
+	}
+
+	b, _ := p.Get(2, 3)
+	if b != a {
+		t.Error("The returned matrix should be reused")
+	}
+
+	if b.backend != nil {
+		t.Errorf("Expected backend is %v, but got %v", nil, b.backend)
+	}
+
+	if c, _ := p.Get(2, 3); c == a {
+		t.Error("A borrowed matrix should not be returned twice")
+	}
+}
+
+func TestPoolPut_ignored(t *testing.T) {
+	parent, _ := New(4, 4, nil)
+	view, _ := parent.Slice(0, 2, 0, 2)
+
+	p := &Pool{}
+	p.Put(nil)
+	p.Put(view)
+	p.Put(&Matrix{})
+
+	if m, _ := p.Get(2, 2); m == view {
+		t.Error("A view should not be reused")
+	}
+
+	if len(p.free) != 0 {
+		t.Errorf("Expected number of shapes is %d, but got %d", 0, len(p.free))
+	}
+}
+
+func TestPoolPut_twice(t *testing.T) {
+	p := &Pool{}
+	a, _ := p.Get(2, 3)
+	p.Put(a)
+	p.Put(a)
+
+	if n := len(p.free[[2]int{2, 3}]); n != 1 {
+		t.Errorf("Expected number of free matrices is %d, but got %d", 1, n)
+	}
+
+	b, _ := p.Get(2, 3)
+	if c, _ := p.Get(2, 3); b == c {
+		t.Error("A matrix put back twice should not be borrowed twice")
+	}
+}
+
+func TestPool_allocations(t *testing.T) {
+	p := &Pool{}
+	m, _ := p.Get(16, 16)
+	p.Put(m)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		m, _ := p.Get(16, 16)
+		p.Put(m)
+	})
+
+	if allocs != 0 {
+		t.Errorf("Expected number of allocations is %d, but got %f", 0, allocs)
+	}
+}
+
+func TestPool_concurrent(t *testing.T) {
+	p := &Pool{}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m, _ := p.Get(g%3+1, 2)
+				for idx := range m.Values {
+					m.Values[idx] = float64(g)
+				}
+
+				for _, v := range m.Values {
+					if v != float64(g) {
+						t.Errorf("Expected element is %v, but got %v", float64(g), v)
+						return
+					}
+				}
+
+				p.Put(m)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,132 @@
+package matrix
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// productBlockSize is the edge length of the square tiles that Product works on,
+// a tile of each operand has to fit in the L1 cache together.
+const productBlockSize = 64
+
+var (
+	// productWorkers is the maximum number of goroutines used by Product.
+	productWorkers = int64(runtime.GOMAXPROCS(0))
+
+	// productThreshold is the number of multiply-add operations below which Product stays single-threaded.
+	productThreshold int64 = 1 << 18
+)
+
+// SetProductWorkers sets the maximum number of goroutines used by Product, and returns the previous value.
+// If "n < 1", Product will use a single goroutine.
+func SetProductWorkers(n int) int {
+	if n < 1 {
+		n = 1
+	}
+
+	return int(atomic.SwapInt64(&productWorkers, int64(n)))
+}
+
+// SetProductThreshold sets the number of multiply-add operations ("rows of a * columns of a * columns of b")
+// below which Product stays single-threaded, and returns the previous value.
+func SetProductThreshold(n int) int {
+	return int(atomic.SwapInt64(&productThreshold, int64(n)))
+}
+
+// product is the kernel of Product, the receiver must not alias any of the operands.
+// The rows of the result are split into bands of "productBlockSize" rows, and the bands are distributed between the workers,
+// so each element is calculated by exactly one goroutine and the order of the additions is the same as in a naive triple loop.
+// The receiver must already have the dimensions of the result, or it must be a non-view matrix.
+func (m *Matrix) product(aMat, bMat *Matrix) {
+	m.reuse(aMat.Rows, bMat.Columns)
+	for r := 0; r < m.Rows; r++ {
+		mRow := m.row(r)
+		for idx := range mRow {
+			mRow[idx] = 0
+		}
+	}
+
+	workers, bands := productPlan(m.Rows, int64(aMat.Rows)*int64(aMat.Columns)*int64(bMat.Columns))
+	if workers <= 1 {
+		productBlock(m, aMat, bMat, 0, m.Rows)
+		return
+	}
+
+	productParallel(m.Rows, workers, bands, func(r0, r1 int) {
+		productBlock(m, aMat, bMat, r0, r1)
+	})
+}
+
+// productPlan returns the number of workers and the number of row bands of a product with "rows" rows in the result and "ops" multiply-add operations.
+func productPlan(rows int, ops int64) (int, int) {
+	bands := (rows + productBlockSize - 1) / productBlockSize
+	workers := int(atomic.LoadInt64(&productWorkers))
+	if workers > bands {
+		workers = bands
+	}
+
+	if ops < atomic.LoadInt64(&productThreshold) {
+		workers = 1
+	}
+
+	return workers, bands
+}
+
+// productParallel distributes the "bands" row bands of a result with "rows" rows between "workers" goroutines,
+// and calls "block" with the rows "r0 <= r < r1" of each band.
+func productParallel(rows, workers, bands int, block func(r0, r1 int)) {
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func(w int) {
+			defer wg.Done()
+			for band := w; band < bands; band += workers {
+				r1 := (band + 1) * productBlockSize
+				if r1 > rows {
+					r1 = rows
+				}
+
+				block(band*productBlockSize, r1)
+			}
+		}(w)
+	}
+	wg.Wait()
+}
+
+// productBlock accumulates the rows "r0 <= r < r1" of "a * b" into "m", tile by tile.
+func productBlock(m, aMat, bMat *Matrix, r0, r1 int) {
+	aCols, bCols := aMat.Columns, bMat.Columns
+	aStride, bStride, mStride := aMat.Stride(), bMat.Stride(), m.Stride()
+	aVals, bVals, mVals := aMat.Values, bMat.Values, m.Values
+
+	for i0 := r0; i0 < r1; i0 += productBlockSize {
+		i1 := minInt(i0+productBlockSize, r1)
+		for k0 := 0; k0 < aCols; k0 += productBlockSize {
+			k1 := minInt(k0+productBlockSize, aCols)
+			for j0 := 0; j0 < bCols; j0 += productBlockSize {
+				j1 := minInt(j0+productBlockSize, bCols)
+				for i := i0; i < i1; i++ {
+					mRow := mVals[i*mStride+j0 : i*mStride+j1]
+					aRow := aVals[i*aStride+k0 : i*aStride+k1]
+					for k, aVal := range aRow {
+						bRow := bVals[(k0+k)*bStride+j0 : (k0+k)*bStride+j1]
+						bRow = bRow[:len(mRow)]
+						for j, bVal := range bRow {
+							mRow[j] += aVal * bVal
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// minInt returns the smaller of "a" and "b".
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,74 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// naiveProduct is the reference implementation of the matrix multiplication.
+func naiveProduct(aMat, bMat *Matrix) *Matrix {
+	m, _ := New(aMat.Rows, bMat.Columns, nil)
+	for r := 0; r < aMat.Rows; r++ {
+		for c := 0; c < bMat.Columns; c++ {
+			for k := 0; k < aMat.Columns; k++ {
+				m.Values[r*m.Columns+c] += aMat.Values[r*aMat.Columns+k] * bMat.Values[k*bMat.Columns+c]
+			}
+		}
+	}
+
+	return m
+}
+
+func randomMatrix(r *rand.Rand, rows, cols int) *Matrix {
+	m, _ := New(rows, cols, nil)
+	for idx := range m.Values {
+		m.Values[idx] = r.Float64()*2 - 1
+	}
+
+	return m
+}
+
+func TestProduct_blocked(t *testing.T) {
+	defer SetProductWorkers(SetProductWorkers(1))
+	defer SetProductThreshold(SetProductThreshold(0))
+
+	type dimension struct {
+		aRows, aCols, bCols int
+	}
+	dimensions := []dimension{{1, 1, 1}, {3, 5, 2}, {64, 64, 64}, {65, 130, 67}, {200, 70, 129}, {1, 300, 1}, {300, 1, 300}}
+	workers := []int{1, 2, 3, 8}
+
+	for _, w := range workers {
+		SetProductWorkers(w)
+		for _, d := range dimensions {
+			r := rand.New(rand.NewSource(int64(d.aRows*d.aCols*d.bCols + w)))
+			aMat, bMat := randomMatrix(r, d.aRows, d.aCols), randomMatrix(r, d.aCols, d.bCols)
+			expected := naiveProduct(aMat, bMat)
+
+			m := &Matrix{}
+			if err := m.Product(aMat, bMat); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if m.Rows != expected.Rows || m.Columns != expected.Columns {
+				t.Fatalf("Expected dimensions are %dx%d, but got %dx%d", expected.Rows, expected.Columns, m.Rows, m.Columns)
+			}
+
+			for idx, v := range m.Values {
+				if math.Abs(v-expected.Values[idx]) > 1e-12 {
+					t.Fatalf("%d workers, %v: expected value is %f, but got %f", w, d, expected.Values[idx], v)
+				}
+			}
+		}
+	}
+}
+
+func TestSetProductWorkers(t *testing.T) {
+	prev := SetProductWorkers(0)
+	defer SetProductWorkers(prev)
+
+	if w := SetProductWorkers(4); w != 1 {
+		t.Errorf("Expected number of workers is %d, but got %d", 1, w)
+	}
+}
@@ -0,0 +1,119 @@
+package matrix
+
+import "math"
+
+// QR represents the thin QR decomposition of a "rows x columns" matrix "A", such that "A = Q * R",
+// where "Q" is a "rows x k" matrix with orthonormal columns, "R" is a "k x columns" upper triangular matrix and "k = min(rows, columns)".
+type QR struct {
+	q, r *Matrix
+}
+
+// NewQR computes the QR decomposition of "a" with Householder reflections, "a" is left unchanged.
+// It will return an error if "a == nil".
+func NewQR(aMat *Matrix) (*QR, error) {
+	if aMat == nil {
+		return nil, ErrNilMatrix
+	}
+
+	rows, cols := aMat.Rows, aMat.Columns
+	k := minInt(rows, cols)
+	r, _ := Copy(aMat)
+	v := make([]float64, rows)
+
+	// The "j"-th reflector is kept below the diagonal of the "j"-th column of "r", with its first element in "heads" and its squared norm in "norms",
+	// a zero norm means that the column needed no reflection.
+	heads, norms := make([]float64, k), make([]float64, k)
+
+	for j := 0; j < rows-1 && j < cols; j++ {
+		norm := 0.0
+		for idx := j; idx < rows; idx++ {
+			norm = math.Hypot(norm, r.Values[idx*cols+j])
+		}
+
+		if norm == 0 {
+			continue
+		}
+
+		// The reflection maps the "j"-th column below the diagonal onto "alpha * e1", the sign of "alpha" avoids cancellation.
+		alpha := -math.Copysign(norm, r.Values[j*cols+j])
+		hv := v[:rows-j]
+		for idx := range hv {
+			hv[idx] = r.Values[(j+idx)*cols+j]
+		}
+		hv[0] -= alpha
+
+		vv := 0.0
+		for _, x := range hv {
+			vv += x * x
+		}
+
+		for c := j + 1; c < cols; c++ {
+			f := 0.0
+			for idx, x := range hv {
+				f += x * r.Values[(j+idx)*cols+c]
+			}
+
+			f = 2 * f / vv
+			for idx, x := range hv {
+				r.Values[(j+idx)*cols+c] -= f * x
+			}
+		}
+
+		r.Values[j*cols+j] = alpha
+		heads[j], norms[j] = hv[0], vv
+	}
+
+	// "Q" is the product of the reflectors applied to the first "k" columns of the identity, in reverse order,
+	// so only the "rows x k" thin factor is ever stored.
+	q, _ := New(rows, k, nil)
+	for idx := 0; idx < k; idx++ {
+		q.Values[idx*k+idx] = 1
+	}
+
+	for j := k - 1; j >= 0; j-- {
+		if norms[j] == 0 {
+			continue
+		}
+
+		hv := v[:rows-j]
+		hv[0] = heads[j]
+		for idx := 1; idx < len(hv); idx++ {
+			hv[idx] = r.Values[(j+idx)*cols+j]
+		}
+
+		for c := j; c < k; c++ {
+			f := 0.0
+			for idx, x := range hv {
+				f += x * q.Values[(j+idx)*k+c]
+			}
+
+			f = 2 * f / norms[j]
+			for idx, x := range hv {
+				q.Values[(j+idx)*k+c] -= f * x
+			}
+		}
+	}
+
+	rThin, _ := r.Slice(0, k, 0, cols)
+	rThin, _ = Copy(rThin)
+	for row := 1; row < k; row++ {
+		rRow := rThin.row(row)
+		for c := 0; c < row && c < cols; c++ {
+			rRow[c] = 0
+		}
+	}
+
+	return &QR{q, rThin}, nil
+}
+
+// Q returns a new Matrix holding the orthonormal factor of the decomposition.
+func (d *QR) Q() *Matrix {
+	q, _ := Copy(d.q)
+	return q
+}
+
+// R returns a new Matrix holding the upper triangular factor of the decomposition.
+func (d *QR) R() *Matrix {
+	r, _ := Copy(d.r)
+	return r
+}
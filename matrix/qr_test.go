@@ -0,0 +1,98 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// isOrthonormal reports whether the columns of "m" are orthonormal.
+func isOrthonormal(m *Matrix, tol float64) bool {
+	mT, product := &Matrix{}, &Matrix{}
+	mT.Transpose(m)
+	product.Product(mT, m)
+	i, _ := Identity(m.Columns)
+
+	return isApproxEqual(product.Values, i.Values, tol)
+}
+
+func TestNewQR(t *testing.T) {
+	testCases := []struct {
+		name          string
+		matrix        *Matrix
+		expectedR     []float64
+		expectedError error
+	}{
+		{"Square", &Matrix{Values: []float64{12, -51, 4, 6, 167, -68, -4, 24, -41}, Rows: 3, Columns: 3}, []float64{14, 21, 14, 0, 175, 70, 0, 0, 35}, nil},
+		{"Tall", &Matrix{Values: []float64{3, 0, 4, 0, 0, 2}, Rows: 3, Columns: 2}, []float64{5, 0, 0, 2}, nil},
+		{"Wide", &Matrix{Values: []float64{3, 1, 4, 2}, Rows: 2, Columns: 2}, []float64{5, 2.2, 0, 0.4}, nil},
+		{"Zero column", &Matrix{Values: []float64{0, 1, 0, 1}, Rows: 2, Columns: 2}, []float64{0, 1, 0, 1}, nil},
+		{"ErrNilMatrix", nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := NewQR(tc.matrix)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				q, r := d.Q(), d.R()
+				absR := make([]float64, len(r.Values))
+				for idx, v := range r.Values {
+					absR[idx] = math.Abs(v)
+				}
+
+				// The signs of the rows of R depend on the reflections, so only the magnitudes are compared.
+				if !isApproxEqual(absR, tc.expectedR, 1e-12) {
+					t.Errorf("Expected |R| is %v, but got %v", tc.expectedR, absR)
+				}
+
+				qr := &Matrix{}
+				qr.Product(q, r)
+				if !isApproxEqual(qr.Values, tc.matrix.Values, 1e-12) {
+					t.Errorf("Expected Q * R is %v, but got %v", tc.matrix.Values, qr.Values)
+				}
+			}
+		})
+	}
+
+	t.Run("Random", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(0))
+		for _, dim := range [][2]int{{1, 1}, {5, 3}, {3, 5}, {20, 20}, {200, 3}} {
+			aMat := randomMatrix(r, dim[0], dim[1])
+			d, _ := NewQR(aMat)
+			q, rMat := d.Q(), d.R()
+			k := minInt(dim[0], dim[1])
+			if q.Rows != dim[0] || q.Columns != k || rMat.Rows != k || rMat.Columns != dim[1] {
+				t.Fatalf("Unexpected dimensions %dx%d and %dx%d for a %dx%d matrix", q.Rows, q.Columns, rMat.Rows, rMat.Columns, dim[0], dim[1])
+			}
+
+			if !isOrthonormal(q, 1e-12) {
+				t.Errorf("Columns of Q should be orthonormal for a %dx%d matrix", dim[0], dim[1])
+			}
+
+			for row := 0; row < rMat.Rows; row++ {
+				for c := 0; c < row; c++ {
+					if v := rMat.Values[row*rMat.Columns+c]; v != 0 {
+						t.Errorf("Expected value below the diagonal of R is %f, but got %f", 0.0, v)
+					}
+				}
+			}
+
+			qr := &Matrix{}
+			qr.Product(q, rMat)
+			if !isApproxEqual(qr.Values, aMat.Values, 1e-12) {
+				t.Errorf("Expected Q * R to be A for a %dx%d matrix", dim[0], dim[1])
+			}
+		}
+	})
+}
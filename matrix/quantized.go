@@ -0,0 +1,245 @@
+package matrix
+
+import "math"
+
+// Granularity selects how many scales and zero points a Quantized matrix has.
+type Granularity int
+
+const (
+	// PerTensor quantizes the whole matrix with a single scale and zero point.
+	PerTensor Granularity = iota
+
+	// PerRow quantizes each row with its own scale and zero point, which is more precise when the magnitudes of the rows differ, e.g. the weights of different nodes.
+	PerRow
+)
+
+// maxQuantizedInner is the largest number of multiply-adds that always fit into the int32 accumulator of QuantizedProduct,
+// the product of two zero point adjusted elements is at most "255 * 255".
+const maxQuantizedInner = math.MaxInt32 / (255 * 255)
+
+// Quantized represents a matrix with int8 elements, where each element "q" stands for the real value "scale * (q - zeroPoint)".
+// The scale and the zero point either belong to the whole matrix, or to the row of the element (see Granularity).
+type Quantized struct {
+	Values      []int8      // Values of the matrix in row-major order
+	Rows        int         // Number of rows
+	Columns     int         // Number of columns
+	Granularity Granularity // Granularity of the scales and the zero points
+
+	Scales     []float64 // Scales, one for the whole matrix or one for each row
+	ZeroPoints []int8    // Zero points, one for the whole matrix or one for each row
+}
+
+// NewQuantized creates a new Quantized matrix with "r" rows and "c" columns from already quantized elements, e.g. the ones loaded from a file.
+// The slices are used without copying them, the "vals" must be arranged in row-major order,
+// "scales" and "zeroPoints" must have a single element for PerTensor, or one element for each row for PerRow.
+// It will return an error if "r <= 0", "c <= 0", the length of "vals" is not "r * c", "g" is not valid,
+// the lengths of "scales" and "zeroPoints" do not match "g", or any of the scales is not positive and finite.
+func NewQuantized(r, c int, vals []int8, g Granularity, scales []float64, zeroPoints []int8) (*Quantized, error) {
+	if r <= 0 {
+		return nil, ErrZeroRow
+	}
+
+	if c <= 0 {
+		return nil, ErrZeroCol
+	}
+
+	if len(vals) != r*c {
+		return nil, ErrDataLength
+	}
+
+	n, err := quantizationGroups(r, g)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(scales) != n || len(zeroPoints) != n {
+		return nil, ErrQuantizationParameters
+	}
+
+	for _, s := range scales {
+		if !(s > 0) || math.IsInf(s, 1) {
+			return nil, ErrQuantizationParameters
+		}
+	}
+
+	return &Quantized{vals, r, c, g, scales, zeroPoints}, nil
+}
+
+// quantizationGroups returns the number of scales of an "r" rows matrix with the "g" granularity.
+func quantizationGroups(r int, g Granularity) (int, error) {
+	switch g {
+	case PerTensor:
+		return 1, nil
+	case PerRow:
+		return r, nil
+	default:
+		return 0, ErrBadGranularity
+	}
+}
+
+// Quantize creates a new Quantized matrix from "m" with the "g" granularity, using asymmetric quantization.
+// The range of each group is extended to contain zero, so zero is always represented exactly,
+// and the "[min, max]" range is mapped onto "[-128, 127]", so the error of each element is at most half of the scale.
+// It will return an error if "m == nil", "g" is not valid, or any of the elements is NaN or infinite.
+func Quantize(m *Matrix, g Granularity) (*Quantized, error) {
+	if m == nil {
+		return nil, ErrNilMatrix
+	}
+
+	n, err := quantizationGroups(m.Rows, g)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.IsFinite() {
+		return nil, ErrQuantizeNotFinite
+	}
+
+	q := &Quantized{make([]int8, m.Rows*m.Columns), m.Rows, m.Columns, g, make([]float64, n), make([]int8, n)}
+	rowsPerGroup := m.Rows / n
+	for grp := 0; grp < n; grp++ {
+		r0, r1 := grp*rowsPerGroup, (grp+1)*rowsPerGroup
+
+		min, max := 0.0, 0.0
+		for r := r0; r < r1; r++ {
+			for _, v := range m.row(r) {
+				min, max = math.Min(min, v), math.Max(max, v)
+			}
+		}
+
+		scale, zp := quantizationParameters(min, max)
+		q.Scales[grp], q.ZeroPoints[grp] = scale, zp
+
+		for r := r0; r < r1; r++ {
+			dst := q.Values[r*m.Columns : (r+1)*m.Columns]
+			for c, v := range m.row(r) {
+				dst[c] = quantize(v, scale, zp)
+			}
+		}
+	}
+
+	return q, nil
+}
+
+// quantizationParameters returns the scale and the zero point that map "[min, max]" onto "[-128, 127]", "min <= 0 <= max" must hold.
+func quantizationParameters(min, max float64) (float64, int8) {
+	if max == min {
+		return 1, 0
+	}
+
+	scale := (max - min) / 255
+	zp := math.Round(-128 - min/scale)
+	return scale, int8(math.Max(-128, math.Min(127, zp)))
+}
+
+// quantize returns the nearest quantized value of "v", saturated to the int8 range.
+func quantize(v, scale float64, zp int8) int8 {
+	q := math.Round(v/scale) + float64(zp)
+	return int8(math.Max(-128, math.Min(127, q)))
+}
+
+// group returns the index of the scale and the zero point of the "r"-th row.
+func (q *Quantized) group(r int) int {
+	if q.Granularity == PerRow {
+		return r
+	}
+
+	return 0
+}
+
+// At returns the real value of the element at row "r", column "c", the indexing is the same as in Matrix.At.
+// It will return an error if "r" or "c" is out of bounds.
+func (q *Quantized) At(r, c int) (float64, error) {
+	if r < 0 || r > q.Rows-1 {
+		return 0, ErrRowOutOfBounds
+	}
+
+	if c < 0 || c > q.Columns-1 {
+		return 0, ErrColOutOfBounds
+	}
+
+	grp := q.group(r)
+	return q.Scales[grp] * float64(int32(q.Values[r*q.Columns+c])-int32(q.ZeroPoints[grp])), nil
+}
+
+// Dequantize sets the receiver to the real values of "qMat".
+// It will return an error if "qMat == nil", or the receiver is a view with different dimensions.
+func (m *Matrix) Dequantize(qMat *Quantized) error {
+	if qMat == nil {
+		return ErrNilMatrix
+	}
+
+	if err := m.reuse(qMat.Rows, qMat.Columns); err != nil {
+		return err
+	}
+
+	for r := 0; r < m.Rows; r++ {
+		grp := qMat.group(r)
+		scale, zp := qMat.Scales[grp], int32(qMat.ZeroPoints[grp])
+		src := qMat.Values[r*qMat.Columns : (r+1)*qMat.Columns]
+		dst := m.row(r)
+		for c, v := range src[:len(dst)] {
+			dst[c] = scale * float64(int32(v)-zp)
+		}
+	}
+
+	return nil
+}
+
+// QuantizedProduct performs matrix multiplication of the quantized "a" and "b", placing the real result in the receiver.
+// The zero point adjusted elements are multiplied and accumulated in int32, and each accumulator is scaled back to float64 once,
+// so "a" can have any granularity, e.g. the weights of a layer quantized PerRow, but "b" must be quantized PerTensor, e.g. the inputs.
+// It will return an error if "aMat == nil" or "bMat == nil", the number of columns in "a" not equal with the number of rows in "b",
+// "b" is not quantized PerTensor, or the number of columns in "a" is so large that the int32 accumulator could overflow.
+// It will also return an error if the receiver is a view with different dimensions.
+func (m *Matrix) QuantizedProduct(aMat, bMat *Quantized) error {
+	if aMat == nil || bMat == nil {
+		return ErrNilMatrix
+	}
+
+	if aMat.Columns != bMat.Rows {
+		return newShapeError("QuantizedProduct", ErrBadProductDimension, aMat.Columns, bMat.Columns, bMat.Rows, bMat.Columns)
+	}
+
+	if bMat.Granularity != PerTensor {
+		return ErrBadGranularity
+	}
+
+	if aMat.Columns > maxQuantizedInner {
+		return ErrQuantizedOverflow
+	}
+
+	if err := m.reuse(aMat.Rows, bMat.Columns); err != nil {
+		return err
+	}
+
+	bZp, bScale := int32(bMat.ZeroPoints[0]), bMat.Scales[0]
+	acc := make([]int32, bMat.Columns)
+	for r := 0; r < aMat.Rows; r++ {
+		for idx := range acc {
+			acc[idx] = 0
+		}
+
+		grp := aMat.group(r)
+		aZp := int32(aMat.ZeroPoints[grp])
+		for k, aVal := range aMat.Values[r*aMat.Columns : (r+1)*aMat.Columns] {
+			a := int32(aVal) - aZp
+			if a == 0 {
+				continue
+			}
+
+			bRow := bMat.Values[k*bMat.Columns : (k+1)*bMat.Columns]
+			for j, bVal := range bRow {
+				acc[j] += a * (int32(bVal) - bZp)
+			}
+		}
+
+		scale := aMat.Scales[grp] * bScale
+		mRow := m.row(r)
+		for j, v := range acc {
+			mRow[j] = scale * float64(v)
+		}
+	}
+
+	return nil
+}
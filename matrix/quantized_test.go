@@ -0,0 +1,241 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// skewedMatrix returns a random matrix where the magnitude of the "r"-th row is "2**r", so the rows need different scales.
+func skewedMatrix(rnd *rand.Rand, rows, cols int) *Matrix {
+	m := randomMatrix(rnd, rows, cols)
+	for r := 0; r < rows; r++ {
+		for c := range m.row(r) {
+			m.row(r)[c] *= math.Pow(2, float64(r%8))
+		}
+	}
+
+	return m
+}
+
+// relativeError returns the Frobenius norm of "a - b" relative to the Frobenius norm of "b".
+func relativeError(a, b *Matrix) float64 {
+	d := &Matrix{}
+	d.Subtract(a, b)
+	return d.FrobeniusNorm() / b.FrobeniusNorm()
+}
+
+func TestNewQuantized(t *testing.T) {
+	testCases := []struct {
+		name          string
+		rows, columns int
+		values        []int8
+		granularity   Granularity
+		scales        []float64
+		zeroPoints    []int8
+		expectedError error
+	}{
+		{"Per tensor", 2, 2, []int8{1, 2, 3, 4}, PerTensor, []float64{0.5}, []int8{0}, nil},
+		{"Per row", 2, 2, []int8{1, 2, 3, 4}, PerRow, []float64{0.5, 2}, []int8{0, -3}, nil},
+		{"ErrZeroRow", 0, 2, nil, PerTensor, []float64{1}, []int8{0}, ErrZeroRow},
+		{"ErrZeroCol", 2, 0, nil, PerTensor, []float64{1}, []int8{0}, ErrZeroCol},
+		{"ErrDataLength", 2, 2, []int8{1}, PerTensor, []float64{1}, []int8{0}, ErrDataLength},
+		{"ErrBadGranularity", 2, 2, []int8{1, 2, 3, 4}, Granularity(2), []float64{1}, []int8{0}, ErrBadGranularity},
+		{"Too few scales", 2, 2, []int8{1, 2, 3, 4}, PerRow, []float64{1}, []int8{0, 0}, ErrQuantizationParameters},
+		{"Too few zero points", 2, 2, []int8{1, 2, 3, 4}, PerRow, []float64{1, 1}, []int8{0}, ErrQuantizationParameters},
+		{"Zero scale", 2, 2, []int8{1, 2, 3, 4}, PerTensor, []float64{0}, []int8{0}, ErrQuantizationParameters},
+		{"NaN scale", 2, 2, []int8{1, 2, 3, 4}, PerTensor, []float64{math.NaN()}, []int8{0}, ErrQuantizationParameters},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := NewQuantized(tc.rows, tc.columns, tc.values, tc.granularity, tc.scales, tc.zeroPoints)
+			if err != tc.expectedError {
+				t.Fatalf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			grp := 0
+			if tc.granularity == PerRow {
+				grp = 1
+			}
+
+			expected := tc.scales[grp] * float64(int(tc.values[3])-int(tc.zeroPoints[grp]))
+			if v, _ := q.At(1, 1); v != expected {
+				t.Errorf("Expected element is %f, but got %f", expected, v)
+			}
+		})
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	rnd := rand.New(rand.NewSource(0))
+	m := skewedMatrix(rnd, 8, 32)
+
+	for _, g := range []Granularity{PerTensor, PerRow} {
+		q, err := Quantize(m, g)
+		if err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		d := &Matrix{}
+		if err := d.Dequantize(q); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		// Every element is within half of its scale.
+		for r := 0; r < m.Rows; r++ {
+			scale := q.Scales[q.group(r)]
+			for c := 0; c < m.Columns; c++ {
+				v, _ := m.At(r, c)
+				dv, _ := d.At(r, c)
+				if math.Abs(v-dv) > scale/2+1e-12 {
+					t.Errorf("Granularity %d: expected element %d, %d is within %g of %f, but got %f", g, r, c, scale/2, v, dv)
+				}
+
+				if qv, _ := q.At(r, c); qv != dv {
+					t.Errorf("Granularity %d: expected element is %f, but got %f", g, dv, qv)
+				}
+			}
+		}
+
+		t.Logf("Granularity %d: relative error of the dequantized matrix is %.3g", g, relativeError(d, m))
+	}
+}
+
+func TestQuantize_special(t *testing.T) {
+	// Zero is represented exactly, even when it is not in the range of the elements.
+	m, _ := New(2, 3, []float64{1, 2, 3, -6, -5, 0})
+	q, _ := Quantize(m, PerRow)
+	d := &Matrix{}
+	d.Dequantize(q)
+	if v, _ := d.At(1, 2); v != 0 {
+		t.Errorf("Expected element is %f, but got %f", 0.0, v)
+	}
+
+	// An all-zero matrix stays zero.
+	z, _ := New(2, 2, nil)
+	q, _ = Quantize(z, PerTensor)
+	d.Dequantize(q)
+	for _, v := range d.Values {
+		if v != 0 {
+			t.Errorf("Expected element is %f, but got %f", 0.0, v)
+		}
+	}
+
+	// The extremes of the range are mapped onto the extremes of int8.
+	e, _ := New(1, 2, []float64{-1, 3})
+	q, _ = Quantize(e, PerTensor)
+	if q.Values[0] != -128 || q.Values[1] != 127 {
+		t.Errorf("Expected values are %v, but got %v", []int8{-128, 127}, q.Values)
+	}
+
+	if _, err := Quantize(nil, PerTensor); err != ErrNilMatrix {
+		t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+	}
+
+	if _, err := Quantize(m, Granularity(-1)); err != ErrBadGranularity {
+		t.Errorf("Expected error is %v, but got %v", ErrBadGranularity, err)
+	}
+
+	m.Set(0, 0, math.Inf(1))
+	if _, err := Quantize(m, PerTensor); err != ErrQuantizeNotFinite {
+		t.Errorf("Expected error is %v, but got %v", ErrQuantizeNotFinite, err)
+	}
+
+	if err := d.Dequantize(nil); err != ErrNilMatrix {
+		t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+	}
+}
+
+// TestQuantizedProduct compares the quantized product with the float64 product, and reports the difference of the two.
+func TestQuantizedProduct(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	testCases := []struct {
+		name        string
+		a, b        *Matrix
+		granularity Granularity
+		maxError    float64
+	}{
+		{"Uniform per tensor", randomMatrix(rnd, 16, 64), randomMatrix(rnd, 64, 8), PerTensor, 0.02},
+		{"Uniform per row", randomMatrix(rnd, 16, 64), randomMatrix(rnd, 64, 8), PerRow, 0.02},
+		{"Skewed per tensor", skewedMatrix(rnd, 16, 64), randomMatrix(rnd, 64, 8), PerTensor, 0.03},
+		{"Skewed per row", skewedMatrix(rnd, 16, 64), randomMatrix(rnd, 64, 8), PerRow, 0.02},
+		{"Vector", randomMatrix(rnd, 32, 128), randomMatrix(rnd, 128, 1), PerRow, 0.02},
+	}
+
+	errs := make(map[string]float64)
+	for _, tc := range testCases {
+		expected := &Matrix{}
+		expected.Product(tc.a, tc.b)
+
+		qa, _ := Quantize(tc.a, tc.granularity)
+		qb, _ := Quantize(tc.b, PerTensor)
+		m := &Matrix{}
+		if err := m.QuantizedProduct(qa, qb); err != nil {
+			t.Fatalf("%s: expected error is %v, but got %v", tc.name, nil, err)
+		}
+
+		if m.Rows != expected.Rows || m.Columns != expected.Columns {
+			t.Fatalf("%s: expected dimensions are %dx%d, but got %dx%d", tc.name, expected.Rows, expected.Columns, m.Rows, m.Columns)
+		}
+
+		// The quantized product is exactly the product of the dequantized operands, up to the rounding of the float64 path.
+		da, db, dExpected := &Matrix{}, &Matrix{}, &Matrix{}
+		da.Dequantize(qa)
+		db.Dequantize(qb)
+		dExpected.Product(da, db)
+		if !EqualApprox(m, dExpected, 1e-9) {
+			t.Errorf("%s: the quantized product differs from the product of the dequantized operands", tc.name)
+		}
+
+		errs[tc.name] = relativeError(m, expected)
+		t.Logf("%s: relative error against the float64 product is %.3g", tc.name, errs[tc.name])
+		if errs[tc.name] > tc.maxError {
+			t.Errorf("%s: expected relative error is at most %g, but got %g", tc.name, tc.maxError, errs[tc.name])
+		}
+	}
+
+	if errs["Skewed per row"] >= errs["Skewed per tensor"] {
+		t.Errorf("Expected the per row error (%g) to be smaller than the per tensor error (%g)", errs["Skewed per row"], errs["Skewed per tensor"])
+	}
+}
+
+func TestQuantizedProduct_errors(t *testing.T) {
+	aMat, _ := New(2, 3, nil)
+	bMat, _ := New(3, 2, nil)
+	qa, _ := Quantize(aMat, PerRow)
+	qb, _ := Quantize(bMat, PerTensor)
+	qbRow, _ := Quantize(bMat, PerRow)
+	large := &Quantized{Rows: 1, Columns: maxQuantizedInner + 1}
+	largeB := &Quantized{Rows: maxQuantizedInner + 1, Columns: 1}
+
+	parent, _ := New(3, 3, nil)
+	view, _ := parent.Slice(0, 1, 0, 1)
+
+	testCases := []struct {
+		name          string
+		m             *Matrix
+		a, b          *Quantized
+		expectedError error
+	}{
+		{"Nil", &Matrix{}, nil, qb, ErrNilMatrix},
+		{"Dimensions", &Matrix{}, qa, qa, ErrBadProductDimension},
+		{"Granularity", &Matrix{}, qa, qbRow, ErrBadGranularity},
+		{"Overflow", &Matrix{}, large, largeB, ErrQuantizedOverflow},
+		{"View", view, qa, qb, ErrViewDimensions},
+	}
+
+	for _, tc := range testCases {
+		if err := tc.m.QuantizedProduct(tc.a, tc.b); !errors.Is(err, tc.expectedError) {
+			t.Errorf("%s: expected error is %v, but got %v", tc.name, tc.expectedError, err)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Distribution represents a probability distribution that the elements of a random matrix are drawn from.
+type Distribution interface {
+	// Sample draws a single number from the distribution, using "rnd" as the only source of randomness.
+	Sample(rnd *rand.Rand) float64
+}
+
+// Uniform is the continuous uniform distribution on the "[Min, Max)" interval.
+type Uniform struct {
+	Min, Max float64
+}
+
+// Sample implements the Distribution interface.
+func (d Uniform) Sample(rnd *rand.Rand) float64 {
+	return d.Min + (d.Max-d.Min)*rnd.Float64()
+}
+
+// Normal is the normal distribution with the mean "Mean" and the standard deviation "Std".
+type Normal struct {
+	Mean, Std float64
+}
+
+// Sample implements the Distribution interface.
+func (d Normal) Sample(rnd *rand.Rand) float64 {
+	return d.Mean + d.Std*rnd.NormFloat64()
+}
+
+// truncationLimit is the number of standard deviations from the mean beyond which TruncatedNormal samples are redrawn.
+const truncationLimit = 2
+
+// TruncatedNormal is the normal distribution with the mean "Mean" and the standard deviation "Std",
+// where the samples that are more than two standard deviations away from the mean are redrawn.
+type TruncatedNormal struct {
+	Mean, Std float64
+}
+
+// Sample implements the Distribution interface.
+func (d TruncatedNormal) Sample(rnd *rand.Rand) float64 {
+	z := rnd.NormFloat64()
+	for math.Abs(z) > truncationLimit {
+		z = rnd.NormFloat64()
+	}
+
+	return d.Mean + d.Std*z
+}
+
+// Bernoulli is the Bernoulli distribution, it is "1" with the probability "P", otherwise it is "0".
+type Bernoulli struct {
+	P float64
+}
+
+// Sample implements the Distribution interface.
+func (d Bernoulli) Sample(rnd *rand.Rand) float64 {
+	if rnd.Float64() < d.P {
+		return 1
+	}
+
+	return 0
+}
+
+// NewRandom creates a new Matrix with "r" rows and "c" columns, the elements are drawn from "d" in row-major order using "rnd".
+// The same seed of "rnd" always results in the same matrix.
+// It will return an error if "r <= 0", "c <= 0", "d == nil" or "rnd == nil".
+func NewRandom(r, c int, d Distribution, rnd *rand.Rand) (*Matrix, error) {
+	if d == nil {
+		return nil, ErrNilDistribution
+	}
+
+	if rnd == nil {
+		return nil, ErrNilRand
+	}
+
+	m, err := New(r, c, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range m.Values {
+		m.Values[idx] = d.Sample(rnd)
+	}
+
+	return m, nil
+}
+
+// NewOrthogonal creates a new Matrix with "r" rows and "c" columns, drawn uniformly from the matrices with orthonormal columns (if "r >= c"),
+// or orthonormal rows (if "r < c"), using "rnd".
+// The matrix is calculated from the QR decomposition of a matrix with standard normal elements.
+// It will return an error if "r <= 0", "c <= 0" or "rnd == nil".
+func NewOrthogonal(r, c int, rnd *rand.Rand) (*Matrix, error) {
+	if rnd == nil {
+		return nil, ErrNilRand
+	}
+
+	rows, cols := r, c
+	if r < c {
+		rows, cols = c, r
+	}
+
+	a, err := NewRandom(rows, cols, Normal{0, 1}, rnd)
+	if err != nil {
+		return nil, err
+	}
+
+	d, _ := NewQR(a)
+	q, rMat := d.q, d.r
+
+	// The signs of the columns of Q are fixed by the signs of the diagonal of R, so the result is uniformly distributed.
+	for k := 0; k < cols; k++ {
+		if rMat.Values[k*cols+k] < 0 {
+			for row := 0; row < rows; row++ {
+				q.Values[row*cols+k] = -q.Values[row*cols+k]
+			}
+		}
+	}
+
+	if r < c {
+		q.Transpose(q)
+	}
+
+	return q, nil
+}
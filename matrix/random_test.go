@@ -0,0 +1,132 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestNewRandom(t *testing.T) {
+	testCases := []struct {
+		name          string
+		distribution  Distribution
+		check         func(v float64) bool
+		mean, std     float64
+		expectedError error
+	}{
+		{"Uniform", Uniform{-2, 4}, func(v float64) bool { return v >= -2 && v < 4 }, 1, 6 / math.Sqrt(12), nil},
+		{"Normal", Normal{3, 2}, func(v float64) bool { return true }, 3, 2, nil},
+		{"TruncatedNormal", TruncatedNormal{-1, 0.5}, func(v float64) bool { return math.Abs(v+1) <= 1 }, -1, 0.5 * 0.8796, nil},
+		{"Bernoulli", Bernoulli{0.25}, func(v float64) bool { return v == 0 || v == 1 }, 0.25, math.Sqrt(0.25 * 0.75), nil},
+		{"ErrNilDistribution", nil, nil, 0, 0, ErrNilDistribution},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := NewRandom(200, 100, tc.distribution, rand.New(rand.NewSource(0)))
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			for _, v := range m.Values {
+				if !tc.check(v) {
+					t.Fatalf("Value %f is out of the support of the distribution", v)
+				}
+			}
+
+			if mean := m.Mean(); math.Abs(mean-tc.mean) > 0.05*math.Max(1, math.Abs(tc.mean)) {
+				t.Errorf("Expected mean is %f, but got %f", tc.mean, mean)
+			}
+
+			if std := math.Sqrt(m.Variance()); math.Abs(std-tc.std) > 0.05*tc.std {
+				t.Errorf("Expected standard deviation is %f, but got %f", tc.std, std)
+			}
+		})
+	}
+
+	t.Run("Seed", func(t *testing.T) {
+		t.Parallel()
+
+		a, _ := NewRandom(4, 3, Normal{0, 1}, rand.New(rand.NewSource(42)))
+		b, _ := NewRandom(4, 3, Normal{0, 1}, rand.New(rand.NewSource(42)))
+		for idx := range a.Values {
+			if a.Values[idx] != b.Values[idx] {
+				t.Fatalf("Expected values are %v, but got %v", a.Values, b.Values)
+			}
+		}
+	})
+
+	t.Run("ErrNilRand", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewRandom(2, 2, Uniform{0, 1}, nil); err != ErrNilRand {
+			t.Errorf("Expected error is %v, but got %v", ErrNilRand, err)
+		}
+	})
+
+	t.Run("ErrZeroRow", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := NewRandom(0, 2, Uniform{0, 1}, rand.New(rand.NewSource(0))); err != ErrZeroRow {
+			t.Errorf("Expected error is %v, but got %v", ErrZeroRow, err)
+		}
+	})
+}
+
+func TestNewOrthogonal(t *testing.T) {
+	testCases := []struct {
+		name          string
+		r, c          int
+		rand          *rand.Rand
+		expectedError error
+	}{
+		{"Square", 5, 5, rand.New(rand.NewSource(0)), nil},
+		{"Tall", 7, 3, rand.New(rand.NewSource(0)), nil},
+		{"Wide", 3, 7, rand.New(rand.NewSource(0)), nil},
+		{"ErrNilRand", 3, 3, nil, ErrNilRand},
+		{"ErrZeroCol", 3, 0, rand.New(rand.NewSource(0)), ErrZeroCol},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := NewOrthogonal(tc.r, tc.c, tc.rand)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if m.Rows != tc.r || m.Columns != tc.c {
+				t.Fatalf("Expected dimensions are %dx%d, but got %dx%d", tc.r, tc.c, m.Rows, m.Columns)
+			}
+
+			if tc.r < tc.c {
+				m.Transpose(m)
+			}
+
+			if !isOrthonormal(m, 1e-12) {
+				t.Errorf("Expected orthonormal vectors, but got %v", m.Values)
+			}
+		})
+	}
+}
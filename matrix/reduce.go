@@ -0,0 +1,215 @@
+package matrix
+
+import "math"
+
+// Axis selects the direction of a reduction.
+type Axis int
+
+const (
+	// ByRow reduces each row of the matrix into a single value, the result is a column vector with one element per row.
+	ByRow Axis = iota
+
+	// ByColumn reduces each column of the matrix into a single value, the result is a row vector with one element per column.
+	ByColumn
+)
+
+// each calls "fn" with every element of the matrix in row-major order.
+func (m *Matrix) each(fn func(r, c int, v float64)) {
+	for r := 0; r < m.Rows; r++ {
+		for c, v := range m.row(r) {
+			fn(r, c, v)
+		}
+	}
+}
+
+// Sum returns the sum of the elements of the matrix, the elements are added in row-major order.
+func (m *Matrix) Sum() float64 {
+	sum := 0.0
+	for r := 0; r < m.Rows; r++ {
+		for _, v := range m.row(r) {
+			sum += v
+		}
+	}
+
+	return sum
+}
+
+// Mean returns the arithmetic mean of the elements of the matrix, or NaN if the matrix has no elements.
+func (m *Matrix) Mean() float64 {
+	return m.Sum() / float64(m.Rows*m.Columns)
+}
+
+// Variance returns the population variance of the elements of the matrix, or NaN if the matrix has no elements.
+func (m *Matrix) Variance() float64 {
+	mean := m.Mean()
+	sum := 0.0
+	m.each(func(_, _ int, v float64) {
+		sum += (v - mean) * (v - mean)
+	})
+
+	return sum / float64(m.Rows*m.Columns)
+}
+
+// Max returns the largest element of the matrix, or "-Inf" if the matrix has no elements.
+// If any of the elements is NaN, it returns NaN.
+func (m *Matrix) Max() float64 {
+	max := math.Inf(-1)
+	m.each(func(_, _ int, v float64) {
+		max = math.Max(max, v)
+	})
+
+	return max
+}
+
+// Min returns the smallest element of the matrix, or "+Inf" if the matrix has no elements.
+// If any of the elements is NaN, it returns NaN.
+func (m *Matrix) Min() float64 {
+	min := math.Inf(1)
+	m.each(func(_, _ int, v float64) {
+		min = math.Min(min, v)
+	})
+
+	return min
+}
+
+// ArgMax returns the row and the column of the largest element of the matrix, the first one in row-major order if there are more.
+// NaN elements are ignored, it returns "-1, -1" if the matrix has no elements or all of them are NaN.
+func (m *Matrix) ArgMax() (int, int) {
+	return m.arg(func(v, best float64) bool { return v > best })
+}
+
+// ArgMin returns the row and the column of the smallest element of the matrix, the first one in row-major order if there are more.
+// NaN elements are ignored, it returns "-1, -1" if the matrix has no elements or all of them are NaN.
+func (m *Matrix) ArgMin() (int, int) {
+	return m.arg(func(v, best float64) bool { return v < best })
+}
+
+// arg returns the position of the non-NaN element for which "better" reports true against every other, earlier elements win ties.
+func (m *Matrix) arg(better func(v, best float64) bool) (int, int) {
+	bestR, bestC, best := -1, -1, math.NaN()
+	m.each(func(r, c int, v float64) {
+		if math.IsNaN(v) {
+			return
+		}
+
+		if bestR == -1 || better(v, best) {
+			bestR, bestC, best = r, c, v
+		}
+	})
+
+	return bestR, bestC
+}
+
+// reduce applies "fn" to each row or column of "aMat", as selected by "axis", placing the results in the receiver.
+// The "fn" function is called with a Matrix view of the row or column.
+func (m *Matrix) reduce(axis Axis, aMat *Matrix, fn func(v *Matrix) float64) error {
+	if aMat == nil {
+		return ErrNilMatrix
+	}
+
+	var r, c, n int
+	switch axis {
+	case ByRow:
+		r, c, n = aMat.Rows, 1, aMat.Rows
+	case ByColumn:
+		r, c, n = 1, aMat.Columns, aMat.Columns
+	default:
+		return ErrBadAxis
+	}
+
+	if aliases(m.Values, aMat.Values) {
+		aMat, _ = Copy(aMat)
+	}
+
+	if err := m.reuse(r, c); err != nil {
+		return err
+	}
+
+	// The result is a vector, so its elements are "stride" apart in a column, and next to each other in a row.
+	stride := m.Stride()
+	for idx := 0; idx < n; idx++ {
+		if axis == ByRow {
+			v, _ := aMat.Row(idx)
+			m.Values[idx*stride] = fn(v)
+		} else {
+			v, _ := aMat.Col(idx)
+			m.Values[idx] = fn(v)
+		}
+	}
+
+	return nil
+}
+
+// SumAxis sums the elements of each row or column of "aMat", as selected by "axis", placing the result in the receiver.
+// The receiver will be a "rows x 1" matrix for ByRow, and a "1 x columns" matrix for ByColumn.
+// It will return an error if "a == nil", or "axis" is neither ByRow nor ByColumn.
+func (m *Matrix) SumAxis(axis Axis, aMat *Matrix) error {
+	return m.reduce(axis, aMat, (*Matrix).Sum)
+}
+
+// MeanAxis calculates the mean of each row or column of "aMat", as selected by "axis", placing the result in the receiver.
+// The receiver will have the same dimensions as in SumAxis.
+// It will return an error if "a == nil", or "axis" is neither ByRow nor ByColumn.
+func (m *Matrix) MeanAxis(axis Axis, aMat *Matrix) error {
+	return m.reduce(axis, aMat, (*Matrix).Mean)
+}
+
+// VarianceAxis calculates the population variance of each row or column of "aMat", as selected by "axis", placing the result in the receiver.
+// The receiver will have the same dimensions as in SumAxis.
+// It will return an error if "a == nil", or "axis" is neither ByRow nor ByColumn.
+func (m *Matrix) VarianceAxis(axis Axis, aMat *Matrix) error {
+	return m.reduce(axis, aMat, (*Matrix).Variance)
+}
+
+// MaxAxis finds the largest element of each row or column of "aMat", as selected by "axis", placing the result in the receiver.
+// The receiver will have the same dimensions as in SumAxis.
+// It will return an error if "a == nil", or "axis" is neither ByRow nor ByColumn.
+func (m *Matrix) MaxAxis(axis Axis, aMat *Matrix) error {
+	return m.reduce(axis, aMat, (*Matrix).Max)
+}
+
+// MinAxis finds the smallest element of each row or column of "aMat", as selected by "axis", placing the result in the receiver.
+// The receiver will have the same dimensions as in SumAxis.
+// It will return an error if "a == nil", or "axis" is neither ByRow nor ByColumn.
+func (m *Matrix) MinAxis(axis Axis, aMat *Matrix) error {
+	return m.reduce(axis, aMat, (*Matrix).Min)
+}
+
+// ArgMaxAxis returns the index of the largest element in each row or column of the matrix, as selected by "axis".
+// For ByRow the indices are column indices, one for each row, for ByColumn they are row indices, one for each column.
+// NaN elements are ignored the same way as in ArgMax.
+// It will return an error if "axis" is neither ByRow nor ByColumn.
+func (m *Matrix) ArgMaxAxis(axis Axis) ([]int, error) {
+	return m.argAxis(axis, (*Matrix).ArgMax)
+}
+
+// ArgMinAxis returns the index of the smallest element in each row or column of the matrix, as selected by "axis".
+// The indices are arranged the same way as in ArgMaxAxis.
+// It will return an error if "axis" is neither ByRow nor ByColumn.
+func (m *Matrix) ArgMinAxis(axis Axis) ([]int, error) {
+	return m.argAxis(axis, (*Matrix).ArgMin)
+}
+
+// argAxis calls "fn" with a view of each row or column of the matrix, and collects the index it returns along the reduced dimension.
+func (m *Matrix) argAxis(axis Axis, fn func(v *Matrix) (int, int)) ([]int, error) {
+	switch axis {
+	case ByRow:
+		idxs := make([]int, m.Rows)
+		for r := range idxs {
+			v, _ := m.Row(r)
+			_, idxs[r] = fn(v)
+		}
+
+		return idxs, nil
+	case ByColumn:
+		idxs := make([]int, m.Columns)
+		for c := range idxs {
+			v, _ := m.Col(c)
+			idxs[c], _ = fn(v)
+		}
+
+		return idxs, nil
+	default:
+		return nil, ErrBadAxis
+	}
+}
@@ -0,0 +1,208 @@
+package matrix
+
+import (
+	"math"
+	"testing"
+)
+
+func TestReductions(t *testing.T) {
+	testCases := []struct {
+		name                               string
+		matrix                             *Matrix
+		sum, mean, variance, max, min      float64
+		argMaxR, argMaxC, argMinR, argMinC int
+	}{
+		{"Normal", &Matrix{Values: []float64{1, 5, -2, 4, 0, 1}, Rows: 2, Columns: 3}, 9, 1.5, 33.5 / 6, 5, -2, 0, 1, 0, 2},
+		{"Ties", &Matrix{Values: []float64{2, 2, 2, 2}, Rows: 2, Columns: 2}, 8, 2, 0, 2, 2, 0, 0, 0, 0},
+		{"Single", &Matrix{Values: []float64{-3}, Rows: 1, Columns: 1}, -3, -3, 0, -3, -3, 0, 0, 0, 0},
+		{"Empty", &Matrix{}, 0, math.NaN(), math.NaN(), math.Inf(-1), math.Inf(1), -1, -1, -1, -1},
+	}
+
+	equal := func(a, b float64) bool {
+		return (math.IsNaN(a) && math.IsNaN(b)) || math.Abs(a-b) <= 1e-12 || a == b
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if v := tc.matrix.Sum(); !equal(v, tc.sum) {
+				t.Errorf("Expected sum is %f, but got %f", tc.sum, v)
+			}
+
+			if v := tc.matrix.Mean(); !equal(v, tc.mean) {
+				t.Errorf("Expected mean is %f, but got %f", tc.mean, v)
+			}
+
+			if v := tc.matrix.Variance(); !equal(v, tc.variance) {
+				t.Errorf("Expected variance is %f, but got %f", tc.variance, v)
+			}
+
+			if v := tc.matrix.Max(); !equal(v, tc.max) {
+				t.Errorf("Expected max is %f, but got %f", tc.max, v)
+			}
+
+			if v := tc.matrix.Min(); !equal(v, tc.min) {
+				t.Errorf("Expected min is %f, but got %f", tc.min, v)
+			}
+
+			if r, c := tc.matrix.ArgMax(); r != tc.argMaxR || c != tc.argMaxC {
+				t.Errorf("Expected position of the max is %d, %d, but got %d, %d", tc.argMaxR, tc.argMaxC, r, c)
+			}
+
+			if r, c := tc.matrix.ArgMin(); r != tc.argMinR || c != tc.argMinC {
+				t.Errorf("Expected position of the min is %d, %d, but got %d, %d", tc.argMinR, tc.argMinC, r, c)
+			}
+		})
+	}
+
+	t.Run("NaN", func(t *testing.T) {
+		t.Parallel()
+
+		m := &Matrix{Values: []float64{math.NaN(), 1, 3, math.NaN()}, Rows: 2, Columns: 2}
+		if v := m.Max(); !math.IsNaN(v) {
+			t.Errorf("Expected max is %f, but got %f", math.NaN(), v)
+		}
+
+		if r, c := m.ArgMax(); r != 1 || c != 0 {
+			t.Errorf("Expected position of the max is %d, %d, but got %d, %d", 1, 0, r, c)
+		}
+	})
+
+	t.Run("View", func(t *testing.T) {
+		t.Parallel()
+
+		m := &Matrix{Values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, Rows: 3, Columns: 3}
+		v, _ := m.Slice(1, 3, 0, 2)
+		if s := v.Sum(); s != 24 {
+			t.Errorf("Expected sum is %f, but got %f", 24.0, s)
+		}
+
+		if r, c := v.ArgMax(); r != 1 || c != 1 {
+			t.Errorf("Expected position of the max is %d, %d, but got %d, %d", 1, 1, r, c)
+		}
+	})
+}
+
+func TestReduceAxis(t *testing.T) {
+	aMat := &Matrix{Values: []float64{1, 5, -2, 4, 0, 1}, Rows: 2, Columns: 3}
+	type reduceFn func(m *Matrix, axis Axis, aMat *Matrix) error
+	testCases := []struct {
+		name                       string
+		fn                         reduceFn
+		axis                       Axis
+		matrix                     *Matrix
+		expectedRows, expectedCols int
+		expectedValues             []float64
+		expectedError              error
+	}{
+		{"SumAxis ByRow", (*Matrix).SumAxis, ByRow, aMat, 2, 1, []float64{4, 5}, nil},
+		{"SumAxis ByColumn", (*Matrix).SumAxis, ByColumn, aMat, 1, 3, []float64{5, 5, -1}, nil},
+		{"MeanAxis ByRow", (*Matrix).MeanAxis, ByRow, aMat, 2, 1, []float64{4.0 / 3, 5.0 / 3}, nil},
+		{"MeanAxis ByColumn", (*Matrix).MeanAxis, ByColumn, aMat, 1, 3, []float64{2.5, 2.5, -0.5}, nil},
+		{"VarianceAxis ByColumn", (*Matrix).VarianceAxis, ByColumn, aMat, 1, 3, []float64{2.25, 6.25, 2.25}, nil},
+		{"MaxAxis ByRow", (*Matrix).MaxAxis, ByRow, aMat, 2, 1, []float64{5, 4}, nil},
+		{"MinAxis ByColumn", (*Matrix).MinAxis, ByColumn, aMat, 1, 3, []float64{1, 0, -2}, nil},
+		{"ErrBadAxis", (*Matrix).SumAxis, Axis(2), aMat, 0, 0, nil, ErrBadAxis},
+		{"ErrNilMatrix", (*Matrix).SumAxis, ByRow, nil, 0, 0, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := tc.fn(m, tc.axis, tc.matrix)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if m.Rows != tc.expectedRows || m.Columns != tc.expectedCols {
+					t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.expectedRows, tc.expectedCols, m.Rows, m.Columns)
+				}
+
+				for idx, v := range m.Values {
+					if math.Abs(v-tc.expectedValues[idx]) > 1e-12 {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("In-place", func(t *testing.T) {
+		t.Parallel()
+
+		m := &Matrix{Values: []float64{1, 5, -2, 4, 0, 1}, Rows: 2, Columns: 3}
+		if err := m.SumAxis(ByColumn, m); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		expected := []float64{5, 5, -1}
+		for idx, v := range m.Values {
+			if v != expected[idx] {
+				t.Errorf("Expected value is %f, but got %f", expected[idx], v)
+			}
+		}
+	})
+
+	t.Run("Into a view", func(t *testing.T) {
+		t.Parallel()
+
+		parent := &Matrix{Values: []float64{1, 5, 0, 4, 0, 0}, Rows: 2, Columns: 3}
+		col, _ := parent.Col(2)
+		src, _ := parent.Slice(0, 2, 0, 2)
+		if err := col.MaxAxis(ByRow, src); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		expected := []float64{1, 5, 5, 4, 0, 4}
+		for idx, v := range parent.Values {
+			if v != expected[idx] {
+				t.Errorf("Expected value is %f, but got %f", expected[idx], v)
+			}
+		}
+	})
+}
+
+func TestArgMaxAxis(t *testing.T) {
+	m := &Matrix{Values: []float64{1, 5, -2, 4, 0, 1}, Rows: 2, Columns: 3}
+	testCases := []struct {
+		name            string
+		fn              func(m *Matrix, axis Axis) ([]int, error)
+		axis            Axis
+		expectedIndices []int
+		expectedError   error
+	}{
+		{"ArgMaxAxis ByRow", (*Matrix).ArgMaxAxis, ByRow, []int{1, 0}, nil},
+		{"ArgMaxAxis ByColumn", (*Matrix).ArgMaxAxis, ByColumn, []int{1, 0, 1}, nil},
+		{"ArgMinAxis ByRow", (*Matrix).ArgMinAxis, ByRow, []int{2, 1}, nil},
+		{"ArgMinAxis ByColumn", (*Matrix).ArgMinAxis, ByColumn, []int{0, 1, 0}, nil},
+		{"ErrBadAxis", (*Matrix).ArgMaxAxis, Axis(-1), nil, ErrBadAxis},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			idxs, err := tc.fn(m, tc.axis)
+			if err != tc.expectedError {
+				t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+			} else if len(idxs) != len(tc.expectedIndices) {
+				t.Errorf("Expected number of indices is %d, but got %d", len(tc.expectedIndices), len(idxs))
+			} else {
+				for idx, v := range idxs {
+					if v != tc.expectedIndices[idx] {
+						t.Errorf("Expected index is %d, but got %d", tc.expectedIndices[idx], v)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package matrix
+
+import "sort"
+
+// Sparse represents a matrix in the compressed sparse row (CSR) format, only the non-zero elements are stored.
+// The non-zero elements of the "r"-th row are "values[rowPtr[r]:rowPtr[r+1]]", and their columns are at the same positions in "colIdx".
+type Sparse struct {
+	Rows    int // Number of rows
+	Columns int // Number of columns
+
+	rowPtr []int
+	colIdx []int
+	values []float64
+}
+
+// NewSparse creates a new Sparse matrix with "r" rows and "c" columns from coordinate triplets,
+// the "i"-th element is "vals[i]" at row "rowIdx[i]" and column "colIdx[i]", the triplets can be in any order.
+// The values of the duplicated coordinates are summed.
+// It will return an error if "r <= 0" or "c <= 0", the lengths of the slices are not the same, or any of the coordinates is out of bounds.
+func NewSparse(r, c int, rowIdx, colIdx []int, vals []float64) (*Sparse, error) {
+	if r <= 0 {
+		return nil, ErrZeroRow
+	}
+
+	if c <= 0 {
+		return nil, ErrZeroCol
+	}
+
+	if len(rowIdx) != len(vals) || len(colIdx) != len(vals) {
+		return nil, ErrDataLength
+	}
+
+	rowPtr := make([]int, r+1)
+	for idx, row := range rowIdx {
+		if row < 0 || row >= r {
+			return nil, ErrRowOutOfBounds
+		}
+
+		if colIdx[idx] < 0 || colIdx[idx] >= c {
+			return nil, ErrColOutOfBounds
+		}
+
+		rowPtr[row+1]++
+	}
+
+	for row := 0; row < r; row++ {
+		rowPtr[row+1] += rowPtr[row]
+	}
+
+	next := append([]int(nil), rowPtr[:r]...)
+	cols := make([]int, len(vals))
+	values := make([]float64, len(vals))
+	for idx, row := range rowIdx {
+		cols[next[row]], values[next[row]] = colIdx[idx], vals[idx]
+		next[row]++
+	}
+
+	s := &Sparse{r, c, rowPtr, cols, values}
+	s.compact()
+
+	return s, nil
+}
+
+// sparseRow sorts the elements of a single row by their columns.
+type sparseRow struct {
+	cols []int
+	vals []float64
+}
+
+func (s sparseRow) Len() int           { return len(s.cols) }
+func (s sparseRow) Less(i, j int) bool { return s.cols[i] < s.cols[j] }
+func (s sparseRow) Swap(i, j int) {
+	s.cols[i], s.cols[j] = s.cols[j], s.cols[i]
+	s.vals[i], s.vals[j] = s.vals[j], s.vals[i]
+}
+
+// compact sorts the elements of each row by their columns, and merges the elements with the same coordinates.
+func (s *Sparse) compact() {
+	n := 0
+	start := 0
+	for r := 0; r < s.Rows; r++ {
+		end := s.rowPtr[r+1]
+		sort.Stable(sparseRow{s.colIdx[start:end], s.values[start:end]})
+
+		rowStart := n
+		for idx := start; idx < end; idx++ {
+			if n > rowStart && s.colIdx[n-1] == s.colIdx[idx] {
+				s.values[n-1] += s.values[idx]
+				continue
+			}
+
+			s.colIdx[n], s.values[n] = s.colIdx[idx], s.values[idx]
+			n++
+		}
+
+		start = end
+		s.rowPtr[r+1] = n
+	}
+
+	s.colIdx, s.values = s.colIdx[:n], s.values[:n]
+}
+
+// SparseFromMatrix creates a new Sparse matrix from the non-zero elements of "m".
+// It will return an error if "m == nil".
+func SparseFromMatrix(m *Matrix) (*Sparse, error) {
+	if m == nil {
+		return nil, ErrNilMatrix
+	}
+
+	s := &Sparse{Rows: m.Rows, Columns: m.Columns, rowPtr: make([]int, m.Rows+1)}
+	for r := 0; r < m.Rows; r++ {
+		for c, v := range m.row(r) {
+			if v != 0 {
+				s.colIdx = append(s.colIdx, c)
+				s.values = append(s.values, v)
+			}
+		}
+
+		s.rowPtr[r+1] = len(s.values)
+	}
+
+	return s, nil
+}
+
+// Matrix creates a new, dense Matrix with the same elements as the sparse matrix.
+func (s *Sparse) Matrix() *Matrix {
+	m, _ := New(s.Rows, s.Columns, nil)
+	s.Each(func(r, c int, v float64) {
+		m.Values[r*m.Columns+c] = v
+	})
+
+	return m
+}
+
+// NNZ returns the number of the stored elements.
+func (s *Sparse) NNZ() int {
+	return len(s.values)
+}
+
+// Each calls "fn" with the coordinates and the value of every stored element in row-major order.
+func (s *Sparse) Each(fn func(r, c int, v float64)) {
+	for r := 0; r < s.Rows; r++ {
+		for idx := s.rowPtr[r]; idx < s.rowPtr[r+1]; idx++ {
+			fn(r, s.colIdx[idx], s.values[idx])
+		}
+	}
+}
+
+// At returns the element at row "r", column "c", the same way as Matrix.At.
+// It will return an error if "r" bigger than the number of rows or "c" is bigger than the number columns.
+func (s *Sparse) At(r, c int) (float64, error) {
+	if r < 0 || r > s.Rows-1 {
+		return 0, ErrRowOutOfBounds
+	}
+
+	if c < 0 || c > s.Columns-1 {
+		return 0, ErrColOutOfBounds
+	}
+
+	cols := s.colIdx[s.rowPtr[r]:s.rowPtr[r+1]]
+	idx := sort.SearchInts(cols, c)
+	if idx < len(cols) && cols[idx] == c {
+		return s.values[s.rowPtr[r]+idx], nil
+	}
+
+	return 0, nil
+}
+
+// SparseDenseProduct performs matrix multiplication of the sparse "a" and the dense "b", placing the result in the receiver.
+// The receiver may be "b", in that case the result is calculated in a temporary matrix first.
+// It will return an error if the number of columns in "a" not equal with the number of rows in "b".
+// It will also return an error if "b == nil" or "a == nil".
+func (m *Matrix) SparseDenseProduct(aSparse *Sparse, bMat *Matrix) error {
+	if aSparse == nil || bMat == nil {
+		return ErrNilMatrix
+	}
+
+	if aSparse.Columns != bMat.Rows {
+		return newShapeError("SparseDenseProduct", ErrBadProductDimension, aSparse.Columns, bMat.Columns, bMat.Rows, bMat.Columns)
+	}
+
+	dst := m
+	if aliases(m.Values, bMat.Values) {
+		dst = &Matrix{}
+	}
+
+	if err := dst.reuse(aSparse.Rows, bMat.Columns); err != nil {
+		return err
+	}
+
+	for r := 0; r < dst.Rows; r++ {
+		dRow := dst.row(r)
+		for idx := range dRow {
+			dRow[idx] = 0
+		}
+
+		for idx := aSparse.rowPtr[r]; idx < aSparse.rowPtr[r+1]; idx++ {
+			v := aSparse.values[idx]
+			bRow := bMat.row(aSparse.colIdx[idx])
+			bRow = bRow[:len(dRow)]
+			for c, bVal := range bRow {
+				dRow[c] += v * bVal
+			}
+		}
+	}
+
+	if dst != m {
+		return m.CopyFrom(dst)
+	}
+
+	return nil
+}
+
+// DenseSparseProduct performs matrix multiplication of the dense "a" and the sparse "b", placing the result in the receiver.
+// Only the columns of "a" that belong to a non-empty row of "b" are read, so it is efficient when "b" is a sparse input vector.
+// The receiver may be "a", in that case the result is calculated in a temporary matrix first.
+// It will return an error if the number of columns in "a" not equal with the number of rows in "b".
+// It will also return an error if "b == nil" or "a == nil".
+func (m *Matrix) DenseSparseProduct(aMat *Matrix, bSparse *Sparse) error {
+	if aMat == nil || bSparse == nil {
+		return ErrNilMatrix
+	}
+
+	if aMat.Columns != bSparse.Rows {
+		return newShapeError("DenseSparseProduct", ErrBadProductDimension, aMat.Columns, bSparse.Columns, bSparse.Rows, bSparse.Columns)
+	}
+
+	dst := m
+	if aliases(m.Values, aMat.Values) {
+		dst = &Matrix{}
+	}
+
+	if err := dst.reuse(aMat.Rows, bSparse.Columns); err != nil {
+		return err
+	}
+
+	for r := 0; r < dst.Rows; r++ {
+		dRow := dst.row(r)
+		for idx := range dRow {
+			dRow[idx] = 0
+		}
+	}
+
+	aStride, dStride := aMat.Stride(), dst.Stride()
+	for k := 0; k < bSparse.Rows; k++ {
+		for idx := bSparse.rowPtr[k]; idx < bSparse.rowPtr[k+1]; idx++ {
+			c, v := bSparse.colIdx[idx], bSparse.values[idx]
+			for r := 0; r < dst.Rows; r++ {
+				dst.Values[r*dStride+c] += aMat.Values[r*aStride+k] * v
+			}
+		}
+	}
+
+	if dst != m {
+		return m.CopyFrom(dst)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package matrix
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// randomSparse returns a dense matrix that has about "density" ratio of non-zero elements.
+func randomSparse(r *rand.Rand, rows, cols int, density float64) *Matrix {
+	m, _ := New(rows, cols, nil)
+	for idx := range m.Values {
+		if r.Float64() < density {
+			m.Values[idx] = r.Float64()*2 - 1
+		}
+	}
+
+	return m
+}
+
+func TestNewSparse(t *testing.T) {
+	testCases := []struct {
+		name           string
+		rows, columns  int
+		rowIdx, colIdx []int
+		values         []float64
+		expectedNNZ    int
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Normal", 2, 3, []int{1, 0, 1}, []int{2, 1, 0}, []float64{3, 1, 2}, 3, []float64{0, 1, 0, 2, 0, 3}, nil},
+		{"Duplicates", 2, 2, []int{0, 1, 0}, []int{1, 1, 1}, []float64{1, 2, 3}, 2, []float64{0, 4, 0, 2}, nil},
+		{"Empty", 2, 2, nil, nil, nil, 0, []float64{0, 0, 0, 0}, nil},
+		{"ErrZeroRow", 0, 2, nil, nil, nil, 0, nil, ErrZeroRow},
+		{"ErrZeroCol", 2, 0, nil, nil, nil, 0, nil, ErrZeroCol},
+		{"ErrDataLength", 2, 2, []int{0}, []int{0, 1}, []float64{1}, 0, nil, ErrDataLength},
+		{"ErrRowOutOfBounds", 2, 2, []int{2}, []int{0}, []float64{1}, 0, nil, ErrRowOutOfBounds},
+		{"ErrColOutOfBounds", 2, 2, []int{0}, []int{-1}, []float64{1}, 0, nil, ErrColOutOfBounds},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := NewSparse(tc.rows, tc.columns, tc.rowIdx, tc.colIdx, tc.values)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if s.NNZ() != tc.expectedNNZ {
+					t.Errorf("Expected number of stored elements is %d, but got %d", tc.expectedNNZ, s.NNZ())
+				}
+
+				m := s.Matrix()
+				if m.Rows != tc.rows || m.Columns != tc.columns || !isApproxEqual(m.Values, tc.expectedValues, 0) {
+					t.Errorf("Expected values are %v, but got %v", tc.expectedValues, m.Values)
+				}
+
+				for idx, v := range tc.expectedValues {
+					if at, _ := s.At(idx/tc.columns, idx%tc.columns); at != v {
+						t.Errorf("Expected value is %f, but got %f", v, at)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSparseFromMatrix(t *testing.T) {
+	m := &Matrix{Values: []float64{0, 1, 0, 0, 2, 3, 0, 0, 0}, Rows: 3, Columns: 3}
+	s, err := SparseFromMatrix(m)
+	if err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if s.NNZ() != 3 {
+		t.Errorf("Expected number of stored elements is %d, but got %d", 3, s.NNZ())
+	}
+
+	if d := s.Matrix(); !isApproxEqual(d.Values, m.Values, 0) {
+		t.Errorf("Expected values are %v, but got %v", m.Values, d.Values)
+	}
+
+	if _, err := s.At(3, 0); err != ErrRowOutOfBounds {
+		t.Errorf("Expected error is %v, but got %v", ErrRowOutOfBounds, err)
+	}
+
+	if _, err := SparseFromMatrix(nil); err != ErrNilMatrix {
+		t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+	}
+}
+
+func TestSparseDenseProduct(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, dim := range [][3]int{{1, 1, 1}, {5, 7, 3}, {40, 100, 1}} {
+		aDense, bMat := randomSparse(r, dim[0], dim[1], 0.2), randomMatrix(r, dim[1], dim[2])
+		aSparse, _ := SparseFromMatrix(aDense)
+
+		m := &Matrix{}
+		if err := m.SparseDenseProduct(aSparse, bMat); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if expected := naiveProduct(aDense, bMat); !isApproxEqual(m.Values, expected.Values, 1e-12) {
+			t.Errorf("Expected values are %v, but got %v", expected.Values, m.Values)
+		}
+	}
+
+	s, _ := NewSparse(2, 2, []int{0, 1}, []int{1, 0}, []float64{1, 1})
+	b := &Matrix{Values: []float64{1, 2, 3, 4}, Rows: 2, Columns: 2}
+	if err := b.SparseDenseProduct(s, b); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if expected := []float64{3, 4, 1, 2}; !isApproxEqual(b.Values, expected, 0) {
+		t.Errorf("Expected values are %v, but got %v", expected, b.Values)
+	}
+
+	if err := b.SparseDenseProduct(s, &Matrix{Values: []float64{1}, Rows: 1, Columns: 1}); !errors.Is(err, ErrBadProductDimension) {
+		t.Errorf("Expected error is %v, but got %v", ErrBadProductDimension, err)
+	}
+
+	if err := b.SparseDenseProduct(nil, b); err != ErrNilMatrix {
+		t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+	}
+}
+
+func TestDenseSparseProduct(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	for _, dim := range [][3]int{{1, 1, 1}, {5, 7, 3}, {40, 1000, 1}} {
+		aMat, bDense := randomMatrix(r, dim[0], dim[1]), randomSparse(r, dim[1], dim[2], 0.05)
+		bSparse, _ := SparseFromMatrix(bDense)
+
+		m := &Matrix{}
+		if err := m.DenseSparseProduct(aMat, bSparse); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if expected := naiveProduct(aMat, bDense); !isApproxEqual(m.Values, expected.Values, 1e-12) {
+			t.Errorf("Expected values are %v, but got %v", expected.Values, m.Values)
+		}
+	}
+
+	s, _ := NewSparse(2, 2, []int{0, 1}, []int{1, 0}, []float64{1, 1})
+	a := &Matrix{Values: []float64{1, 2, 3, 4}, Rows: 2, Columns: 2}
+	if err := a.DenseSparseProduct(a, s); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if expected := []float64{2, 1, 4, 3}; !isApproxEqual(a.Values, expected, 0) {
+		t.Errorf("Expected values are %v, but got %v", expected, a.Values)
+	}
+
+	if err := a.DenseSparseProduct(&Matrix{Values: []float64{1}, Rows: 1, Columns: 1}, s); !errors.Is(err, ErrBadProductDimension) {
+		t.Errorf("Expected error is %v, but got %v", ErrBadProductDimension, err)
+	}
+
+	if err := a.DenseSparseProduct(a, nil); err != ErrNilMatrix {
+		t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+	}
+}
@@ -0,0 +1,156 @@
+package matrix
+
+// HStack concatenates the matrices horizontally, placing the result in the receiver, e.g. column vectors are stacked into the columns of a mini-batch.
+// The result has the same number of rows as the operands, and the sum of their columns.
+// The receiver may be one of the operands, in that case the result is calculated in a temporary matrix first.
+// It will return an error if no matrix is given, any of them is nil, or the number of their rows are different.
+func (m *Matrix) HStack(mats ...*Matrix) error {
+	return m.stack(mats, false)
+}
+
+// VStack concatenates the matrices vertically, placing the result in the receiver, e.g. row vectors are stacked into the rows of a matrix.
+// The result has the same number of columns as the operands, and the sum of their rows.
+// The receiver may be one of the operands, in that case the result is calculated in a temporary matrix first.
+// It will return an error if no matrix is given, any of them is nil, or the number of their columns are different.
+func (m *Matrix) VStack(mats ...*Matrix) error {
+	return m.stack(mats, true)
+}
+
+// stack concatenates the matrices vertically if "vertical" is true, otherwise horizontally.
+func (m *Matrix) stack(mats []*Matrix, vertical bool) error {
+	if len(mats) == 0 {
+		return ErrNoMatrices
+	}
+
+	dst := m
+	r, c := 0, 0
+	for idx, aMat := range mats {
+		if aMat == nil {
+			return ErrNilMatrix
+		}
+
+		switch {
+		case idx == 0:
+			r, c = aMat.Rows, aMat.Columns
+		case vertical && aMat.Columns == c:
+			r += aMat.Rows
+		case !vertical && aMat.Rows == r:
+			c += aMat.Columns
+		case vertical:
+			return newShapeError("VStack", ErrStackDimensions, aMat.Rows, c, aMat.Rows, aMat.Columns)
+		default:
+			return newShapeError("HStack", ErrStackDimensions, r, aMat.Columns, aMat.Rows, aMat.Columns)
+		}
+
+		if aliases(m.Values, aMat.Values) {
+			dst = &Matrix{}
+		}
+	}
+
+	if m.IsView() && (m.Rows != r || m.Columns != c) {
+		op := "HStack"
+		if vertical {
+			op = "VStack"
+		}
+
+		return newShapeError(op, ErrViewDimensions, m.Rows, m.Columns, r, c)
+	}
+
+	dst.reuse(r, c)
+
+	offset := 0
+	for _, aMat := range mats {
+		for row := 0; row < aMat.Rows; row++ {
+			if vertical {
+				copy(dst.row(offset+row), aMat.row(row))
+			} else {
+				copy(dst.row(row)[offset:], aMat.row(row))
+			}
+		}
+
+		if vertical {
+			offset += aMat.Rows
+		} else {
+			offset += aMat.Columns
+		}
+	}
+
+	if dst != m {
+		return m.CopyFrom(dst)
+	}
+
+	return nil
+}
+
+// Split splits the matrix into parts along "axis", and returns the parts as views of the receiver in order.
+// For ByRow the matrix is split between its rows, so the "i"-th part has "sizes[i]" rows and all of the columns, it is the inverse of VStack.
+// For ByColumn the matrix is split between its columns, so the "i"-th part has "sizes[i]" columns and all of the rows, it is the inverse of HStack.
+// The parts share the elements with the receiver, the same way as the views returned by Slice.
+// It will return an error if "axis" is neither ByRow nor ByColumn, any of the sizes is not positive, or the sizes do not add up to the split dimension.
+func (m *Matrix) Split(axis Axis, sizes ...int) ([]*Matrix, error) {
+	var n int
+	switch axis {
+	case ByRow:
+		n = m.Rows
+	case ByColumn:
+		n = m.Columns
+	default:
+		return nil, ErrBadAxis
+	}
+
+	sum := 0
+	for _, size := range sizes {
+		if size <= 0 {
+			return nil, ErrSplitSizes
+		}
+
+		sum += size
+	}
+
+	if len(sizes) == 0 || sum != n {
+		return nil, ErrSplitSizes
+	}
+
+	parts := make([]*Matrix, len(sizes))
+	start := 0
+	for idx, size := range sizes {
+		if axis == ByRow {
+			parts[idx], _ = m.Slice(start, start+size, 0, m.Columns)
+		} else {
+			parts[idx], _ = m.Slice(0, m.Rows, start, start+size)
+		}
+
+		start += size
+	}
+
+	return parts, nil
+}
+
+// Reshape places the elements of "a" into the receiver with "r" rows and "c" columns, keeping their row-major order.
+// The receiver may be the operand, in that case a non-view matrix is reshaped without copying its elements.
+// It will return an error if "a == nil", "r <= 0", "c <= 0", or "r * c" is not the number of the elements in "a".
+// It will also return an error if the receiver is a view, and its dimensions would change.
+func (m *Matrix) Reshape(r, c int, aMat *Matrix) error {
+	if aMat == nil {
+		return ErrNilMatrix
+	}
+
+	if r <= 0 {
+		return ErrZeroRow
+	}
+
+	if c <= 0 {
+		return ErrZeroCol
+	}
+
+	if r*c != aMat.Rows*aMat.Columns {
+		return newShapeError("Reshape", ErrReshapeDimensions, r, c, aMat.Rows, aMat.Columns)
+	}
+
+	src := aMat
+	if !aMat.contiguous() || (m != aMat && aliases(m.Values, aMat.Values)) {
+		src, _ = Copy(aMat)
+	}
+
+	return m.SetValues(r, c, src.Values[:r*c])
+}
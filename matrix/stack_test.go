@@ -0,0 +1,188 @@
+package matrix
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	a := &Matrix{Values: []float64{1, 2, 3, 4}, Rows: 2, Columns: 2}
+	b := &Matrix{Values: []float64{5, 6}, Rows: 2, Columns: 1}
+	c := &Matrix{Values: []float64{7, 8}, Rows: 1, Columns: 2}
+	testCases := []struct {
+		name           string
+		vertical       bool
+		mats           []*Matrix
+		expectedMatrix *Matrix
+		expectedError  error
+	}{
+		{"HStack", false, []*Matrix{a, b, a}, &Matrix{Values: []float64{1, 2, 5, 1, 2, 3, 4, 6, 3, 4}, Rows: 2, Columns: 5}, nil},
+		{"HStack single", false, []*Matrix{b}, &Matrix{Values: []float64{5, 6}, Rows: 2, Columns: 1}, nil},
+		{"HStack views", false, []*Matrix{{Values: []float64{1, 9, 2}, Rows: 2, Columns: 1, stride: 2}, b}, &Matrix{Values: []float64{1, 5, 2, 6}, Rows: 2, Columns: 2}, nil},
+		{"VStack", true, []*Matrix{a, c}, &Matrix{Values: []float64{1, 2, 3, 4, 7, 8}, Rows: 3, Columns: 2}, nil},
+		{"ErrStackDimensions HStack", false, []*Matrix{a, c}, nil, ErrStackDimensions},
+		{"ErrStackDimensions VStack", true, []*Matrix{a, b}, nil, ErrStackDimensions},
+		{"ErrNilMatrix", false, []*Matrix{a, nil}, nil, ErrNilMatrix},
+		{"ErrNoMatrices", true, nil, nil, ErrNoMatrices},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			var err error
+			if tc.vertical {
+				err = m.VStack(tc.mats...)
+			} else {
+				err = m.HStack(tc.mats...)
+			}
+
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedMatrix != nil && !EqualApprox(m, tc.expectedMatrix, 0) {
+				t.Errorf("Expected matrix is %v, but got %v", tc.expectedMatrix, m)
+			}
+		})
+	}
+
+	t.Run("Receiver is an operand", func(t *testing.T) {
+		t.Parallel()
+
+		m, _ := Copy(a)
+		if err := m.VStack(m, c, m); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		expected := &Matrix{Values: []float64{1, 2, 3, 4, 7, 8, 1, 2, 3, 4}, Rows: 5, Columns: 2}
+		if !EqualApprox(m, expected, 0) {
+			t.Errorf("Expected matrix is %v, but got %v", expected, m)
+		}
+	})
+
+	t.Run("ErrViewDimensions", func(t *testing.T) {
+		t.Parallel()
+
+		parent, _ := Copy(a)
+		v, _ := parent.Col(0)
+		if err := v.HStack(b, b); !errors.Is(err, ErrViewDimensions) {
+			t.Errorf("Expected error is %v, but got %v", ErrViewDimensions, err)
+		}
+
+		if err := v.HStack(b); err != nil || parent.Values[0] != 5 || parent.Values[2] != 6 {
+			t.Errorf("Expected parent values are %v, but got %v (%v)", []float64{5, 2, 6, 4}, parent.Values, err)
+		}
+	})
+}
+
+func TestSplit(t *testing.T) {
+	m := &Matrix{Values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, Rows: 3, Columns: 4}
+	testCases := []struct {
+		name          string
+		axis          Axis
+		sizes         []int
+		expected      []*Matrix
+		expectedError error
+	}{
+		{"ByRow", ByRow, []int{1, 2}, []*Matrix{
+			{Values: []float64{1, 2, 3, 4}, Rows: 1, Columns: 4},
+			{Values: []float64{5, 6, 7, 8, 9, 10, 11, 12}, Rows: 2, Columns: 4},
+		}, nil},
+		{"ByColumn", ByColumn, []int{1, 1, 2}, []*Matrix{
+			{Values: []float64{1, 5, 9}, Rows: 3, Columns: 1},
+			{Values: []float64{2, 6, 10}, Rows: 3, Columns: 1},
+			{Values: []float64{3, 4, 7, 8, 11, 12}, Rows: 3, Columns: 2},
+		}, nil},
+		{"ErrSplitSizes sum", ByRow, []int{1, 1}, nil, ErrSplitSizes},
+		{"ErrSplitSizes zero", ByColumn, []int{0, 4}, nil, ErrSplitSizes},
+		{"ErrSplitSizes empty", ByColumn, nil, nil, ErrSplitSizes},
+		{"ErrBadAxis", Axis(2), []int{3}, nil, ErrBadAxis},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			parts, err := m.Split(tc.axis, tc.sizes...)
+			if err != tc.expectedError {
+				t.Fatalf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if len(parts) != len(tc.expected) {
+				t.Fatalf("Expected number of parts is %d, but got %d", len(tc.expected), len(parts))
+			}
+
+			for idx, p := range parts {
+				if !EqualApprox(p, tc.expected[idx], 0) {
+					t.Errorf("Expected part is %v, but got %v", tc.expected[idx], p)
+				}
+			}
+
+			// Stacking the parts together gives back the original matrix.
+			if err == nil {
+				back := &Matrix{}
+				if tc.axis == ByRow {
+					back.VStack(parts...)
+				} else {
+					back.HStack(parts...)
+				}
+
+				if !EqualApprox(back, m, 0) {
+					t.Errorf("Expected matrix is %v, but got %v", m, back)
+				}
+			}
+		})
+	}
+}
+
+func TestReshape(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		r, c           int
+		expectedMatrix *Matrix
+		expectedError  error
+	}{
+		{"Normal", &Matrix{Values: []float64{1, 2, 3, 4, 5, 6}, Rows: 2, Columns: 3}, 3, 2, &Matrix{Values: []float64{1, 2, 3, 4, 5, 6}, Rows: 3, Columns: 2}, nil},
+		{"View", &Matrix{Values: []float64{1, 2, 0, 3, 4}, Rows: 2, Columns: 2, stride: 3}, 1, 4, &Matrix{Values: []float64{1, 2, 3, 4}, Rows: 1, Columns: 4}, nil},
+		{"ErrReshapeDimensions", &Matrix{Values: []float64{1, 2, 3, 4, 5, 6}, Rows: 2, Columns: 3}, 4, 2, nil, ErrReshapeDimensions},
+		{"ErrZeroRow", &Matrix{Values: []float64{1}, Rows: 1, Columns: 1}, 0, 1, nil, ErrZeroRow},
+		{"ErrZeroCol", &Matrix{Values: []float64{1}, Rows: 1, Columns: 1}, 1, -1, nil, ErrZeroCol},
+		{"ErrNilMatrix", nil, 1, 1, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m := &Matrix{}
+			err := m.Reshape(tc.r, tc.c, tc.matrix)
+			if !errors.Is(err, tc.expectedError) {
+				t.Fatalf("Expected error is %v, but got %v", tc.expectedError, err)
+			}
+
+			if tc.expectedMatrix != nil && !EqualApprox(m, tc.expectedMatrix, 0) {
+				t.Errorf("Expected matrix is %v, but got %v", tc.expectedMatrix, m)
+			}
+		})
+	}
+
+	t.Run("In-place", func(t *testing.T) {
+		t.Parallel()
+
+		vals := []float64{1, 2, 3, 4, 5, 6}
+		m := &Matrix{Values: vals, Rows: 6, Columns: 1}
+		if err := m.Reshape(2, 3, m); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if m.Rows != 2 || m.Columns != 3 || &m.Values[0] != &vals[0] {
+			t.Errorf("Expected a 2x3 matrix sharing the elements, but got %dx%d", m.Rows, m.Columns)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package matrix
+
+import "math"
+
+// SVD represents the thin singular value decomposition of a "rows x columns" matrix "A", such that "A = U * diag(values) * V^T",
+// where "U" is a "rows x k" and "V" is a "columns x k" matrix with orthonormal columns, and "k = min(rows, columns)".
+type SVD struct {
+	u, v   *Matrix
+	values []float64
+}
+
+// NewSVD computes the thin singular value decomposition of "a" with the one-sided Jacobi method, "a" is left unchanged.
+// The singular values are sorted in descending order, the columns of "U" that belong to zero singular values are zero.
+// It will return an error if "a == nil".
+func NewSVD(aMat *Matrix) (*SVD, error) {
+	if aMat == nil {
+		return nil, ErrNilMatrix
+	}
+
+	// The one-sided Jacobi method orthogonalizes the columns, so a wide matrix is decomposed through its transpose.
+	if aMat.Rows < aMat.Columns {
+		aT := &Matrix{}
+		aT.Transpose(aMat)
+		d, _ := NewSVD(aT)
+		d.u, d.v = d.v, d.u
+		return d, nil
+	}
+
+	rows, cols := aMat.Rows, aMat.Columns
+	u, _ := Copy(aMat)
+	v, _ := Identity(cols)
+	uv, vv := u.Values, v.Values
+
+	for sweep := 0; sweep < eigenMaxSweeps; sweep++ {
+		rotated := false
+		for p := 0; p < cols-1; p++ {
+			for q := p + 1; q < cols; q++ {
+				alpha, beta, gamma := 0.0, 0.0, 0.0
+				for k := 0; k < rows; k++ {
+					up, uq := uv[k*cols+p], uv[k*cols+q]
+					alpha += up * up
+					beta += uq * uq
+					gamma += up * uq
+				}
+
+				if gamma == 0 || math.Abs(gamma) <= 1e-15*math.Sqrt(alpha*beta) {
+					continue
+				}
+
+				rotated = true
+				zeta := (beta - alpha) / (2 * gamma)
+				t := math.Copysign(1, zeta) / (math.Abs(zeta) + math.Sqrt(1+zeta*zeta))
+				c := 1 / math.Sqrt(1+t*t)
+				s := c * t
+
+				for k := 0; k < rows; k++ {
+					up, uq := uv[k*cols+p], uv[k*cols+q]
+					uv[k*cols+p], uv[k*cols+q] = c*up-s*uq, s*up+c*uq
+				}
+
+				for k := 0; k < cols; k++ {
+					vp, vq := vv[k*cols+p], vv[k*cols+q]
+					vv[k*cols+p], vv[k*cols+q] = c*vp-s*vq, s*vp+c*vq
+				}
+			}
+		}
+
+		if !rotated {
+			break
+		}
+	}
+
+	values := make([]float64, cols)
+	for c := range values {
+		norm := 0.0
+		for k := 0; k < rows; k++ {
+			norm = math.Hypot(norm, uv[k*cols+c])
+		}
+
+		values[c] = norm
+		if norm == 0 {
+			continue
+		}
+
+		for k := 0; k < rows; k++ {
+			uv[k*cols+c] /= norm
+		}
+	}
+
+	sorted, u := sortColumns(values, u)
+	_, v = sortColumns(values, v)
+	return &SVD{u, v, sorted}, nil
+}
+
+// Values returns the singular values in descending order.
+func (d *SVD) Values() []float64 {
+	return append([]float64(nil), d.values...)
+}
+
+// U returns a new Matrix holding the left singular vectors as its columns, in the same order as Values.
+func (d *SVD) U() *Matrix {
+	u, _ := Copy(d.u)
+	return u
+}
+
+// V returns a new Matrix holding the right singular vectors as its columns, in the same order as Values.
+func (d *SVD) V() *Matrix {
+	v, _ := Copy(d.v)
+	return v
+}
+
+// Rank returns the number of singular values that are larger than a tolerance relative to the largest singular value.
+func (d *SVD) Rank() int {
+	if len(d.values) == 0 {
+		return 0
+	}
+
+	tolerance := d.values[0] * float64(maxInt(d.u.Rows, d.v.Rows)) * 1e-15
+	rank := 0
+	for _, v := range d.values {
+		if v > tolerance {
+			rank++
+		}
+	}
+
+	return rank
+}
+
+// maxInt returns the larger of "a" and "b".
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
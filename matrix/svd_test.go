@@ -0,0 +1,84 @@
+package matrix
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// reconstruct returns "U * diag(values) * V^T" of the decomposition.
+func (d *SVD) reconstruct() *Matrix {
+	us, vT, m := &Matrix{}, &Matrix{}, &Matrix{}
+	us.Multiply(d.U(), &Matrix{Values: d.Values(), Rows: 1, Columns: len(d.values)})
+	vT.Transpose(d.V())
+	m.Product(us, vT)
+
+	return m
+}
+
+func TestNewSVD(t *testing.T) {
+	testCases := []struct {
+		name           string
+		matrix         *Matrix
+		expectedValues []float64
+		expectedRank   int
+		expectedError  error
+	}{
+		{"Wide", &Matrix{Values: []float64{3, 2, 2, 2, 3, -2}, Rows: 2, Columns: 3}, []float64{5, 3}, 2, nil},
+		{"Tall", &Matrix{Values: []float64{3, 2, 2, 3, 2, -2}, Rows: 3, Columns: 2}, []float64{5, 3}, 2, nil},
+		{"Square", &Matrix{Values: []float64{4, 0, 3, -5}, Rows: 2, Columns: 2}, []float64{math.Sqrt(40), math.Sqrt(10)}, 2, nil},
+		{"Rank deficient", &Matrix{Values: []float64{1, 2, 2, 4}, Rows: 2, Columns: 2}, []float64{5, 0}, 1, nil},
+		{"Zero", &Matrix{Values: []float64{0, 0, 0, 0}, Rows: 2, Columns: 2}, []float64{0, 0}, 0, nil},
+		{"ErrNilMatrix", nil, nil, 0, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			d, err := NewSVD(tc.matrix)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if !isApproxEqual(d.Values(), tc.expectedValues, 1e-12) {
+					t.Errorf("Expected singular values are %v, but got %v", tc.expectedValues, d.Values())
+				}
+
+				if d.Rank() != tc.expectedRank {
+					t.Errorf("Expected rank is %d, but got %d", tc.expectedRank, d.Rank())
+				}
+
+				if m := d.reconstruct(); !isApproxEqual(m.Values, tc.matrix.Values, 1e-12) {
+					t.Errorf("Expected U * S * V^T is %v, but got %v", tc.matrix.Values, m.Values)
+				}
+			}
+		})
+	}
+
+	t.Run("Random", func(t *testing.T) {
+		t.Parallel()
+
+		r := rand.New(rand.NewSource(0))
+		for _, dim := range [][2]int{{1, 1}, {1, 4}, {6, 3}, {3, 6}, {30, 30}} {
+			aMat := randomMatrix(r, dim[0], dim[1])
+			d, _ := NewSVD(aMat)
+			k := minInt(dim[0], dim[1])
+			if d.U().Rows != dim[0] || d.U().Columns != k || d.V().Rows != dim[1] || d.V().Columns != k {
+				t.Fatalf("Unexpected dimensions of U and V for a %dx%d matrix", dim[0], dim[1])
+			}
+
+			if !isOrthonormal(d.U(), 1e-12) || !isOrthonormal(d.V(), 1e-12) {
+				t.Errorf("Columns of U and V should be orthonormal for a %dx%d matrix", dim[0], dim[1])
+			}
+
+			if m := d.reconstruct(); !isApproxEqual(m.Values, aMat.Values, 1e-12) {
+				t.Errorf("Expected U * S * V^T to be A for a %dx%d matrix", dim[0], dim[1])
+			}
+		}
+	})
+}
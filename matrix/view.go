@@ -0,0 +1,38 @@
+package matrix
+
+// Slice returns a view of the rows "r0 <= r < r1" and the columns "c0 <= c < c1" of the receiver.
+// The view shares the elements with the receiver, so the changes made through the view are reflected in the receiver and vice versa.
+// It will return an error if the bounds are out of range, or the view would be empty.
+func (m *Matrix) Slice(r0, r1, c0, c1 int) (*Matrix, error) {
+	if r0 < 0 || r1 > m.Rows || r0 >= r1 || c0 < 0 || c1 > m.Columns || c0 >= c1 {
+		return nil, ErrSliceBounds
+	}
+
+	stride := m.Stride()
+	rows, cols := r1-r0, c1-c0
+	offset := r0*stride + c0
+
+	return &Matrix{Values: m.Values[offset : offset+(rows-1)*stride+cols], Rows: rows, Columns: cols, stride: stride, backend: m.backend}, nil
+}
+
+// Row returns a view of the "r"-th row of the receiver as a 1 x c matrix.
+// The view shares the elements with the receiver, so the changes made through the view are reflected in the receiver and vice versa.
+// It will return an error if "r" is out of bounds.
+func (m *Matrix) Row(r int) (*Matrix, error) {
+	if r < 0 || r >= m.Rows {
+		return nil, ErrRowOutOfBounds
+	}
+
+	return m.Slice(r, r+1, 0, m.Columns)
+}
+
+// Col returns a view of the "c"-th column of the receiver as a r x 1 matrix.
+// The view shares the elements with the receiver, so the changes made through the view are reflected in the receiver and vice versa.
+// It will return an error if "c" is out of bounds.
+func (m *Matrix) Col(c int) (*Matrix, error) {
+	if c < 0 || c >= m.Columns {
+		return nil, ErrColOutOfBounds
+	}
+
+	return m.Slice(0, m.Rows, c, c+1)
+}
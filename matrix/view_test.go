@@ -0,0 +1,270 @@
+package matrix
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestSlice(t *testing.T) {
+	parent := &Matrix{Values: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, Rows: 3, Columns: 4}
+	testCases := []struct {
+		name                       string
+		r0, r1, c0, c1             int
+		expectedRows, expectedCols int
+		expectedValues             []float64
+		expectedError              error
+	}{
+		{"Normal", 1, 3, 1, 3, 2, 2, []float64{5, 6, 9, 10}, nil},
+		{"Whole", 0, 3, 0, 4, 3, 4, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, nil},
+		{"Single element", 2, 3, 3, 4, 1, 1, []float64{11}, nil},
+		{"ErrSliceBounds negative", -1, 2, 0, 2, 0, 0, nil, ErrSliceBounds},
+		{"ErrSliceBounds rows", 0, 4, 0, 2, 0, 0, nil, ErrSliceBounds},
+		{"ErrSliceBounds columns", 0, 2, 0, 5, 0, 0, nil, ErrSliceBounds},
+		{"ErrSliceBounds empty", 1, 1, 0, 2, 0, 0, nil, ErrSliceBounds},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := parent.Slice(tc.r0, tc.r1, tc.c0, tc.c1)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if v.Rows != tc.expectedRows || v.Columns != tc.expectedCols {
+					t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.expectedRows, tc.expectedCols, v.Rows, v.Columns)
+				}
+
+				if !v.IsView() {
+					t.Error("Matrix should be a view")
+				}
+
+				if v.Stride() != parent.Columns {
+					t.Errorf("Expected stride is %d, but got %d", parent.Columns, v.Stride())
+				}
+
+				c, _ := Copy(v)
+				for idx, val := range c.Values {
+					if val != tc.expectedValues[idx] {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], val)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestRow(t *testing.T) {
+	parent := &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}
+	testCases := []struct {
+		name           string
+		row            int
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"First", 0, []float64{0, 1, 2}, nil},
+		{"Last", 1, []float64{3, 4, 5}, nil},
+		{"ErrRowOutOfBounds", 2, nil, ErrRowOutOfBounds},
+		{"ErrRowOutOfBounds negative", -1, nil, ErrRowOutOfBounds},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := parent.Row(tc.row)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if v.Rows != 1 || v.Columns != parent.Columns {
+					t.Errorf("Expected dimensions are %dx%d, but got %dx%d", 1, parent.Columns, v.Rows, v.Columns)
+				}
+
+				for c, ev := range tc.expectedValues {
+					if val, _ := v.At(0, c); val != ev {
+						t.Errorf("Expected value is %f, but got %f", ev, val)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCol(t *testing.T) {
+	parent := &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}
+	testCases := []struct {
+		name           string
+		col            int
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"First", 0, []float64{0, 3}, nil},
+		{"Last", 2, []float64{2, 5}, nil},
+		{"ErrColOutOfBounds", 3, nil, ErrColOutOfBounds},
+		{"ErrColOutOfBounds negative", -1, nil, ErrColOutOfBounds},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := parent.Col(tc.col)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if v.Rows != parent.Rows || v.Columns != 1 {
+					t.Errorf("Expected dimensions are %dx%d, but got %dx%d", parent.Rows, 1, v.Rows, v.Columns)
+				}
+
+				for r, ev := range tc.expectedValues {
+					if val, _ := v.At(r, 0); val != ev {
+						t.Errorf("Expected value is %f, but got %f", ev, val)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestView_operations(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	double := func(v float64) float64 { return v * 2 }
+	reverse := func(dst, src *Matrix) {
+		l := len(src.Values)
+		for idx := 0; idx < l/2; idx++ {
+			dst.Values[idx], dst.Values[l-idx-1] = src.Values[l-idx-1], src.Values[idx]
+		}
+	}
+	testCases := []struct {
+		name string
+		fn   func(m, a, b *Matrix) error
+	}{
+		{"Add", func(m, a, b *Matrix) error { return m.Add(a, b) }},
+		{"Apply", func(m, a, _ *Matrix) error { return m.Apply(double, a) }},
+		{"ApplyVector", func(m, a, _ *Matrix) error { return m.ApplyVector(reverse, a) }},
+		{"CopyFrom", func(m, a, _ *Matrix) error { return m.CopyFrom(a) }},
+		{"Multiply", func(m, a, b *Matrix) error { return m.Multiply(a, b) }},
+		{"Product", func(m, a, b *Matrix) error { return m.Product(a, b) }},
+		{"Scale", func(m, a, _ *Matrix) error { return m.Scale(3, a) }},
+		{"Subtract", func(m, a, b *Matrix) error { return m.Subtract(a, b) }},
+		{"Transpose", func(m, a, _ *Matrix) error { return m.Transpose(a) }},
+	}
+
+	for _, tc := range testCases {
+		parent := randomMatrix(r, 7, 8)
+		original, _ := Copy(parent)
+
+		a, _ := parent.Slice(0, 3, 1, 4)
+		b, _ := parent.Slice(4, 7, 5, 8)
+		m, _ := parent.Slice(3, 6, 0, 3)
+		aCopy, _ := Copy(a)
+		bCopy, _ := Copy(b)
+
+		expected := &Matrix{}
+		if err := tc.fn(expected, aCopy, bCopy); err != nil {
+			t.Fatalf("%s: expected error is %v, but got %v", tc.name, nil, err)
+		}
+
+		if err := tc.fn(m, a, b); err != nil {
+			t.Fatalf("%s: expected error is %v, but got %v", tc.name, nil, err)
+		}
+
+		for row := 0; row < parent.Rows; row++ {
+			for col := 0; col < parent.Columns; col++ {
+				v, _ := parent.At(row, col)
+				ev, _ := original.At(row, col)
+				if row >= 3 && row < 6 && col < 3 {
+					ev, _ = expected.At(row-3, col)
+				}
+
+				if math.Abs(v-ev) > 1e-12 {
+					t.Errorf("%s: expected value at [%d][%d] is %f, but got %f", tc.name, row, col, ev, v)
+				}
+			}
+		}
+	}
+}
+
+func TestView_overlapping(t *testing.T) {
+	parent := &Matrix{Values: []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, Rows: 3, Columns: 3}
+	a, _ := parent.Slice(0, 2, 0, 3)
+	m, _ := parent.Slice(1, 3, 0, 3)
+
+	if err := m.Scale(10, a); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	for idx, v := range []float64{1, 2, 3, 10, 20, 30, 40, 50, 60} {
+		if parent.Values[idx] != v {
+			t.Errorf("Expected value is %f, but got %f", v, parent.Values[idx])
+		}
+	}
+}
+
+func TestView_ErrViewDimensions(t *testing.T) {
+	parent := &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}
+	v, _ := parent.Row(0)
+	b := &Matrix{Values: []float64{0, 1}, Rows: 1, Columns: 2}
+	testCases := []struct {
+		name string
+		fn   func() error
+	}{
+		{"Add", func() error { return v.Add(b, b) }},
+		{"CopyFrom", func() error { return v.CopyFrom(b) }},
+		{"Product", func() error { return v.Product(b, &Matrix{Values: []float64{0, 1}, Rows: 2, Columns: 1}) }},
+		{"Product aliased", func() error { return v.Product(parent, &Matrix{Values: []float64{0, 1, 2}, Rows: 3, Columns: 1}) }},
+		{"SetValues", func() error { return v.SetValues(1, 2, []float64{0, 1}) }},
+		{"Transpose", func() error { return v.Transpose(v) }},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.fn(); !errors.Is(err, ErrViewDimensions) {
+				t.Errorf("Expected error is %v, but got %v", ErrViewDimensions, err)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	parent := &Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}
+	col, _ := parent.Col(1)
+	testCases := []struct {
+		name          string
+		r, c          int
+		expectedError error
+	}{
+		{"Normal", 1, 0, nil},
+		{"ErrRowOutOfBounds", 2, 0, ErrRowOutOfBounds},
+		{"ErrColOutOfBounds", 0, 1, ErrColOutOfBounds},
+	}
+
+	for _, tc := range testCases {
+		err := col.Set(tc.r, tc.c, 10)
+		if err != tc.expectedError {
+			t.Errorf("%s: expected error is %v, but got %v", tc.name, tc.expectedError, err)
+		}
+	}
+
+	if parent.Values[4] != 10 {
+		t.Errorf("Expected value is %f, but got %f", 10.0, parent.Values[4])
+	}
+}
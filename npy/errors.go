@@ -0,0 +1,21 @@
+package npy
+
+import "errors"
+
+// ErrNilMatrix is returned by Write when `m` is nil.
+var ErrNilMatrix = errors.New("npy: matrix must not be nil")
+
+// ErrBadMagic is returned by Read when the data does not start with the magic string of the .npy format.
+var ErrBadMagic = errors.New("npy: the data is not in the .npy format")
+
+// ErrUnsupportedVersion is returned by Read when the version of the .npy format is not supported.
+var ErrUnsupportedVersion = errors.New("npy: the version of the .npy format is not supported")
+
+// ErrBadHeader is returned by Read when the header of the .npy data cannot be parsed, or it is longer than 10000 bytes.
+var ErrBadHeader = errors.New("npy: the header is malformed")
+
+// ErrUnsupportedDtype is returned by Read and Write when the data type is not a float or integer type.
+var ErrUnsupportedDtype = errors.New("npy: the data type is not supported, it must be a float or integer type")
+
+// ErrUnsupportedShape is returned by Read when the array has more than two dimensions, it has no elements, or it has too many elements to be addressed.
+var ErrUnsupportedShape = errors.New("npy: the array must have at most two dimensions, at least one element, and an addressable number of elements")
@@ -0,0 +1,324 @@
+// Package npy reads and writes matrices in the NumPy .npy and .npz formats.
+//
+// The arrays can have float64, float32 or integer elements in either byte order, and they can be in C or Fortran order.
+// A one dimensional array is read as a column vector, the same way as the inputs of the networks, and a scalar is read as a 1 x 1 matrix.
+package npy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// Dtype is the NumPy type descriptor of the elements, e.g. "<f8".
+type Dtype string
+
+// The data types that Write supports, Read supports any byte order and integer size as well.
+const (
+	Float64 Dtype = "<f8"
+	Float32 Dtype = "<f4"
+	Int64   Dtype = "<i8"
+	Int32   Dtype = "<i4"
+)
+
+const magic = "\x93NUMPY"
+
+// headerAlignment is the alignment of the beginning of the data, written by NumPy as well.
+const headerAlignment = 64
+
+const (
+	// maxHeaderLen is the longest header that Read accepts, the same limit as the default of NumPy,
+	// so a malformed length in a version 2.0 or 3.0 header cannot force a huge allocation.
+	maxHeaderLen = 10000
+
+	// maxElements is the largest number of elements that Read accepts, the float64 elements of the matrix still have to be addressable.
+	maxElements = int(^uint(0)>>1) / 8
+
+	// dataChunk is the largest buffer that Read allocates for the data before the data arrives,
+	// so a header with a huge shape and a truncated body fails with io.ErrUnexpectedEOF instead of allocating the whole shape.
+	dataChunk = 1 << 20
+)
+
+var (
+	descrPattern   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	fortranPattern = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	shapePattern   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// dtype describes how a single element is stored.
+type dtype struct {
+	kind  byte // 'f' for floats, 'i' for signed and 'u' for unsigned integers
+	size  int
+	order binary.ByteOrder
+}
+
+// parseDtype parses a NumPy type descriptor, e.g. "<f8" or "|u1".
+func parseDtype(descr string) (dtype, error) {
+	if len(descr) < 3 {
+		return dtype{}, ErrUnsupportedDtype
+	}
+
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '|':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	default:
+		return dtype{}, ErrUnsupportedDtype
+	}
+
+	size, err := strconv.Atoi(descr[2:])
+	if err != nil {
+		return dtype{}, ErrUnsupportedDtype
+	}
+
+	d := dtype{descr[1], size, order}
+	switch {
+	case d.kind == 'f' && (size == 4 || size == 8):
+	case (d.kind == 'i' || d.kind == 'u') && (size == 1 || size == 2 || size == 4 || size == 8):
+	default:
+		return dtype{}, ErrUnsupportedDtype
+	}
+
+	return d, nil
+}
+
+// decode returns the value of the element stored in "b".
+func (d dtype) decode(b []byte) float64 {
+	var bits uint64
+	switch d.size {
+	case 1:
+		bits = uint64(b[0])
+	case 2:
+		bits = uint64(d.order.Uint16(b))
+	case 4:
+		bits = uint64(d.order.Uint32(b))
+	case 8:
+		bits = d.order.Uint64(b)
+	}
+
+	switch d.kind {
+	case 'f':
+		if d.size == 4 {
+			return float64(math.Float32frombits(uint32(bits)))
+		}
+
+		return math.Float64frombits(bits)
+	case 'i':
+		// The sign bit of the element is extended to 64 bits.
+		shift := uint(64 - 8*d.size)
+		return float64(int64(bits<<shift) >> shift)
+	default:
+		return float64(bits)
+	}
+}
+
+// encode stores "v" into "b", the integers are truncated toward zero.
+func (d dtype) encode(b []byte, v float64) {
+	switch {
+	case d.kind == 'f' && d.size == 4:
+		d.order.PutUint32(b, math.Float32bits(float32(v)))
+	case d.kind == 'f':
+		d.order.PutUint64(b, math.Float64bits(v))
+	case d.size == 4:
+		d.order.PutUint32(b, uint32(int32(v)))
+	default:
+		d.order.PutUint64(b, uint64(int64(v)))
+	}
+}
+
+// parseHeader returns the type, the order and the dimensions of the array described by the header dictionary.
+func parseHeader(header string) (dtype, bool, int, int, error) {
+	descr, fortran, shape := descrPattern.FindStringSubmatch(header), fortranPattern.FindStringSubmatch(header), shapePattern.FindStringSubmatch(header)
+	if descr == nil || fortran == nil || shape == nil {
+		return dtype{}, false, 0, 0, ErrBadHeader
+	}
+
+	d, err := parseDtype(descr[1])
+	if err != nil {
+		return dtype{}, false, 0, 0, err
+	}
+
+	dims := []int{}
+	for _, s := range strings.Split(shape[1], ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		dim, err := strconv.Atoi(strings.TrimSuffix(s, "L"))
+		if err != nil || dim < 0 {
+			return dtype{}, false, 0, 0, ErrBadHeader
+		}
+
+		dims = append(dims, dim)
+	}
+
+	rows, cols := 1, 1
+	switch len(dims) {
+	case 0:
+	case 1:
+		rows = dims[0]
+	case 2:
+		rows, cols = dims[0], dims[1]
+	default:
+		return dtype{}, false, 0, 0, ErrUnsupportedShape
+	}
+
+	if rows == 0 || cols == 0 || rows > maxElements/cols {
+		return dtype{}, false, 0, 0, ErrUnsupportedShape
+	}
+
+	return d, fortran[1] == "True", rows, cols, nil
+}
+
+// Read reads a matrix in the .npy format from "r".
+// It will return an error if the data is not in the .npy format, its header is malformed or longer than the default limit of NumPy (10000 bytes),
+// or the array has more than two dimensions, no elements, too many elements to be addressed, or a non-numeric type.
+// It will also return the error of "r", or io.ErrUnexpectedEOF if the data is truncated.
+// The memory of the data is allocated as the data arrives, so a truncated input cannot allocate much more than its own size.
+func Read(r io.Reader) (*matrix.Matrix, error) {
+	prefix := make([]byte, len(magic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, err
+	}
+
+	if string(prefix[:len(magic)]) != magic {
+		return nil, ErrBadMagic
+	}
+
+	var headerLen int
+	switch prefix[len(magic)] {
+	case 1:
+		b := make([]byte, 2)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+
+		headerLen = int(binary.LittleEndian.Uint16(b))
+	case 2, 3:
+		b := make([]byte, 4)
+		if _, err := io.ReadFull(r, b); err != nil {
+			return nil, err
+		}
+
+		headerLen = int(binary.LittleEndian.Uint32(b))
+	default:
+		return nil, ErrUnsupportedVersion
+	}
+
+	if headerLen > maxHeaderLen {
+		return nil, ErrBadHeader
+	}
+
+	header := make([]byte, headerLen)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	d, fortran, rows, cols, err := parseHeader(string(header))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := readData(r, rows*cols*d.size)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := matrix.New(rows, cols, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx := range m.Values {
+		dst := idx
+		if fortran {
+			dst = (idx%rows)*cols + idx/rows
+		}
+
+		m.Values[dst] = d.decode(data[idx*d.size:])
+	}
+
+	return m, nil
+}
+
+// readData reads the "n" bytes of the data from "r", the buffer grows from at most "dataChunk" bytes as the data arrives.
+// It will return the error of "r", or io.ErrUnexpectedEOF if there are fewer than "n" bytes.
+func readData(r io.Reader, n int) ([]byte, error) {
+	size := n
+	if size > dataChunk {
+		size = dataChunk
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, size))
+	if _, err := io.CopyN(buf, r, int64(n)); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Write writes "m" to "w" in the .npy format, with float64 elements in C order.
+// It will return an error if "m == nil", or the error of "w".
+func Write(w io.Writer, m *matrix.Matrix) error {
+	return WriteAs(w, m, Float64, false)
+}
+
+// WriteAs writes "m" to "w" in the .npy format, with elements of the type "t", in Fortran order if "fortran" is true, otherwise in C order.
+// The elements are truncated toward zero when "t" is an integer type.
+// It will return an error if "m == nil", "t" is not one of the types of Write, or the error of "w".
+func WriteAs(w io.Writer, m *matrix.Matrix, t Dtype, fortran bool) error {
+	if m == nil {
+		return ErrNilMatrix
+	}
+
+	if t != Float64 && t != Float32 && t != Int64 && t != Int32 {
+		return ErrUnsupportedDtype
+	}
+
+	d, _ := parseDtype(string(t))
+	order := "False"
+	if fortran {
+		order = "True"
+	}
+
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': (%d, %d), }", t, order, m.Rows, m.Columns)
+	total := len(magic) + 2 + 2 + len(header) + 1
+	header += strings.Repeat(" ", (headerAlignment-total%headerAlignment)%headerAlignment) + "\n"
+
+	buf := &bytes.Buffer{}
+	buf.WriteString(magic)
+	buf.Write([]byte{1, 0})
+	binary.Write(buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+
+	data := make([]byte, m.Rows*m.Columns*d.size)
+	idx := 0
+	for outer := 0; outer < m.Rows*m.Columns; outer++ {
+		r, c := outer/m.Columns, outer%m.Columns
+		if fortran {
+			r, c = outer%m.Rows, outer/m.Rows
+		}
+
+		v, _ := m.At(r, c)
+		d.encode(data[idx:], v)
+		idx += d.size
+	}
+	buf.Write(data)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,24 @@
+package npy_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/azuwey/gonetwork/ann"
+	"github.com/azuwey/gonetwork/matrix"
+	"github.com/azuwey/gonetwork/npy"
+)
+
+func Example() {
+	// The weights of a layer with 2 nodes and 3 inputs, saved with "numpy.save".
+	weights, _ := matrix.New(2, 3, []float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6})
+	buf := &bytes.Buffer{}
+	npy.Write(buf, weights)
+
+	loaded, _ := npy.Read(buf)
+	layer := ann.LayerDescriptor{Nodes: loaded.Rows, ActivationFunction: "LogisticSigmoid", Weights: loaded.Values}
+
+	fmt.Println(layer.Nodes, layer.Weights)
+	// Output:
+	// 2 [0.1 0.2 0.3 0.4 0.5 0.6]
+}
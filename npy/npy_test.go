@@ -0,0 +1,288 @@
+package npy
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// npyBytes returns a version 1.0 .npy file with the "header" dictionary and the raw "data".
+func npyBytes(header string, data ...interface{}) []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString(magic)
+	buf.Write([]byte{1, 0})
+	binary.Write(buf, binary.LittleEndian, uint16(len(header)+1))
+	buf.WriteString(header + "\n")
+	for _, d := range data {
+		order := binary.ByteOrder(binary.LittleEndian)
+		if b, ok := d.(bigEndian); ok {
+			order, d = binary.BigEndian, b.v
+		}
+
+		binary.Write(buf, order, d)
+	}
+
+	return buf.Bytes()
+}
+
+// bigEndian marks the data that npyBytes writes in big-endian byte order.
+type bigEndian struct{ v interface{} }
+
+func TestRead(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		data                       []byte
+		expectedRows, expectedCols int
+		expectedValues             []float64
+		expectedError              error
+	}{
+		{"float64", npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }", []float64{0, 1, 2, 3, 4, 5}), 2, 3, []float64{0, 1, 2, 3, 4, 5}, nil},
+		{"float32", npyBytes("{'descr': '<f4', 'fortran_order': False, 'shape': (1, 2), }", []float32{0.5, -2}), 1, 2, []float64{0.5, -2}, nil},
+		{"float64 big-endian", npyBytes("{'descr': '>f8', 'fortran_order': False, 'shape': (2,), }", bigEndian{[]float64{1.5, -3}}), 2, 1, []float64{1.5, -3}, nil},
+		{"int64", npyBytes("{'descr': '<i8', 'fortran_order': False, 'shape': (2, 2), }", []int64{-1, 2, -3, 4}), 2, 2, []float64{-1, 2, -3, 4}, nil},
+		{"int32 big-endian", npyBytes("{'descr': '>i4', 'fortran_order': False, 'shape': (3,), }", bigEndian{[]int32{-7, 0, 7}}), 3, 1, []float64{-7, 0, 7}, nil},
+		{"int16", npyBytes("{'descr': '<i2', 'fortran_order': False, 'shape': (2,), }", []int16{-300, 300}), 2, 1, []float64{-300, 300}, nil},
+		{"int8", npyBytes("{'descr': '|i1', 'fortran_order': False, 'shape': (2,), }", []int8{-128, 127}), 2, 1, []float64{-128, 127}, nil},
+		{"uint8", npyBytes("{'descr': '|u1', 'fortran_order': False, 'shape': (2,), }", []uint8{0, 255}), 2, 1, []float64{0, 255}, nil},
+		{"Fortran order", npyBytes("{'descr': '<f8', 'fortran_order': True, 'shape': (2, 3), }", []float64{0, 3, 1, 4, 2, 5}), 2, 3, []float64{0, 1, 2, 3, 4, 5}, nil},
+		{"Scalar", npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (), }", []float64{42}), 1, 1, []float64{42}, nil},
+		{"ErrBadMagic", []byte("\x93NUMPX\x01\x00\x00\x00"), 0, 0, nil, ErrBadMagic},
+		{"ErrUnsupportedVersion", []byte("\x93NUMPY\x04\x00\x00\x00"), 0, 0, nil, ErrUnsupportedVersion},
+		{"ErrBadHeader", npyBytes("{'descr': '<f8', 'shape': (2, 3), }"), 0, 0, nil, ErrBadHeader},
+		{"ErrUnsupportedDtype", npyBytes("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }"), 0, 0, nil, ErrUnsupportedDtype},
+		{"ErrUnsupportedShape rank", npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (1, 1, 1), }"), 0, 0, nil, ErrUnsupportedShape},
+		{"ErrUnsupportedShape empty", npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (0,), }"), 0, 0, nil, ErrUnsupportedShape},
+		{"io.ErrUnexpectedEOF", npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2,), }", []float64{1}), 0, 0, nil, io.ErrUnexpectedEOF},
+		{"io.EOF", nil, 0, 0, nil, io.EOF},
+		{"ErrBadHeader length", []byte("\x93NUMPY\x02\x00\xff\xff\xff\xff{}"), 0, 0, nil, ErrBadHeader},
+		{"ErrUnsupportedShape overflow", npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (4000000000, 4000000000), }"), 0, 0, nil, ErrUnsupportedShape},
+		{"ErrUnsupportedShape huge", npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (9223372036854775807,), }"), 0, 0, nil, ErrUnsupportedShape},
+		{"io.ErrUnexpectedEOF huge shape", npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (100000000, 1000), }", []float64{1, 2}), 0, 0, nil, io.ErrUnexpectedEOF},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := Read(bytes.NewReader(tc.data))
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if m.Rows != tc.expectedRows || m.Columns != tc.expectedCols {
+					t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.expectedRows, tc.expectedCols, m.Rows, m.Columns)
+				}
+
+				for idx, v := range m.Values {
+					if v != tc.expectedValues[idx] {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+					}
+				}
+			}
+		})
+	}
+
+	t.Run("Version 2.0", func(t *testing.T) {
+		t.Parallel()
+
+		header := "{'descr': '<f8', 'fortran_order': False, 'shape': (1,), }\n"
+		buf := &bytes.Buffer{}
+		buf.WriteString(magic)
+		buf.Write([]byte{2, 0})
+		binary.Write(buf, binary.LittleEndian, uint32(len(header)))
+		buf.WriteString(header)
+		binary.Write(buf, binary.LittleEndian, []float64{7})
+
+		m, err := Read(buf)
+		if err != nil || m.Values[0] != 7 {
+			t.Errorf("Expected value is %f, but got %v (error %v)", 7.0, m, err)
+		}
+	})
+}
+
+// TestRead_malformed checks that a header with a huge shape and a truncated body does not allocate the whole shape.
+func TestRead_malformed(t *testing.T) {
+	data := npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (100000000, 1000), }", []float64{1, 2})
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err := Read(bytes.NewReader(data))
+	runtime.ReadMemStats(&after)
+
+	if err != io.ErrUnexpectedEOF {
+		t.Fatalf("Expected error is %v, but got %v", io.ErrUnexpectedEOF, err)
+	}
+
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 4*dataChunk {
+		t.Errorf("Expected allocated bytes are at most %d, but got %d", 4*dataChunk, allocated)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	m, _ := matrix.New(2, 3, []float64{0, 1.5, -2, 3, 4, 5})
+	buf := &bytes.Buffer{}
+	if err := Write(buf, m); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	// The header is the same as the one of "numpy.save".
+	data := buf.Bytes()
+	header := "{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }"
+	if !bytes.HasPrefix(data[10:], []byte(header)) {
+		t.Errorf("Expected header is %q, but got %q", header, data[10:10+len(header)])
+	}
+
+	if headerLen := int(binary.LittleEndian.Uint16(data[8:])); (10+headerLen)%headerAlignment != 0 || data[9+headerLen] != '\n' {
+		t.Errorf("Expected the data to start at a multiple of %d bytes, but it starts at %d", headerAlignment, 10+headerLen)
+	}
+
+	if err := Write(buf, nil); err != ErrNilMatrix {
+		t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+	}
+
+	if err := WriteAs(buf, m, Dtype("<c16"), false); err != ErrUnsupportedDtype {
+		t.Errorf("Expected error is %v, but got %v", ErrUnsupportedDtype, err)
+	}
+}
+
+func TestWriteAs(t *testing.T) {
+	m, _ := matrix.New(2, 3, []float64{0, 1.5, -2.5, 3, 4, math.MaxInt32})
+	testCases := []struct {
+		name           string
+		dtype          Dtype
+		fortran        bool
+		expectedValues []float64
+	}{
+		{"float64", Float64, false, m.Values},
+		{"float64 Fortran order", Float64, true, m.Values},
+		{"float32", Float32, false, []float64{0, 1.5, -2.5, 3, 4, float64(float32(math.MaxInt32))}},
+		{"int64", Int64, true, []float64{0, 1, -2, 3, 4, math.MaxInt32}},
+		{"int32", Int32, false, []float64{0, 1, -2, 3, 4, math.MaxInt32}},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := &bytes.Buffer{}
+			if err := WriteAs(buf, m, tc.dtype, tc.fortran); err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			r, err := Read(buf)
+			if err != nil {
+				t.Fatalf("Expected error is %v, but got %v", nil, err)
+			}
+
+			if r.Rows != m.Rows || r.Columns != m.Columns {
+				t.Errorf("Expected dimensions are %dx%d, but got %dx%d", m.Rows, m.Columns, r.Rows, r.Columns)
+			}
+
+			for idx, v := range r.Values {
+				if v != tc.expectedValues[idx] {
+					t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+				}
+			}
+		})
+	}
+}
+
+func TestNPZ(t *testing.T) {
+	weights, _ := matrix.New(2, 2, []float64{1, 2, 3, 4})
+	biases, _ := matrix.New(2, 1, []float64{-1, 1})
+	arrays := map[string]*matrix.Matrix{"weights": weights, "biases": biases}
+
+	t.Run("Stored", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		if err := WriteNPZ(buf, arrays); err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		read, err := ReadNPZ(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if len(read) != len(arrays) {
+			t.Fatalf("Expected number of arrays is %d, but got %d", len(arrays), len(read))
+		}
+
+		for name, m := range arrays {
+			if r, ok := read[name]; !ok || r.Rows != m.Rows || r.Columns != m.Columns {
+				t.Errorf("Expected array %q to be %v, but got %v", name, m, r)
+			}
+		}
+	})
+
+	t.Run("Compressed", func(t *testing.T) {
+		t.Parallel()
+
+		buf := &bytes.Buffer{}
+		zw := zip.NewWriter(buf)
+		fw, _ := zw.CreateHeader(&zip.FileHeader{Name: "arr_0.npy", Method: zip.Deflate})
+		fw.Write(npyBytes("{'descr': '<i8', 'fortran_order': False, 'shape': (3,), }", []int64{1, 2, 3}))
+		zw.Close()
+
+		read, err := ReadNPZ(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatalf("Expected error is %v, but got %v", nil, err)
+		}
+
+		if m := read["arr_0"]; m == nil || m.Rows != 3 || m.Values[2] != 3 {
+			t.Errorf("Expected array is %v, but got %v", []float64{1, 2, 3}, m)
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		t.Parallel()
+
+		if err := WriteNPZ(&bytes.Buffer{}, map[string]*matrix.Matrix{"nil": nil}); err != ErrNilMatrix {
+			t.Errorf("Expected error is %v, but got %v", ErrNilMatrix, err)
+		}
+
+		if _, err := ReadNPZ(bytes.NewReader([]byte("not a zip")), 9); err != zip.ErrFormat {
+			t.Errorf("Expected error is %v, but got %v", zip.ErrFormat, err)
+		}
+	})
+}
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	m, _ := matrix.New(1, 3, []float64{1, 2, 3})
+
+	name := filepath.Join(dir, "m.npy")
+	if err := Save(name, m); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if r, err := Load(name); err != nil || r.Columns != 3 || r.Values[2] != 3 {
+		t.Errorf("Expected matrix is %v, but got %v (error %v)", m, r, err)
+	}
+
+	name = filepath.Join(dir, "m.npz")
+	if err := SaveNPZ(name, map[string]*matrix.Matrix{"m": m}); err != nil {
+		t.Fatalf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if arrays, err := LoadNPZ(name); err != nil || arrays["m"] == nil || arrays["m"].Values[1] != 2 {
+		t.Errorf("Expected matrix is %v, but got %v (error %v)", m, arrays, err)
+	}
+
+	if _, err := Load(filepath.Join(dir, "missing.npy")); err == nil {
+		t.Error("Error should not be nil")
+	}
+}
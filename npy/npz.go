@@ -0,0 +1,133 @@
+package npy
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// ReadNPZ reads all the arrays of a .npz archive from "r", which has "size" bytes.
+// The arrays are returned by their names without the ".npy" extension, the same way as NumPy does, both the stored and the compressed archives are supported.
+// It will return an error if the data is not a zip archive, or any of its arrays cannot be read by Read.
+func ReadNPZ(r io.ReaderAt, size int64) (map[string]*matrix.Matrix, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	arrays := make(map[string]*matrix.Matrix, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		m, err := Read(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		arrays[strings.TrimSuffix(f.Name, ".npy")] = m
+	}
+
+	return arrays, nil
+}
+
+// WriteNPZ writes "arrays" to "w" as an uncompressed .npz archive, the same way as "numpy.savez" does, with float64 elements in C order.
+// The arrays are written in the order of their names, and the ".npy" extension is added to the names.
+// It will return an error if any of the arrays is nil, or the error of "w".
+func WriteNPZ(w io.Writer, arrays map[string]*matrix.Matrix) error {
+	names := make([]string, 0, len(arrays))
+	for name, m := range arrays {
+		if m == nil {
+			return ErrNilMatrix
+		}
+
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: name + ".npy", Method: zip.Store})
+		if err != nil {
+			return err
+		}
+
+		if err := Write(fw, arrays[name]); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// Load reads a matrix from the .npy file "name".
+// It will return the same errors as Read, or the error of opening the file.
+func Load(name string) (*matrix.Matrix, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Read(f)
+}
+
+// Save writes "m" into the .npy file "name" the same way as Write, the file is created or truncated.
+// It will return the same errors as Write, or the error of creating the file.
+func Save(name string, m *matrix.Matrix) error {
+	if m == nil {
+		return ErrNilMatrix
+	}
+
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := Write(f, m); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// LoadNPZ reads all the arrays of the .npz file "name".
+// It will return the same errors as ReadNPZ, or the error of opening the file.
+func LoadNPZ(name string) (map[string]*matrix.Matrix, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadNPZ(f, info.Size())
+}
+
+// SaveNPZ writes "arrays" into the .npz file "name" the same way as WriteNPZ, the file is created or truncated.
+// It will return the same errors as WriteNPZ, or the error of creating the file.
+func SaveNPZ(name string, arrays map[string]*matrix.Matrix) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if err := WriteNPZ(f, arrays); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
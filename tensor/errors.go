@@ -0,0 +1,33 @@
+package tensor
+
+import "errors"
+
+// ErrEmptyShape is returned by New and Reshape when the shape has no dimensions.
+var ErrEmptyShape = errors.New("tensor: shape must have at least one dimension")
+
+// ErrZeroDimension is returned by New and Reshape when the size of a dimension is equal to, or less than zero.
+var ErrZeroDimension = errors.New("tensor: size of each dimension must be greater than zero")
+
+// ErrDataLength is returned by New when length of data is not equal to the product of the dimensions.
+var ErrDataLength = errors.New("tensor: length of the data must be equal to the product of the dimensions")
+
+// ErrNilTensor is returned by any operation that is require a tensor as argument.
+var ErrNilTensor = errors.New("tensor: tensor must not be nil")
+
+// ErrNilMatrix is returned by FromMatrix when `m` is nil.
+var ErrNilMatrix = errors.New("tensor: matrix must not be nil")
+
+// ErrBadRank is returned by At, Set and Batch when the number of indices does not match the rank of the tensor.
+var ErrBadRank = errors.New("tensor: number of indices must be equal to the rank of the tensor")
+
+// ErrIndexOutOfBounds is returned by At, Set and Batch when an index is out of bounds.
+var ErrIndexOutOfBounds = errors.New("tensor: index out of bounds")
+
+// ErrReshapeSize is returned by Reshape when the number of elements of the new shape is not equal to the number of elements of the tensor.
+var ErrReshapeSize = errors.New("tensor: the new shape must have the same number of elements")
+
+// ErrInferDimension is returned by Reshape when more than one dimension is `-1`, or the size of the inferred dimension is not an integer.
+var ErrInferDimension = errors.New("tensor: only one dimension can be inferred")
+
+// ErrNotContiguous is returned by Reshape when the tensor is not stored contiguously in row-major order.
+var ErrNotContiguous = errors.New("tensor: tensor must be contiguous")
@@ -0,0 +1,285 @@
+package tensor
+
+import (
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+// Tensor represents an N-dimensional array.
+// By convention, the tensors passed between layers have the shape "[batch, rows, columns, depth]",
+// so the elements of a single sample are interleaved by depth (e.g. RGBA pixels).
+type Tensor struct {
+	Values  []float64 // Values of the tensor
+	Shape   []int     // Size of each dimension
+	Strides []int     // Distance in Values between two consecutive elements of each dimension, nil means row-major order
+}
+
+// rowMajorStrides returns the strides of a contiguous, row-major tensor with the shape "s".
+func rowMajorStrides(s []int) []int {
+	strides := make([]int, len(s))
+	stride := 1
+	for idx := len(s) - 1; idx >= 0; idx-- {
+		strides[idx] = stride
+		stride *= s[idx]
+	}
+
+	return strides
+}
+
+// size returns the number of elements of a tensor with the shape "s".
+func size(s []int) int {
+	n := 1
+	for _, d := range s {
+		n *= d
+	}
+
+	return n
+}
+
+// validateShape returns an error if "s" is not a valid shape.
+func validateShape(s []int) error {
+	if len(s) == 0 {
+		return ErrEmptyShape
+	}
+
+	for _, d := range s {
+		if d <= 0 {
+			return ErrZeroDimension
+		}
+	}
+
+	return nil
+}
+
+// New creates a new Tensor with the shape "s", the "vals" must be arranged in row-major order.
+// If "vals == nil", a new slice will be allocated with the size of the product of the dimensions.
+// If "vals" is not nil it will be copied, so the changes won't be reflected.
+// It will return an error if the shape is empty, any of the dimensions is "<= 0", or the length of the "vals" does not match the shape.
+func New(s []int, vals []float64) (*Tensor, error) {
+	if err := validateShape(s); err != nil {
+		return nil, err
+	}
+
+	n := size(s)
+	if vals != nil && len(vals) != n {
+		return nil, ErrDataLength
+	}
+
+	t := &Tensor{make([]float64, n), append([]int(nil), s...), rowMajorStrides(s)}
+	copy(t.Values, vals)
+
+	return t, nil
+}
+
+// Copy creates a new, contiguous Tensor with the same shape and elements as "t".
+// It will return an error if "t == nil".
+func Copy(t *Tensor) (*Tensor, error) {
+	if t == nil {
+		return nil, ErrNilTensor
+	}
+
+	nt, err := New(t.Shape, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := 0
+	t.each(func(offset int) {
+		nt.Values[idx] = t.Values[offset]
+		idx++
+	})
+
+	return nt, nil
+}
+
+// FromMatrix creates a new rank 2 Tensor with the shape "[rows, columns]" from "m".
+// The tensor shares the underlying slice with the matrix, so the changes will be reflected, if "m" is a view the tensor will have the same stride.
+// It will return an error if "m == nil".
+func FromMatrix(m *matrix.Matrix) (*Tensor, error) {
+	if m == nil {
+		return nil, ErrNilMatrix
+	}
+
+	return &Tensor{m.Values, []int{m.Rows, m.Columns}, []int{m.Stride(), 1}}, nil
+}
+
+// Matrix returns a Matrix where all the dimensions except the last one are flattened into the rows,
+// and the last dimension is used as the columns, e.g. a tensor with the shape "[1, 4, 1, 1]" becomes a 4 x 1 matrix.
+// If the tensor is contiguous the matrix shares the underlying slice with the tensor, otherwise the elements are copied.
+func (t *Tensor) Matrix() (*matrix.Matrix, error) {
+	if err := validateShape(t.Shape); err != nil {
+		return nil, err
+	}
+
+	src := t
+	if !t.IsContiguous() {
+		src, _ = Copy(t)
+	}
+
+	cols := src.Shape[len(src.Shape)-1]
+	n := size(src.Shape)
+	if len(src.Values) < n {
+		return nil, ErrDataLength
+	}
+
+	return &matrix.Matrix{Values: src.Values[:n], Rows: n / cols, Columns: cols}, nil
+}
+
+// Rank returns the number of dimensions of the tensor.
+func (t *Tensor) Rank() int {
+	return len(t.Shape)
+}
+
+// Size returns the number of elements of the tensor.
+func (t *Tensor) Size() int {
+	return size(t.Shape)
+}
+
+// strides returns the strides of the tensor, falling back to the row-major strides when they are not set.
+func (t *Tensor) strides() []int {
+	if t.Strides == nil {
+		return rowMajorStrides(t.Shape)
+	}
+
+	return t.Strides
+}
+
+// IsContiguous reports whether the elements of the tensor are stored in row-major order without gaps.
+func (t *Tensor) IsContiguous() bool {
+	if t.Strides == nil {
+		return true
+	}
+
+	stride := 1
+	for idx := len(t.Shape) - 1; idx >= 0; idx-- {
+		if t.Shape[idx] != 1 && t.Strides[idx] != stride {
+			return false
+		}
+
+		stride *= t.Shape[idx]
+	}
+
+	return true
+}
+
+// offset returns the position of the element at "idx" in Values.
+func (t *Tensor) offset(idx []int) (int, error) {
+	if len(idx) != len(t.Shape) {
+		return 0, ErrBadRank
+	}
+
+	strides := t.strides()
+	offset := 0
+	for d, i := range idx {
+		if i < 0 || i >= t.Shape[d] {
+			return 0, ErrIndexOutOfBounds
+		}
+
+		offset += i * strides[d]
+	}
+
+	return offset, nil
+}
+
+// each calls "fn" with the position in Values of every element of the tensor, in row-major order.
+func (t *Tensor) each(fn func(offset int)) {
+	strides := t.strides()
+	idx := make([]int, len(t.Shape))
+	offset := 0
+	for n := size(t.Shape); n > 0; n-- {
+		fn(offset)
+
+		for d := len(idx) - 1; d >= 0; d-- {
+			idx[d]++
+			offset += strides[d]
+			if idx[d] < t.Shape[d] {
+				break
+			}
+
+			offset -= idx[d] * strides[d]
+			idx[d] = 0
+		}
+	}
+}
+
+// At returns the element at the position "idx", where every dimension has a zero-based index.
+// It will return an error if the number of indices does not match the rank, or any of the indices is out of bounds.
+func (t *Tensor) At(idx ...int) (float64, error) {
+	offset, err := t.offset(idx)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Values[offset], nil
+}
+
+// Set sets the element at the position "idx" to "v", where every dimension has a zero-based index.
+// It will return an error if the number of indices does not match the rank, or any of the indices is out of bounds.
+func (t *Tensor) Set(v float64, idx ...int) error {
+	offset, err := t.offset(idx)
+	if err != nil {
+		return err
+	}
+
+	t.Values[offset] = v
+
+	return nil
+}
+
+// Reshape returns a new Tensor with the shape "s" that shares the underlying slice with "t".
+// One of the dimensions can be "-1", in that case its size is inferred from the number of elements.
+// It will return an error if the tensor is not contiguous, or the number of elements does not match.
+func (t *Tensor) Reshape(s ...int) (*Tensor, error) {
+	if !t.IsContiguous() {
+		return nil, ErrNotContiguous
+	}
+
+	s = append([]int(nil), s...)
+	inferred, known := -1, 1
+	for idx, d := range s {
+		if d == -1 {
+			if inferred != -1 {
+				return nil, ErrInferDimension
+			}
+
+			inferred = idx
+			continue
+		}
+
+		known *= d
+	}
+
+	n := t.Size()
+	if inferred != -1 {
+		if known <= 0 || n%known != 0 {
+			return nil, ErrInferDimension
+		}
+
+		s[inferred] = n / known
+	}
+
+	if err := validateShape(s); err != nil {
+		return nil, err
+	}
+
+	if size(s) != n {
+		return nil, ErrReshapeSize
+	}
+
+	return &Tensor{t.Values, s, rowMajorStrides(s)}, nil
+}
+
+// Batch returns the "i"-th element along the first (batch) dimension as a tensor with one less dimension,
+// the returned tensor shares the underlying slice with "t".
+// It will return an error if the tensor has less than two dimensions, or "i" is out of bounds.
+func (t *Tensor) Batch(i int) (*Tensor, error) {
+	if len(t.Shape) < 2 {
+		return nil, ErrBadRank
+	}
+
+	if i < 0 || i >= t.Shape[0] {
+		return nil, ErrIndexOutOfBounds
+	}
+
+	strides := t.strides()
+	return &Tensor{t.Values[i*strides[0]:], append([]int(nil), t.Shape[1:]...), append([]int(nil), strides[1:]...)}, nil
+}
@@ -0,0 +1,46 @@
+package tensor_test
+
+import (
+	"fmt"
+
+	"github.com/azuwey/gonetwork/matrix"
+	"github.com/azuwey/gonetwork/tensor"
+)
+
+func ExampleNew() {
+	// Create a batch of one 2 x 2 px RGBA image.
+	t, _ := tensor.New([]int{1, 2, 2, 4}, []float64{
+		255, 0, 0, 1, 0, 255, 0, 1,
+		0, 0, 255, 1, 255, 255, 255, 1,
+	})
+
+	// Blue channel of the pixel in the second row, first column.
+	v, _ := t.At(0, 1, 0, 2)
+
+	fmt.Println(t.Shape, t.Strides)
+	fmt.Println(v)
+	// Output:
+	// [1 2 2 4] [16 8 4 1]
+	// 255
+}
+
+func Example_reshape() {
+	t, _ := tensor.New([]int{2, 3}, []float64{0, 1, 2, 3, 4, 5})
+
+	r, _ := t.Reshape(3, -1)
+	fmt.Println(r.Shape)
+	// Output:
+	// [3 2]
+}
+
+func Example_matrix() {
+	mat, _ := matrix.New(2, 3, []float64{0, 1, 2, 3, 4, 5})
+
+	t, _ := tensor.FromMatrix(mat)
+	b, _ := t.Reshape(1, 2, 3, 1)
+
+	m, _ := b.Matrix()
+	fmt.Println(m.Rows, m.Columns, m.Values)
+	// Output:
+	// 6 1 [0 1 2 3 4 5]
+}
@@ -0,0 +1,345 @@
+package tensor
+
+import (
+	"testing"
+
+	"github.com/azuwey/gonetwork/matrix"
+)
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name                   string
+		shape                  []int
+		values, expectedValues []float64
+		expectedStrides        []int
+		expectedError          error
+	}{
+		{"Normal", []int{2, 3}, []float64{0, 1, 2, 3, 4, 5}, []float64{0, 1, 2, 3, 4, 5}, []int{3, 1}, nil},
+		{"Zero", []int{2, 1, 2}, nil, []float64{0, 0, 0, 0}, []int{2, 2, 1}, nil},
+		{"Batch", []int{1, 2, 2, 4}, make([]float64, 16), make([]float64, 16), []int{16, 8, 4, 1}, nil},
+		{"ErrEmptyShape", []int{}, nil, nil, nil, ErrEmptyShape},
+		{"ErrZeroDimension", []int{2, 0}, nil, nil, nil, ErrZeroDimension},
+		{"ErrDataLength", []int{2, 2}, []float64{0}, nil, nil, ErrDataLength},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tn, err := New(tc.shape, tc.values)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				for idx, v := range tn.Values {
+					if v != tc.expectedValues[idx] {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+					}
+				}
+
+				if !equalInts(tn.Shape, tc.shape) {
+					t.Errorf("Expected shape is %v, but got %v", tc.shape, tn.Shape)
+				}
+
+				if !equalInts(tn.Strides, tc.expectedStrides) {
+					t.Errorf("Expected strides are %v, but got %v", tc.expectedStrides, tn.Strides)
+				}
+			}
+		})
+	}
+
+	t.Run("Data reflection", func(t *testing.T) {
+		t.Parallel()
+
+		v := []float64{1, 2}
+		s := []int{2}
+		tn, _ := New(s, v)
+		v[0], s[0] = 3, 1
+		if tn.Values[0] != 1 || tn.Shape[0] != 2 {
+			t.Errorf("Expected value and shape are %f, %d, but got %f, %d", 1.0, 2, tn.Values[0], tn.Shape[0])
+		}
+	})
+}
+
+func TestCopy(t *testing.T) {
+	testCases := []struct {
+		name           string
+		tensor         *Tensor
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"Contiguous", &Tensor{[]float64{0, 1, 2, 3}, []int{2, 2}, nil}, []float64{0, 1, 2, 3}, nil},
+		{"Strided", &Tensor{[]float64{0, 1, 2, 3, 4, 5}, []int{2, 2}, []int{3, 2}}, []float64{0, 2, 3, 5}, nil},
+		{"ErrNilTensor", nil, nil, ErrNilTensor},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tn, err := Copy(tc.tensor)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				for idx, v := range tn.Values {
+					if v != tc.expectedValues[idx] {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+					}
+				}
+
+				if !tn.IsContiguous() {
+					t.Error("Tensor should be contiguous")
+				}
+			}
+		})
+	}
+}
+
+func TestFromMatrix(t *testing.T) {
+	testCases := []struct {
+		name          string
+		matrix        *matrix.Matrix
+		expectedShape []int
+		expectedError error
+	}{
+		{"Normal", &matrix.Matrix{Values: []float64{0, 1, 2, 3, 4, 5}, Rows: 2, Columns: 3}, []int{2, 3}, nil},
+		{"ErrNilMatrix", nil, nil, ErrNilMatrix},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tn, err := FromMatrix(tc.matrix)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if !equalInts(tn.Shape, tc.expectedShape) {
+					t.Errorf("Expected shape is %v, but got %v", tc.expectedShape, tn.Shape)
+				}
+
+				v, _ := tn.At(1, 2)
+				if v != 5 {
+					t.Errorf("Expected value is %f, but got %f", 5.0, v)
+				}
+
+				tc.matrix.Values[5] = 6
+				if v, _ = tn.At(1, 2); v != 6 {
+					t.Errorf("Expected value is %f, but got %f", 6.0, v)
+				}
+			}
+		})
+	}
+}
+
+func TestMatrix(t *testing.T) {
+	testCases := []struct {
+		name                       string
+		tensor                     *Tensor
+		expectedRows, expectedCols int
+		expectedValues             []float64
+		expectedError              error
+	}{
+		{"Rank 2", &Tensor{[]float64{0, 1, 2, 3, 4, 5}, []int{2, 3}, nil}, 2, 3, []float64{0, 1, 2, 3, 4, 5}, nil},
+		{"Rank 4", &Tensor{[]float64{0, 1, 2, 3}, []int{1, 4, 1, 1}, nil}, 4, 1, []float64{0, 1, 2, 3}, nil},
+		{"Rank 1", &Tensor{[]float64{0, 1, 2}, []int{3}, nil}, 1, 3, []float64{0, 1, 2}, nil},
+		{"Strided", &Tensor{[]float64{0, 1, 2, 3, 4, 5}, []int{2, 2}, []int{3, 1}}, 2, 2, []float64{0, 1, 3, 4}, nil},
+		{"ErrEmptyShape", &Tensor{[]float64{0}, []int{}, nil}, 0, 0, nil, ErrEmptyShape},
+		{"ErrDataLength", &Tensor{[]float64{0}, []int{2, 1}, nil}, 0, 0, nil, ErrDataLength},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			m, err := tc.tensor.Matrix()
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if m.Rows != tc.expectedRows || m.Columns != tc.expectedCols {
+					t.Errorf("Expected dimensions are %dx%d, but got %dx%d", tc.expectedRows, tc.expectedCols, m.Rows, m.Columns)
+				}
+
+				for idx, v := range m.Values {
+					if v != tc.expectedValues[idx] {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAt(t *testing.T) {
+	tn := &Tensor{[]float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}, []int{2, 3, 2}, nil}
+	testCases := []struct {
+		name          string
+		idx           []int
+		expectedValue float64
+		expectedError error
+	}{
+		{"First", []int{0, 0, 0}, 0, nil},
+		{"Middle", []int{1, 1, 0}, 8, nil},
+		{"Last", []int{1, 2, 1}, 11, nil},
+		{"ErrBadRank", []int{1, 2}, 0, ErrBadRank},
+		{"ErrIndexOutOfBounds", []int{0, 3, 0}, 0, ErrIndexOutOfBounds},
+		{"ErrIndexOutOfBounds negative", []int{0, -1, 0}, 0, ErrIndexOutOfBounds},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := tn.At(tc.idx...)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else if v != tc.expectedValue {
+				t.Errorf("Expected value is %f, but got %f", tc.expectedValue, v)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	tn, _ := New([]int{2, 2}, nil)
+	if err := tn.Set(3, 1, 0); err != nil {
+		t.Errorf("Expected error is %v, but got %v", nil, err)
+	}
+
+	if tn.Values[2] != 3 {
+		t.Errorf("Expected value is %f, but got %f", 3.0, tn.Values[2])
+	}
+
+	if err := tn.Set(3, 2, 0); err != ErrIndexOutOfBounds {
+		t.Errorf("Expected error is %v, but got %v", ErrIndexOutOfBounds, err)
+	}
+}
+
+func TestReshape(t *testing.T) {
+	testCases := []struct {
+		name                           string
+		tensor                         *Tensor
+		shape                          []int
+		expectedShape, expectedStrides []int
+		expectedError                  error
+	}{
+		{"Normal", &Tensor{make([]float64, 12), []int{3, 4}, nil}, []int{2, 3, 2}, []int{2, 3, 2}, []int{6, 2, 1}, nil},
+		{"Inferred", &Tensor{make([]float64, 12), []int{3, 4}, nil}, []int{-1, 6}, []int{2, 6}, []int{6, 1}, nil},
+		{"ErrReshapeSize", &Tensor{make([]float64, 12), []int{3, 4}, nil}, []int{5, 2}, nil, nil, ErrReshapeSize},
+		{"ErrInferDimension", &Tensor{make([]float64, 12), []int{3, 4}, nil}, []int{-1, 5}, nil, nil, ErrInferDimension},
+		{"ErrInferDimension twice", &Tensor{make([]float64, 12), []int{3, 4}, nil}, []int{-1, -1}, nil, nil, ErrInferDimension},
+		{"ErrZeroDimension", &Tensor{make([]float64, 12), []int{3, 4}, nil}, []int{0, 12}, nil, nil, ErrZeroDimension},
+		{"ErrNotContiguous", &Tensor{make([]float64, 12), []int{2, 2}, []int{4, 1}}, []int{4}, nil, nil, ErrNotContiguous},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tn, err := tc.tensor.Reshape(tc.shape...)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if !equalInts(tn.Shape, tc.expectedShape) {
+					t.Errorf("Expected shape is %v, but got %v", tc.expectedShape, tn.Shape)
+				}
+
+				if !equalInts(tn.Strides, tc.expectedStrides) {
+					t.Errorf("Expected strides are %v, but got %v", tc.expectedStrides, tn.Strides)
+				}
+
+				tc.tensor.Values[0] = 1
+				if tn.Values[0] != 1 {
+					t.Errorf("Expected value is %f, but got %f", 1.0, tn.Values[0])
+				}
+			}
+		})
+	}
+}
+
+func TestBatch(t *testing.T) {
+	tn, _ := New([]int{2, 2, 1, 2}, []float64{0, 1, 2, 3, 4, 5, 6, 7})
+	testCases := []struct {
+		name           string
+		tensor         *Tensor
+		idx            int
+		expectedShape  []int
+		expectedValues []float64
+		expectedError  error
+	}{
+		{"First", tn, 0, []int{2, 1, 2}, []float64{0, 1, 2, 3}, nil},
+		{"Second", tn, 1, []int{2, 1, 2}, []float64{4, 5, 6, 7}, nil},
+		{"ErrIndexOutOfBounds", tn, 2, nil, nil, ErrIndexOutOfBounds},
+		{"ErrBadRank", &Tensor{[]float64{0, 1}, []int{2}, nil}, 0, nil, nil, ErrBadRank},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := tc.tensor.Batch(tc.idx)
+			if tc.expectedError != nil {
+				if err != tc.expectedError {
+					t.Errorf("Expected error is %v, but got %v", tc.expectedError, err)
+				}
+			} else if err != nil {
+				t.Errorf("Expected error is %v, but got %v", nil, err)
+			} else {
+				if !equalInts(b.Shape, tc.expectedShape) {
+					t.Errorf("Expected shape is %v, but got %v", tc.expectedShape, b.Shape)
+				}
+
+				m, _ := b.Matrix()
+				for idx, v := range m.Values {
+					if v != tc.expectedValues[idx] {
+						t.Errorf("Expected value is %f, but got %f", tc.expectedValues[idx], v)
+					}
+				}
+			}
+		})
+	}
+}